@@ -0,0 +1,315 @@
+// Package ats estimates how well an applicant tracking system can read a
+// resume. It works on the extracted text only, so layout problems show up
+// as missing sections or contact details rather than being detected directly.
+package ats
+
+import (
+	"Inquiro/utils/redact"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Status string
+
+const (
+	StatusPass    Status = "pass"
+	StatusWarn    Status = "warn"
+	StatusFail    Status = "fail"
+	StatusSkipped Status = "skipped"
+)
+
+// Check weights add up to 100. Skipped checks are left out of the total and
+// the score is scaled back to 100.
+const (
+	weightSections = 25
+	weightContact  = 15
+	weightDates    = 15
+	weightLength   = 15
+	weightKeywords = 30
+)
+
+type Check struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   Status `json:"status"`
+	Score    int    `json:"score"`
+	MaxScore int    `json:"max_score"`
+	Message  string `json:"message"`
+}
+
+type KeywordHit struct {
+	Keyword string `json:"keyword"`
+	Count   int    `json:"count"`
+}
+
+type KeywordReport struct {
+	Matched  []KeywordHit `json:"matched"`
+	Missing  []string     `json:"missing"`
+	Coverage float64      `json:"coverage"`
+	Density  float64      `json:"density"`
+}
+
+type Report struct {
+	Score     int             `json:"score"`
+	Grade     string          `json:"grade"`
+	WordCount int             `json:"word_count"`
+	Sections  map[string]bool `json:"sections"`
+	Checks    []Check         `json:"checks"`
+	Keywords  *KeywordReport  `json:"keywords,omitempty"`
+}
+
+// Analyze checks text against the rules of common applicant tracking
+// systems. keywords are the skills of the target job, the keyword check is
+// skipped without them.
+func Analyze(text string, keywords []string) Report {
+	words := len(strings.Fields(text))
+	report := Report{WordCount: words, Sections: map[string]bool{}}
+	if words == 0 {
+		report.Checks = []Check{{
+			ID: "text", Title: "Readable text", Status: StatusFail, MaxScore: 100,
+			Message: "No text could be read from the file. Scanned or image only resumes cannot be parsed by applicant tracking systems.",
+		}}
+		report.Grade = grade(0)
+		return report
+	}
+
+	sectionsCheck, sections := checkSections(text)
+	report.Sections = sections
+	report.Checks = []Check{sectionsCheck, checkContact(text), checkDates(text), checkLength(words)}
+	keywordCheck, keywordReport := checkKeywords(text, words, keywords)
+	report.Checks = append(report.Checks, keywordCheck)
+	report.Keywords = keywordReport
+
+	score, max := 0, 0
+	for _, check := range report.Checks {
+		if check.Status == StatusSkipped {
+			continue
+		}
+		score += check.Score
+		max += check.MaxScore
+	}
+	report.Score = score * 100 / max
+	report.Grade = grade(report.Score)
+	return report
+}
+
+func grade(score int) string {
+	switch {
+	case score >= 85:
+		return "A"
+	case score >= 70:
+		return "B"
+	case score >= 55:
+		return "C"
+	case score >= 40:
+		return "D"
+	}
+	return "F"
+}
+
+func status(ratio float64) Status {
+	switch {
+	case ratio >= 0.8:
+		return StatusPass
+	case ratio >= 0.5:
+		return StatusWarn
+	}
+	return StatusFail
+}
+
+// sectionHeadings match a heading on a line of its own, optionally followed
+// by a colon, as written by most resume templates.
+var sectionHeadings = map[string]*regexp.Regexp{
+	"experience": heading(`(?:work |professional |relevant )?experience|employment(?: history)?|work history|career history`),
+	"education":  heading(`education(?:al background)?|academic (?:background|qualifications)|qualifications`),
+	"skills":     heading(`(?:technical |key |core )?skills(?: (?:&|and) (?:tools|technologies))?|core competencies|technologies|tech stack`),
+	"summary":    heading(`(?:professional )?summary|profile|objective|about me`),
+}
+
+func heading(names string) *regexp.Regexp {
+	return regexp.MustCompile(`(?im)^[\s#*•-]*(?:` + names + `)[\s:]*$`)
+}
+
+// requiredSections are scored, the others are only reported.
+var requiredSections = []string{"experience", "education", "skills"}
+
+func checkSections(text string) (Check, map[string]bool) {
+	found := map[string]bool{}
+	for name, expr := range sectionHeadings {
+		found[name] = expr.MatchString(text)
+	}
+	var missing []string
+	for _, name := range requiredSections {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	present := len(requiredSections) - len(missing)
+	check := Check{
+		ID: "sections", Title: "Standard sections",
+		Score:    weightSections * present / len(requiredSections),
+		MaxScore: weightSections,
+		Status:   status(float64(present) / float64(len(requiredSections))),
+	}
+	if len(missing) == 0 {
+		check.Message = "Experience, education and skills sections were found."
+	} else {
+		check.Message = "Missing section headings: " + strings.Join(missing, ", ") + ". Use plain headings such as \"Experience\" on their own line."
+	}
+	return check, found
+}
+
+func checkContact(text string) Check {
+	_, found := redact.Default(nil).Redact(text, nil)
+	check := Check{ID: "contact", Title: "Contact information", MaxScore: weightContact}
+	var missing []string
+	if found[redact.KindEmail] > 0 {
+		check.Score += weightContact * 2 / 3
+	} else {
+		missing = append(missing, "email address")
+	}
+	if found[redact.KindPhone] > 0 {
+		check.Score += weightContact - weightContact*2/3
+	} else {
+		missing = append(missing, "phone number")
+	}
+	check.Status = status(float64(check.Score) / float64(weightContact))
+	if len(missing) == 0 {
+		check.Message = "Email address and phone number were found."
+	} else {
+		check.Message = "No " + strings.Join(missing, " or ") + " found in the text. Keep contact details in the body rather than in headers or images."
+	}
+	return check
+}
+
+// dateFormats are the ways resumes commonly write a month and year. Bare
+// years are only counted when no other format is used.
+var dateFormats = []struct {
+	name string
+	expr *regexp.Regexp
+}{
+	{"Month YYYY", regexp.MustCompile(`(?i)\b(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|jun(?:e)?|jul(?:y)?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?,?\s+(?:19|20)\d{2}\b`)},
+	{"MM/YYYY", regexp.MustCompile(`\b(?:0?[1-9]|1[0-2])[/.](?:19|20)\d{2}\b`)},
+	{"YYYY-MM", regexp.MustCompile(`\b(?:19|20)\d{2}-(?:0[1-9]|1[0-2])\b`)},
+}
+
+var yearExpr = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+
+func checkDates(text string) Check {
+	check := Check{ID: "dates", Title: "Consistent dates", MaxScore: weightDates}
+	counts := map[string]int{}
+	total := 0
+	for _, format := range dateFormats {
+		if n := len(format.expr.FindAllStringIndex(text, -1)); n > 0 {
+			counts[format.name] = n
+			total += n
+		}
+	}
+	if total == 0 {
+		if len(yearExpr.FindAllStringIndex(text, -1)) == 0 {
+			check.Status = StatusFail
+			check.Message = "No dates were found. List start and end dates for every position."
+			return check
+		}
+		check.Score = weightDates * 2 / 3
+		check.Status = StatusWarn
+		check.Message = "Only years were found. Month and year, such as \"Jan 2021\", lets systems compute your experience precisely."
+		return check
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return counts[names[i]] > counts[names[j]] })
+	ratio := float64(counts[names[0]]) / float64(total)
+	check.Score = int(float64(weightDates)*ratio + 0.5)
+	check.Status = status(ratio)
+	if len(names) == 1 {
+		check.Message = "All dates use the " + names[0] + " format."
+	} else {
+		check.Message = "Dates mix the formats " + strings.Join(names, ", ") + ". Use " + names[0] + " everywhere."
+	}
+	return check
+}
+
+const (
+	minWords   = 400
+	maxWords   = 1200
+	lowerWords = 250
+	upperWords = 1600
+)
+
+func checkLength(words int) Check {
+	check := Check{ID: "length", Title: "Length", MaxScore: weightLength}
+	switch {
+	case words >= minWords && words <= maxWords:
+		check.Status, check.Score = StatusPass, weightLength
+		check.Message = "The resume has a typical length."
+	case words >= lowerWords && words < minWords:
+		check.Status, check.Score = StatusWarn, weightLength/2
+		check.Message = "The resume is short. Describe your work in more detail."
+	case words > maxWords && words <= upperWords:
+		check.Status, check.Score = StatusWarn, weightLength/2
+		check.Message = "The resume is long. Focus on the most relevant experience."
+	case words < lowerWords:
+		check.Status = StatusFail
+		check.Message = "The resume is very short, systems may rank it below others."
+	default:
+		check.Status = StatusFail
+		check.Message = "The resume is very long, recruiters and systems may truncate it."
+	}
+	return check
+}
+
+// stuffingDensity is the share of words above which keywords look stuffed.
+const stuffingDensity = 0.08
+
+func checkKeywords(text string, words int, keywords []string) (Check, *KeywordReport) {
+	check := Check{ID: "keywords", Title: "Job keywords", MaxScore: weightKeywords}
+	keywords = uniqueFold(keywords)
+	if len(keywords) == 0 {
+		check.Status = StatusSkipped
+		check.Message = "No target job was given."
+		return check, nil
+	}
+	report := &KeywordReport{Matched: []KeywordHit{}, Missing: []string{}}
+	occurrences := 0
+	for _, keyword := range keywords {
+		expr := regexp.MustCompile(`(?i)(?:^|[^\pL\pN+#])` + regexp.QuoteMeta(keyword) + `(?:$|[^\pL\pN+#])`)
+		if n := len(expr.FindAllStringIndex(text, -1)); n > 0 {
+			report.Matched = append(report.Matched, KeywordHit{Keyword: keyword, Count: n})
+			occurrences += n * len(strings.Fields(keyword))
+		} else {
+			report.Missing = append(report.Missing, keyword)
+		}
+	}
+	report.Coverage = float64(len(report.Matched)) / float64(len(keywords))
+	report.Density = float64(occurrences) / float64(words)
+
+	check.Score = int(float64(weightKeywords)*report.Coverage + 0.5)
+	check.Status = status(report.Coverage)
+	check.Message = "The resume mentions " + strconv.Itoa(len(report.Matched)) + " of " + strconv.Itoa(len(keywords)) + " job keywords."
+	if report.Density > stuffingDensity {
+		check.Status = StatusWarn
+		check.Score = check.Score * 2 / 3
+		check.Message += " Keywords make up an unusually large part of the text, which can look like keyword stuffing."
+	}
+	return check, report
+}
+
+func uniqueFold(values []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		out = append(out, v)
+	}
+	return out
+}
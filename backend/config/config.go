@@ -2,6 +2,7 @@ package config
 
 import (
 	"Inquiro/auth"
+	"Inquiro/parser"
 	jobpb "Inquiro/protos"
 	"Inquiro/repositories"
 	"Inquiro/utils/mailer"
@@ -13,8 +14,12 @@ import (
 type Config struct {
 	Addr        string
 	FrontendURL string
-	DBConfig    DBConfig
-	MailConfig  MailConfig
+	// APIURL is the address the backend is reached at from outside, for
+	// links in mails that are handled by the backend itself
+	APIURL     string
+	DBConfig   DBConfig
+	MailConfig MailConfig
+	JobService JobServiceConfig
 }
 
 type Application struct {
@@ -25,6 +30,7 @@ type Application struct {
 	Mail    mailer.Client
 	Session *scs.SessionManager
 	Grpc    jobpb.JobServiceClient
+	Parser  parser.ResumeParser
 }
 
 type DBConfig struct {
@@ -39,3 +45,15 @@ type MailConfig struct {
 	APIKey    string
 	FromEmail string
 }
+
+// JobServiceConfig is how the backend reaches the job service. Without
+// CAFile the connection is plaintext, without TokenSecret calls carry no
+// service token.
+type JobServiceConfig struct {
+	Address     string
+	CAFile      string
+	CertFile    string
+	KeyFile     string
+	ServerName  string
+	TokenSecret string
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// unsubscribePage is what the unsubscribe link of a digest opens. Following
+// the link only shows the form, so mail scanners that open links do not
+// unsubscribe anyone; submitting it posts to the same address.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+{{if .Done}}<p>You will no longer receive the job alert {{.Name}}.</p>
+{{else if .Missing}}<p>This unsubscribe link is not valid.</p>
+{{else}}<form method="post"><p>Stop receiving this job alert?</p><button type="submit">Unsubscribe</button></form>
+{{end}}</body>
+</html>
+`))
+
+type unsubscribeView struct {
+	Done    bool
+	Missing bool
+	Name    string
+}
+
+type Alert struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Alert) alertError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrAlertNotFound):
+		response.Error(w, r, "Failed", "Job alert does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrDuplicateAlert):
+		response.Error(w, r, "Failed", err.Error(), 409, http.StatusConflict)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Job alert request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+func (u Alert) readAlertPayload(w http.ResponseWriter, r *http.Request) (*models.JobAlertPayload, bool) {
+	var payload models.JobAlertPayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	return &payload, true
+}
+
+func (u Alert) ListJobAlerts(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	alerts, err := u.srv.AlertServices.ListAlerts(r.Context(), user.ID, pagination)
+	if err != nil {
+		u.alertError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Job alerts fetched", alerts, pagination.Next, http.StatusOK)
+}
+
+func (u Alert) CreateJobAlert(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	payload, ok := u.readAlertPayload(w, r)
+	if !ok {
+		return
+	}
+	alert, err := u.srv.AlertServices.CreateAlert(r.Context(), user.ID, *payload)
+	if err != nil {
+		u.alertError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job alert created", alert, http.StatusCreated)
+}
+
+func (u Alert) UpdateJobAlert(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job alert id", 400, http.StatusBadRequest)
+		return
+	}
+	payload, ok := u.readAlertPayload(w, r)
+	if !ok {
+		return
+	}
+	alert, err := u.srv.AlertServices.UpdateAlert(r.Context(), id, user.ID, *payload)
+	if err != nil {
+		u.alertError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job alert updated", alert, http.StatusOK)
+}
+
+func (u Alert) DeleteJobAlert(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job alert id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.AlertServices.DeleteAlert(r.Context(), id, user.ID); err != nil {
+		u.alertError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job alert deleted", nil, http.StatusOK)
+}
+
+func (u Alert) renderUnsubscribe(w http.ResponseWriter, view unsubscribeView, status int) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := unsubscribePage.Execute(w, view); err != nil {
+		u.cfg.Logger.Errorw("Could not render the unsubscribe page", "error : ", err.Error())
+	}
+}
+
+// ConfirmUnsubscribe shows the page asking to confirm the unsubscription
+// the digest's link was followed for.
+func (u Alert) ConfirmUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	u.renderUnsubscribe(w, unsubscribeView{}, http.StatusOK)
+}
+
+// UnsubscribeJobAlert turns off the alert named by the token of its
+// digests. It needs no session, so mail clients can unsubscribe in one
+// click with the List-Unsubscribe-Post header. Browsers submitting the
+// confirmation page get a page back, other clients JSON.
+func (u Alert) UnsubscribeJobAlert(w http.ResponseWriter, r *http.Request) {
+	page := strings.Contains(r.Header.Get("Accept"), "text/html")
+	alert, err := u.srv.AlertServices.Unsubscribe(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if page && errors.Is(err, repositories.ErrAlertNotFound) {
+			u.renderUnsubscribe(w, unsubscribeView{Missing: true}, http.StatusNotFound)
+			return
+		}
+		u.alertError(w, r, err)
+		return
+	}
+	if page {
+		u.renderUnsubscribe(w, unsubscribeView{Done: true, Name: alert.Name}, http.StatusOK)
+		return
+	}
+	response.Success(w, r, "Unsubscribed", map[string]string{"name": alert.Name}, http.StatusOK)
+}
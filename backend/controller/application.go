@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+)
+
+type Application struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Application) applicationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrApplicationNotFound):
+		response.Error(w, r, "Failed", "Application does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrJobNotFound):
+		response.Error(w, r, "Failed", "Job does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrResumeNotFound):
+		response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrDuplicateApplication),
+		errors.Is(err, repositories.ErrApplicationStatusChanged),
+		errors.Is(err, services.ErrInvalidTransition):
+		response.Error(w, r, "Failed", err.Error(), 409, http.StatusConflict)
+	case errors.Is(err, services.ErrNotJobManager), errors.Is(err, services.ErrCompanyUnverified),
+		errors.Is(err, services.ErrNotCompanyJob):
+		response.Error(w, r, "Failed", err.Error(), 403, http.StatusForbidden)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Application request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+// readPayload reads and validates the JSON body into payload.
+func (u Application) readPayload(w http.ResponseWriter, r *http.Request, payload any) bool {
+	if err := json.Read(w, r, payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return false
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (u Application) Apply(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	var payload models.ApplicationPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	application, err := u.srv.ApplicationServices.Apply(r.Context(), user.ID, payload)
+	if err != nil {
+		u.applicationError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Application sent", application, http.StatusCreated)
+}
+
+func (u Application) ListApplications(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	applications, err := u.srv.ApplicationServices.ListApplications(r.Context(), user.ID, pagination)
+	if err != nil {
+		u.applicationError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Applications fetched", applications, pagination.Next, http.StatusOK)
+}
+
+func (u Application) GetApplication(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid application id", 400, http.StatusBadRequest)
+		return
+	}
+	application, err := u.srv.ApplicationServices.GetApplication(r.Context(), id, user)
+	if err != nil {
+		u.applicationError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Application fetched", application, http.StatusOK)
+}
+
+// ListJobApplications lists the applications to a job, optionally only
+// those in the status given by the status parameter.
+func (u Application) ListJobApplications(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if err := json.Validate.Var(status, "omitempty,oneof=applied screening interview offer rejected withdrawn"); err != nil {
+		response.Error(w, r, "Bad request", "Invalid status", 400, http.StatusBadRequest)
+		return
+	}
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	applications, err := u.srv.ApplicationServices.ListJobApplications(r.Context(), id, user, status, pagination)
+	if err != nil {
+		u.applicationError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Applications fetched", applications, pagination.Next, http.StatusOK)
+}
+
+func (u Application) WithdrawApplication(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid application id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.ApplicationWithdrawPayload
+	if r.ContentLength != 0 && !u.readPayload(w, r, &payload) {
+		return
+	}
+	application, err := u.srv.ApplicationServices.Withdraw(r.Context(), id, user.ID, payload.Note)
+	if err != nil {
+		u.applicationError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Application withdrawn", application, http.StatusOK)
+}
+
+func (u Application) SetApplicationStatus(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid application id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.ApplicationStatusPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	application, err := u.srv.ApplicationServices.SetStatus(r.Context(), id, user, payload)
+	if err != nil {
+		u.applicationError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Application status updated", application, http.StatusOK)
+}
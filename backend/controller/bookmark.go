@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+)
+
+type Bookmark struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Bookmark) bookmarkError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrCollectionNotFound):
+		response.Error(w, r, "Failed", "Collection does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrBookmarkNotFound):
+		response.Error(w, r, "Failed", "Bookmark does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrJobNotFound):
+		response.Error(w, r, "Failed", "Job does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrDuplicateCollection), errors.Is(err, repositories.ErrDuplicateBookmark):
+		response.Error(w, r, "Failed", err.Error(), 409, http.StatusConflict)
+	case errors.Is(err, services.ErrReminderInPast):
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Bookmark request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+// readPayload reads and validates the JSON body into payload.
+func (u Bookmark) readPayload(w http.ResponseWriter, r *http.Request, payload any) bool {
+	if err := json.Read(w, r, payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return false
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (u Bookmark) ListCollections(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	collections, err := u.srv.BookmarkServices.ListCollections(r.Context(), user.ID, pagination)
+	if err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Collections fetched", collections, pagination.Next, http.StatusOK)
+}
+
+func (u Bookmark) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	var payload models.JobCollectionPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	collection, err := u.srv.BookmarkServices.CreateCollection(r.Context(), user.ID, payload)
+	if err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Collection created", collection, http.StatusCreated)
+}
+
+func (u Bookmark) GetCollection(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid collection id", 400, http.StatusBadRequest)
+		return
+	}
+	collection, err := u.srv.BookmarkServices.GetCollection(r.Context(), id, user.ID)
+	if err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Collection fetched", collection, http.StatusOK)
+}
+
+func (u Bookmark) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid collection id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.JobCollectionPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	collection, err := u.srv.BookmarkServices.UpdateCollection(r.Context(), id, user.ID, payload)
+	if err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Collection updated", collection, http.StatusOK)
+}
+
+func (u Bookmark) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid collection id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.BookmarkServices.DeleteCollection(r.Context(), id, user.ID); err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Collection deleted", nil, http.StatusOK)
+}
+
+func (u Bookmark) ListBookmarks(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid collection id", 400, http.StatusBadRequest)
+		return
+	}
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	bookmarks, err := u.srv.BookmarkServices.ListBookmarks(r.Context(), id, user.ID, pagination)
+	if err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Bookmarks fetched", bookmarks, pagination.Next, http.StatusOK)
+}
+
+func (u Bookmark) AddBookmark(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid collection id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.BookmarkPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	bookmark, err := u.srv.BookmarkServices.AddBookmark(r.Context(), id, user.ID, payload)
+	if err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job bookmarked", bookmark, http.StatusCreated)
+}
+
+func (u Bookmark) UpdateBookmark(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid collection id", 400, http.StatusBadRequest)
+		return
+	}
+	bookmarkID, err := uuidParam(r, "bookmark")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid bookmark id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.BookmarkUpdatePayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	bookmark, err := u.srv.BookmarkServices.UpdateBookmark(r.Context(), bookmarkID, id, user.ID, payload)
+	if err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Bookmark updated", bookmark, http.StatusOK)
+}
+
+func (u Bookmark) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid collection id", 400, http.StatusBadRequest)
+		return
+	}
+	bookmarkID, err := uuidParam(r, "bookmark")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid bookmark id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.BookmarkServices.RemoveBookmark(r.Context(), bookmarkID, id, user.ID); err != nil {
+		u.bookmarkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Bookmark removed", nil, http.StatusOK)
+}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Company struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Company) companyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrCompanyNotFound):
+		response.Error(w, r, "Failed", "Company does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrMemberNotFound):
+		response.Error(w, r, "Failed", "Member does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrInviteNotFound):
+		response.Error(w, r, "Failed", "Invite does not exist or has expired", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrUserNotFound):
+		response.Error(w, r, "Failed", "User does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrDuplicateCompany), errors.Is(err, repositories.ErrAlreadyMember),
+		errors.Is(err, repositories.ErrLastCompanyOwner):
+		response.Error(w, r, "Failed", err.Error(), 409, http.StatusConflict)
+	case errors.Is(err, services.ErrNotCompanyOwner):
+		response.Error(w, r, "Failed", err.Error(), 403, http.StatusForbidden)
+	case errors.Is(err, services.ErrInvalidCompanyName):
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Company request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+// readPayload reads and validates the JSON body into payload.
+func (u Company) readPayload(w http.ResponseWriter, r *http.Request, payload any) bool {
+	if err := json.Read(w, r, payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return false
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (u Company) GetCompany(w http.ResponseWriter, r *http.Request) {
+	company, err := u.srv.CompanyServices.GetCompany(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Company fetched", company, http.StatusOK)
+}
+
+// ListCompanyJobs lists the jobs of a company, narrowed by the same filters
+// as the job listing.
+func (u Company) ListCompanyJobs(w http.ResponseWriter, r *http.Request) {
+	filter := &models.JobFilter{}
+	if err := filter.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(filter); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	jobs, err := u.srv.CompanyServices.ListCompanyJobs(r.Context(), chi.URLParam(r, "slug"), filter)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Jobs fetched", jobs, filter.Paginatin.Next, http.StatusOK)
+}
+
+func (u Company) CreateCompany(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	var payload models.CompanyPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	company, err := u.srv.CompanyServices.CreateCompany(r.Context(), user.ID, payload)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Company created", company, http.StatusCreated)
+}
+
+func (u Company) GetMyCompany(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	company, err := u.srv.CompanyServices.MyCompany(r.Context(), user.ID)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Company fetched", company, http.StatusOK)
+}
+
+func (u Company) UpdateCompany(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	var payload models.CompanyPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	company, err := u.srv.CompanyServices.UpdateCompany(r.Context(), user.ID, payload)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Company updated", company, http.StatusOK)
+}
+
+func (u Company) ListCompanyMembers(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	members, err := u.srv.CompanyServices.ListMembers(r.Context(), user.ID, pagination)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Members fetched", members, pagination.Next, http.StatusOK)
+}
+
+func (u Company) InviteCompanyMember(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	var payload models.CompanyInvitePayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	invite, err := u.srv.CompanyServices.InviteMember(r.Context(), user.ID, payload)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Invite sent", invite, http.StatusCreated)
+}
+
+func (u Company) ListCompanyInvites(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	invites, err := u.srv.CompanyServices.ListInvites(r.Context(), user.ID, pagination)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Invites fetched", invites, pagination.Next, http.StatusOK)
+}
+
+func (u Company) RevokeCompanyInvite(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid invite id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.CompanyServices.RevokeInvite(r.Context(), user.ID, id); err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Invite revoked", nil, http.StatusOK)
+}
+
+// AcceptCompanyInvite adds the signed in user to the company of the invite
+// with the token, when it was sent to their email.
+func (u Company) AcceptCompanyInvite(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	member, err := u.srv.CompanyServices.AcceptInvite(r.Context(), user, chi.URLParam(r, "token"))
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Invite accepted", member, http.StatusCreated)
+}
+
+func (u Company) RemoveCompanyMember(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	memberID, err := uuidParam(r, "user")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid user id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.CompanyServices.RemoveMember(r.Context(), user.ID, memberID); err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Member removed", nil, http.StatusOK)
+}
+
+// ListCompanies lists the companies for admins, only the verified or
+// unverified ones when the verified parameter is given.
+func (u Company) ListCompanies(w http.ResponseWriter, r *http.Request) {
+	var verified *bool
+	if raw := r.URL.Query().Get("verified"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.Error(w, r, "Bad request", "Invalid verified", 400, http.StatusBadRequest)
+			return
+		}
+		verified = &v
+	}
+	pagination := &models.PaginatedQuery{}
+	if err := pagination.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	pagination.SetDefaults()
+	if err := json.Validate.Struct(pagination); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	companies, err := u.srv.CompanyServices.ListCompanies(r.Context(), verified, pagination)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Companies fetched", companies, pagination.Next, http.StatusOK)
+}
+
+func (u Company) VerifyCompany(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid company id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.CompanyVerificationPayload
+	if !u.readPayload(w, r, &payload) {
+		return
+	}
+	company, err := u.srv.CompanyServices.VerifyCompany(r.Context(), id, user.ID, *payload.Verified)
+	if err != nil {
+		u.companyError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Company verification updated", company, http.StatusOK)
+}
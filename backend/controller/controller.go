@@ -2,8 +2,16 @@ package controller
 
 import (
 	"Inquiro/config"
+	"Inquiro/models"
 	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 type Controller struct {
@@ -14,11 +22,125 @@ type Controller struct {
 	}
 	Resume interface {
 		ProcessResume(w http.ResponseWriter, r *http.Request)
+		StartParseJob(w http.ResponseWriter, r *http.Request)
+		ParseJobEvents(w http.ResponseWriter, r *http.Request)
+		ListResumes(w http.ResponseWriter, r *http.Request)
+		GetResume(w http.ResponseWriter, r *http.Request)
+		UpdateResumeProfile(w http.ResponseWriter, r *http.Request)
+		ListResumeDocuments(w http.ResponseWriter, r *http.Request)
+		DiffResumeVersions(w http.ResponseWriter, r *http.Request)
+		SetPrimaryResume(w http.ResponseWriter, r *http.Request)
+		BatchRelevancy(w http.ResponseWriter, r *http.Request)
+		ATSReport(w http.ResponseWriter, r *http.Request)
+	}
+	Skill interface {
+		ListSkills(w http.ResponseWriter, r *http.Request)
+		CreateSkill(w http.ResponseWriter, r *http.Request)
+		UpdateSkill(w http.ResponseWriter, r *http.Request)
+		DeleteSkill(w http.ResponseWriter, r *http.Request)
+		ImportSkills(w http.ResponseWriter, r *http.Request)
+		ListUnknownSkills(w http.ResponseWriter, r *http.Request)
+		NormalizeSkills(w http.ResponseWriter, r *http.Request)
+		SuggestSkills(w http.ResponseWriter, r *http.Request)
+	}
+	Export interface {
+		ExportResume(w http.ResponseWriter, r *http.Request)
+		ListExportThemes(w http.ResponseWriter, r *http.Request)
+		ImportResume(w http.ResponseWriter, r *http.Request)
+	}
+	Job interface {
+		ListJobs(w http.ResponseWriter, r *http.Request)
+		SearchJobs(w http.ResponseWriter, r *http.Request)
+		SkillGap(w http.ResponseWriter, r *http.Request)
+		GetJob(w http.ResponseWriter, r *http.Request)
+		CreateJob(w http.ResponseWriter, r *http.Request)
+		UpdateJob(w http.ResponseWriter, r *http.Request)
+		DeleteJob(w http.ResponseWriter, r *http.Request)
+	}
+	Alert interface {
+		ListJobAlerts(w http.ResponseWriter, r *http.Request)
+		CreateJobAlert(w http.ResponseWriter, r *http.Request)
+		UpdateJobAlert(w http.ResponseWriter, r *http.Request)
+		DeleteJobAlert(w http.ResponseWriter, r *http.Request)
+		ConfirmUnsubscribe(w http.ResponseWriter, r *http.Request)
+		UnsubscribeJobAlert(w http.ResponseWriter, r *http.Request)
+	}
+	Application interface {
+		Apply(w http.ResponseWriter, r *http.Request)
+		ListApplications(w http.ResponseWriter, r *http.Request)
+		GetApplication(w http.ResponseWriter, r *http.Request)
+		ListJobApplications(w http.ResponseWriter, r *http.Request)
+		WithdrawApplication(w http.ResponseWriter, r *http.Request)
+		SetApplicationStatus(w http.ResponseWriter, r *http.Request)
+	}
+	Bookmark interface {
+		ListCollections(w http.ResponseWriter, r *http.Request)
+		CreateCollection(w http.ResponseWriter, r *http.Request)
+		GetCollection(w http.ResponseWriter, r *http.Request)
+		UpdateCollection(w http.ResponseWriter, r *http.Request)
+		DeleteCollection(w http.ResponseWriter, r *http.Request)
+		ListBookmarks(w http.ResponseWriter, r *http.Request)
+		AddBookmark(w http.ResponseWriter, r *http.Request)
+		UpdateBookmark(w http.ResponseWriter, r *http.Request)
+		RemoveBookmark(w http.ResponseWriter, r *http.Request)
+	}
+	Company interface {
+		GetCompany(w http.ResponseWriter, r *http.Request)
+		ListCompanyJobs(w http.ResponseWriter, r *http.Request)
+		CreateCompany(w http.ResponseWriter, r *http.Request)
+		GetMyCompany(w http.ResponseWriter, r *http.Request)
+		UpdateCompany(w http.ResponseWriter, r *http.Request)
+		ListCompanyMembers(w http.ResponseWriter, r *http.Request)
+		InviteCompanyMember(w http.ResponseWriter, r *http.Request)
+		ListCompanyInvites(w http.ResponseWriter, r *http.Request)
+		RevokeCompanyInvite(w http.ResponseWriter, r *http.Request)
+		AcceptCompanyInvite(w http.ResponseWriter, r *http.Request)
+		RemoveCompanyMember(w http.ResponseWriter, r *http.Request)
+		ListCompanies(w http.ResponseWriter, r *http.Request)
+		VerifyCompany(w http.ResponseWriter, r *http.Request)
+	}
+	Recommendation interface {
+		RecommendedJobs(w http.ResponseWriter, r *http.Request)
+		JobFeedback(w http.ResponseWriter, r *http.Request)
+		DeleteJobFeedback(w http.ResponseWriter, r *http.Request)
+		RecommendationFeedbackStats(w http.ResponseWriter, r *http.Request)
+	}
+	Ingest interface {
+		ListJobSources(w http.ResponseWriter, r *http.Request)
+		CreateJobSource(w http.ResponseWriter, r *http.Request)
+		UpdateJobSource(w http.ResponseWriter, r *http.Request)
+		DeleteJobSource(w http.ResponseWriter, r *http.Request)
+		RunJobSource(w http.ResponseWriter, r *http.Request)
+		ListJobImportRuns(w http.ResponseWriter, r *http.Request)
+	}
+	Link interface {
+		CreateResumeLink(w http.ResponseWriter, r *http.Request)
+		ListResumeLinks(w http.ResponseWriter, r *http.Request)
+		RevokeResumeLink(w http.ResponseWriter, r *http.Request)
+		ResumeLinkStats(w http.ResponseWriter, r *http.Request)
+		ViewPublicResume(w http.ResponseWriter, r *http.Request)
+		DownloadPublicResume(w http.ResponseWriter, r *http.Request)
+	}
+	Share interface {
+		ShareResume(w http.ResponseWriter, r *http.Request)
+		ListResumeShares(w http.ResponseWriter, r *http.Request)
+		RevokeResumeShare(w http.ResponseWriter, r *http.Request)
+		ListSharedResumes(w http.ResponseWriter, r *http.Request)
+		GetSharedResume(w http.ResponseWriter, r *http.Request)
+		DownloadSharedResume(w http.ResponseWriter, r *http.Request)
+	}
+	Quota interface {
+		GetParseQuota(w http.ResponseWriter, r *http.Request)
+	}
+	Title interface {
+		SuggestTitles(w http.ResponseWriter, r *http.Request)
 	}
 	Mentor interface {
 		MentorSignUp(w http.ResponseWriter, r *http.Request)
 		MentorLogin(w http.ResponseWriter, r *http.Request)
 		MentorActivation(w http.ResponseWriter, r *http.Request)
+		GetMentorSkills(w http.ResponseWriter, r *http.Request)
+		UpdateMentorSkills(w http.ResponseWriter, r *http.Request)
 	}
 }
 
@@ -32,9 +154,94 @@ func NewController(service services.Service, cfg config.Application) Controller
 			srv: service,
 			cfg: cfg,
 		},
+		Skill: Skill{
+			srv: service,
+			cfg: cfg,
+		},
+		Export: Export{
+			srv: service,
+			cfg: cfg,
+		},
+		Job: Job{
+			srv: service,
+			cfg: cfg,
+		},
+		Alert: Alert{
+			srv: service,
+			cfg: cfg,
+		},
+		Bookmark: Bookmark{
+			srv: service,
+			cfg: cfg,
+		},
+		Application: Application{
+			srv: service,
+			cfg: cfg,
+		},
+		Company: Company{
+			srv: service,
+			cfg: cfg,
+		},
+		Recommendation: Recommendation{
+			srv: service,
+			cfg: cfg,
+		},
+		Ingest: Ingest{
+			srv: service,
+			cfg: cfg,
+		},
+		Link: Link{
+			srv: service,
+			cfg: cfg,
+		},
+		Quota: Quota{
+			srv: service,
+			cfg: cfg,
+		},
+		Share: Share{
+			srv: service,
+			cfg: cfg,
+		},
+		Title: Title{
+			srv: service,
+			cfg: cfg,
+		},
 		Mentor: Mentor{
 			srv: service,
 			cfg: cfg,
 		},
 	}
 }
+
+func uuidParam(r *http.Request, name string) (uuid.UUID, error) {
+	return uuid.Parse(chi.URLParam(r, name))
+}
+
+// readPagination reads and validates the page parameters, answering 400
+// when they are invalid.
+func readPagination(w http.ResponseWriter, r *http.Request) (*models.PaginatedQuery, bool) {
+	pagination := &models.PaginatedQuery{}
+	if err := pagination.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return nil, false
+	}
+	pagination.SetDefaults()
+	if err := json.Validate.Struct(pagination); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	return pagination, true
+}
+
+// suggestQuery reads the typeahead parameters q and limit.
+func suggestQuery(r *http.Request) (models.SuggestQuery, error) {
+	query := models.SuggestQuery{Query: strings.TrimSpace(r.URL.Query().Get("q")), Limit: 10}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return query, err
+		}
+		query.Limit = l
+	}
+	return query, json.Validate.Struct(query)
+}
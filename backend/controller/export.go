@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/export"
+	"Inquiro/middlewares"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/response"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type Export struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Export) exportError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrResumeNotFound):
+		response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrResumeDocumentNotFound):
+		response.Error(w, r, "Failed", "Resume document does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, export.ErrUnknownTheme), errors.Is(err, services.ErrUnknownExportFormat):
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+	case errors.Is(err, export.ErrInvalidJSONResume), errors.Is(err, export.ErrEmptyJSONResume):
+		response.Error(w, r, "Import failed", err.Error(), 422, http.StatusUnprocessableEntity)
+	default:
+		u.cfg.Logger.Errorw("Export request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+// ExportResume downloads the resume as ?format=json|html|pdf, HTML and PDF
+// take an optional ?theme=.
+func (u Export) ExportResume(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	q := r.URL.Query()
+	file, err := u.srv.ExportServices.Export(r.Context(), user, id, q.Get("format"), q.Get("theme"))
+	if err != nil {
+		u.exportError(w, r, err)
+		return
+	}
+	response.File(w, r, file.FileName, file.ContentType, file.Content)
+}
+
+func (u Export) ListExportThemes(w http.ResponseWriter, r *http.Request) {
+	response.Success(w, r, "Themes fetched", u.srv.ExportServices.Themes(), http.StatusOK)
+}
+
+// ImportResume reads a JSON Resume document from the body. ?document_id=
+// adds it as a new version of an existing document, ?title= names a new one.
+func (u Export) ImportResume(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	var documentID uuid.UUID
+	if value := r.URL.Query().Get("document_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			response.Error(w, r, "Bad request", "Invalid document id", 400, http.StatusBadRequest)
+			return
+		}
+		documentID = id
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		response.Error(w, r, "Bad request", "Document too large", 400, http.StatusBadRequest)
+		return
+	}
+	resume, err := u.srv.ExportServices.Import(r.Context(), user, documentID, r.URL.Query().Get("title"), data)
+	if err != nil {
+		u.exportError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Resume imported", resume, http.StatusCreated)
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/ingest"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Ingest struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func sourceIDParam(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+}
+
+func (u Ingest) ingestError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrJobSourceNotFound):
+		response.Error(w, r, "Failed", "Job source does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrDuplicateJobSource):
+		response.Error(w, r, "Failed", err.Error(), 409, http.StatusConflict)
+	case errors.Is(err, ingest.ErrInvalidSource):
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Job source request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+func (u Ingest) readSourcePayload(w http.ResponseWriter, r *http.Request) (*models.JobSourcePayload, bool) {
+	var payload models.JobSourcePayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	return &payload, true
+}
+
+func (u Ingest) ListJobSources(w http.ResponseWriter, r *http.Request) {
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	sources, err := u.srv.IngestServices.ListSources(r.Context(), pagination)
+	if err != nil {
+		u.ingestError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Job sources fetched", sources, pagination.Next, http.StatusOK)
+}
+
+func (u Ingest) CreateJobSource(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	payload, ok := u.readSourcePayload(w, r)
+	if !ok {
+		return
+	}
+	source, err := u.srv.IngestServices.CreateSource(r.Context(), user.ID, *payload)
+	if err != nil {
+		u.ingestError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job source created", source, http.StatusCreated)
+}
+
+func (u Ingest) UpdateJobSource(w http.ResponseWriter, r *http.Request) {
+	id, err := sourceIDParam(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job source id", 400, http.StatusBadRequest)
+		return
+	}
+	payload, ok := u.readSourcePayload(w, r)
+	if !ok {
+		return
+	}
+	source, err := u.srv.IngestServices.UpdateSource(r.Context(), id, *payload)
+	if err != nil {
+		u.ingestError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job source updated", source, http.StatusOK)
+}
+
+func (u Ingest) DeleteJobSource(w http.ResponseWriter, r *http.Request) {
+	id, err := sourceIDParam(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job source id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.IngestServices.DeleteSource(r.Context(), id); err != nil {
+		u.ingestError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job source deleted", nil, http.StatusOK)
+}
+
+// RunJobSource imports the source now. It answers once the run has started;
+// the run's stats are read from ListJobImportRuns.
+func (u Ingest) RunJobSource(w http.ResponseWriter, r *http.Request) {
+	id, err := sourceIDParam(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job source id", 400, http.StatusBadRequest)
+		return
+	}
+	run, err := u.srv.IngestServices.RunSource(r.Context(), id)
+	if err != nil {
+		u.ingestError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job import started", run, http.StatusAccepted)
+}
+
+func (u Ingest) ListJobImportRuns(w http.ResponseWriter, r *http.Request) {
+	id, err := sourceIDParam(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job source id", 400, http.StatusBadRequest)
+		return
+	}
+	pagination := &models.PaginatedQuery{}
+	if err := pagination.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	pagination.SetDefaults()
+	if err := json.Validate.Struct(pagination); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	runs, err := u.srv.IngestServices.ListRuns(r.Context(), id, pagination)
+	if err != nil {
+		u.ingestError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Job import runs fetched", runs, pagination.Next, http.StatusOK)
+}
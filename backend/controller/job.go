@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Job struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Job) jobError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrJobNotFound):
+		response.Error(w, r, "Failed", "Job does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrResumeNotFound):
+		response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, services.ErrNotJobManager), errors.Is(err, services.ErrCompanyUnverified),
+		errors.Is(err, services.ErrNotCompanyJob):
+		response.Error(w, r, "Failed", err.Error(), 403, http.StatusForbidden)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Job request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+func (u Job) readJobPayload(w http.ResponseWriter, r *http.Request) (*models.JobPayload, bool) {
+	var payload models.JobPayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	return &payload, true
+}
+
+func (u Job) ListJobs(w http.ResponseWriter, r *http.Request) {
+	filter := &models.JobFilter{}
+	if err := filter.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(filter); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	jobs, err := u.srv.JobServices.ListJobs(r.Context(), filter)
+	if err != nil {
+		u.jobError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Jobs fetched", jobs, filter.Paginatin.Next, http.StatusOK)
+}
+
+// SearchJobs takes the listing filters plus q, the search text in web search
+// syntax ("go developer" -senior), and experience_band.
+func (u Job) SearchJobs(w http.ResponseWriter, r *http.Request) {
+	query := &models.JobSearchQuery{
+		Query: strings.TrimSpace(r.URL.Query().Get("q")),
+		Band:  r.URL.Query().Get("experience_band"),
+	}
+	if err := query.Filter.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(query); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	result, err := u.srv.JobServices.SearchJobs(r.Context(), query)
+	if err != nil {
+		u.jobError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Jobs fetched", result, query.Filter.Paginatin.Next, http.StatusOK)
+}
+
+func (u Job) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	job, err := u.srv.JobServices.GetJob(r.Context(), id)
+	if err != nil {
+		u.jobError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job fetched", job, http.StatusOK)
+}
+
+// SkillGap compares the job with the resume given by the resume parameter,
+// the user's primary resume without it.
+func (u Job) SkillGap(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	resumeID := uuid.Nil
+	if raw := r.URL.Query().Get("resume"); raw != "" {
+		if resumeID, err = uuid.Parse(raw); err != nil {
+			response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+			return
+		}
+	}
+	gap, err := u.srv.JobServices.SkillGap(r.Context(), id, resumeID, user.ID)
+	if err != nil {
+		u.jobError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Skill gap fetched", gap, http.StatusOK)
+}
+
+func (u Job) CreateJob(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	payload, ok := u.readJobPayload(w, r)
+	if !ok {
+		return
+	}
+	job, err := u.srv.JobServices.CreateJob(r.Context(), user, *payload)
+	if err != nil {
+		u.jobError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job created", job, http.StatusCreated)
+}
+
+func (u Job) UpdateJob(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	payload, ok := u.readJobPayload(w, r)
+	if !ok {
+		return
+	}
+	job, err := u.srv.JobServices.UpdateJob(r.Context(), id, user, *payload)
+	if err != nil {
+		u.jobError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job updated", job, http.StatusOK)
+}
+
+func (u Job) DeleteJob(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.JobServices.DeleteJob(r.Context(), id, user); err != nil {
+		u.jobError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Job deleted", nil, http.StatusOK)
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Link struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Link) linkError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrResumeNotFound):
+		response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrLinkNotFound):
+		// Expired and revoked links look the same as unknown ones
+		response.Error(w, r, "Failed", "Link does not exist or has expired", 404, http.StatusNotFound)
+	case errors.Is(err, services.ErrLinkPasswordRequired), errors.Is(err, services.ErrLinkPasswordInvalid):
+		response.Error(w, r, "Failed", err.Error(), 401, http.StatusUnauthorized)
+	case errors.Is(err, services.ErrLinkPasswordLocked):
+		response.Error(w, r, "Failed", err.Error(), 429, http.StatusTooManyRequests)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Link request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+func (u Link) CreateResumeLink(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.ResumeLinkPayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	link, err := u.srv.LinkServices.CreateLink(r.Context(), id, user.ID, payload)
+	if err != nil {
+		u.linkError(w, r, err)
+		return
+	}
+	link.URL = strings.TrimSuffix(u.cfg.Config.FrontendURL, "/") + "/r/" + link.Token
+	response.Success(w, r, "Link created", link, http.StatusCreated)
+}
+
+func (u Link) ListResumeLinks(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	links, err := u.srv.LinkServices.ListLinks(r.Context(), id, user.ID, pagination)
+	if err != nil {
+		u.linkError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Links fetched", links, pagination.Next, http.StatusOK)
+}
+
+func (u Link) RevokeResumeLink(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	linkID, err := uuidParam(r, "linkId")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid link id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.LinkServices.RevokeLink(r.Context(), linkID, id, user.ID); err != nil {
+		u.linkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Link revoked", nil, http.StatusOK)
+}
+
+func (u Link) ResumeLinkStats(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	linkID, err := uuidParam(r, "linkId")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid link id", 400, http.StatusBadRequest)
+		return
+	}
+	stats, err := u.srv.LinkServices.LinkStats(r.Context(), linkID, id, user.ID)
+	if err != nil {
+		u.linkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Link views fetched", stats, http.StatusOK)
+}
+
+// publicLinkRequest reads the token, the password sent in X-Link-Password and
+// the referrer. The frontend passes the page referrer as ?ref= because the
+// Referer of its own API calls is always the frontend.
+func publicLinkRequest(r *http.Request) (string, string, string) {
+	referrer := r.URL.Query().Get("ref")
+	if referrer == "" {
+		referrer = r.Referer()
+	}
+	return chi.URLParam(r, "token"), r.Header.Get("X-Link-Password"), referrer
+}
+
+func (u Link) ViewPublicResume(w http.ResponseWriter, r *http.Request) {
+	token, password, referrer := publicLinkRequest(r)
+	resume, err := u.srv.LinkServices.ViewLink(r.Context(), token, password, referrer)
+	if err != nil {
+		u.linkError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Resume fetched", resume, http.StatusOK)
+}
+
+func (u Link) DownloadPublicResume(w http.ResponseWriter, r *http.Request) {
+	token, password, referrer := publicLinkRequest(r)
+	file, err := u.srv.LinkServices.LinkPDF(r.Context(), token, password, referrer)
+	if err != nil {
+		u.linkError(w, r, err)
+		return
+	}
+	response.File(w, r, file.FileName, file.ContentType, file.Content)
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"Inquiro/config"
+	"Inquiro/middlewares"
 	"Inquiro/models"
 	"Inquiro/repositories"
 	"Inquiro/services"
@@ -149,3 +150,38 @@ func (m Mentor) MentorActivation(w http.ResponseWriter, r *http.Request) {
 func convertExperienceToYears(ExperienceMonth, ExperienceYear int) float32 {
 	return float32(ExperienceMonth) + float32(ExperienceYear)
 }
+
+func (m Mentor) GetMentorSkills(w http.ResponseWriter, r *http.Request) {
+	mentor, _ := middlewares.SessionMentor(r.Context())
+	skills, err := m.srv.MentorServices.Skills(r.Context(), mentor.ID)
+	if err != nil {
+		m.cfg.Logger.Errorw("Could not read the mentor skills", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Skills fetched", skills, http.StatusOK)
+}
+
+// UpdateMentorSkills replaces the skills the mentor lists. Skills outside the
+// catalog are dropped, so they are never matched to a skill gap.
+func (m Mentor) UpdateMentorSkills(w http.ResponseWriter, r *http.Request) {
+	mentor, _ := middlewares.SessionMentor(r.Context())
+	var payload models.MentorSkillsPayload
+	if err := json.Read(w, r, &payload); err != nil {
+		m.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		m.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	skills, err := m.srv.MentorServices.SetSkills(r.Context(), mentor.ID, payload.Skills)
+	if err != nil {
+		m.cfg.Logger.Errorw("Could not update the mentor skills", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Skills updated", skills, http.StatusOK)
+}
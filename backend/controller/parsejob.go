@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"Inquiro/middlewares"
+	"Inquiro/services"
+	"Inquiro/utils/response"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// sseKeepAlive is how often a comment is sent on an idle event stream so
+// proxies do not close it.
+const sseKeepAlive = 15 * time.Second
+
+// StartParseJob accepts the same form as ProcessResume but parses in the
+// background. Progress is followed on the returned events URL.
+func (u Resume) StartParseJob(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	upload, ok := u.readUpload(w, r, user.ID)
+	if !ok {
+		return
+	}
+	release, ok := u.reserveParse(w, r, user.ID)
+	if !ok {
+		return
+	}
+	job := u.srv.ParseJobServices.StartParse(r.Context(), upload, release)
+	job.EventsURL = "/api/resume/jobs/" + job.ID.String() + "/events"
+	w.Header().Set("Location", job.EventsURL)
+	response.Success(w, r, "Parsing started", job, http.StatusAccepted)
+}
+
+// ParseJobEvents streams the events of a parse job as Server-Sent Events.
+// Clients that reconnect send the Last-Event-ID header, or the last_event_id
+// query parameter where EventSource cannot set headers, and get only the
+// events they missed.
+func (u Resume) ParseJobEvents(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	lastID := 0
+	if lastEventID != "" {
+		lastID, err = strconv.Atoi(lastEventID)
+		if err != nil || lastID < 0 {
+			response.Error(w, r, "Bad request", "Invalid Last-Event-ID", 400, http.StatusBadRequest)
+			return
+		}
+	}
+	events, err := u.srv.ParseJobServices.Events(r.Context(), id, user.ID, lastID)
+	if err != nil {
+		if errors.Is(err, services.ErrParseJobNotFound) {
+			response.Error(w, r, "Failed", "Parse job does not exist or has expired", 404, http.StatusNotFound)
+			return
+		}
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher := http.NewResponseController(w)
+	// Clients wait this long before reconnecting after a dropped stream
+	fmt.Fprint(w, "retry: 2000\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				u.cfg.Logger.Errorw("Could not encode parse event", "error : ", err.Error())
+				return
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+}
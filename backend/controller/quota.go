@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/services"
+	"Inquiro/utils/response"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// jobServiceRetryAfter is the Retry-After sent when the job service is busy.
+const jobServiceRetryAfter = 5 * time.Second
+
+type Quota struct {
+	srv services.Service
+	cfg config.Application
+}
+
+// retryAfter formats d as whole seconds for the Retry-After header.
+func retryAfter(d time.Duration) string {
+	return strconv.Itoa(max(int(math.Ceil(d.Seconds())), 1))
+}
+
+func (u Quota) GetParseQuota(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	quota, err := u.srv.QuotaServices.ParseQuota(r.Context(), user.ID)
+	if err != nil {
+		u.cfg.Logger.Errorw("Could not fetch parse quota", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Could not fetch quota", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Quota fetched", quota, http.StatusOK)
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+type Recommendation struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Recommendation) recommendationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrResumeNotFound):
+		response.Error(w, r, "Failed", "Upload a resume to get recommendations", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrJobNotFound):
+		response.Error(w, r, "Failed", "Job does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrFeedbackNotFound):
+		response.Error(w, r, "Failed", "No feedback on this job", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Job recommendation request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+// RecommendedJobs lists the jobs recommended from the user's primary resume,
+// narrowed by the job listing filters.
+func (u Recommendation) RecommendedJobs(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	filter := &models.JobFilter{}
+	if err := filter.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(filter); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	page, err := u.srv.RecommendationServices.Recommend(r.Context(), user.ID, filter)
+	if err != nil {
+		u.recommendationError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Recommended jobs fetched", page, filter.Paginatin.Next, http.StatusOK)
+}
+
+// JobFeedback records the user's thumbs up, thumbs down or lack of interest
+// in a recommended job, replacing earlier feedback on it.
+func (u Recommendation) JobFeedback(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.RecommendationFeedbackPayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	feedback, err := u.srv.RecommendationServices.GiveFeedback(r.Context(), user.ID, id, payload)
+	if err != nil {
+		u.recommendationError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Feedback saved", feedback, http.StatusOK)
+}
+
+func (u Recommendation) DeleteJobFeedback(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid job id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.RecommendationServices.RemoveFeedback(r.Context(), user.ID, id); err != nil {
+		u.recommendationError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Feedback deleted", nil, http.StatusOK)
+}
+
+// RecommendationFeedbackStats sums up the feedback of the last days days,
+// 30 by default.
+func (u Recommendation) RecommendationFeedbackStats(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if value := r.URL.Query().Get("days"); value != "" {
+		d, err := strconv.Atoi(value)
+		if err != nil || d < 1 || d > 365 {
+			response.Error(w, r, "Bad request", "days must be between 1 and 365", 400, http.StatusBadRequest)
+			return
+		}
+		days = d
+	}
+	stats, err := u.srv.RecommendationServices.FeedbackStats(r.Context(), days)
+	if err != nil {
+		u.recommendationError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Recommendation feedback fetched", stats, http.StatusOK)
+}
@@ -2,12 +2,23 @@ package controller
 
 import (
 	"Inquiro/config"
-	"Inquiro/protos"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/parser"
+	"Inquiro/repositories"
 	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/limiter"
+	"Inquiro/utils/pdf"
 	"Inquiro/utils/response"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc/status"
 )
 
@@ -16,48 +27,334 @@ type Resume struct {
 	cfg config.Application
 }
 
-func (u Resume) ProcessResume(w http.ResponseWriter, r *http.Request) {
+// readUpload reads the multipart resume upload, writing the error response
+// itself when the request is invalid.
+func (u Resume) readUpload(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (models.ResumeUpload, bool) {
 	err := r.ParseMultipartForm(10 << 20)
 	if err != nil {
 		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
 		response.Error(w, r, "Bad request", "File too large", 400, http.StatusBadRequest)
-		return
+		return models.ResumeUpload{}, false
 	}
 	file, header, err := r.FormFile("resume")
 	if err != nil {
 		u.cfg.Logger.Warnw("Could not find resume in request", "error : ", err.Error())
 		response.Error(w, r, "Bad request", "File not found", 400, http.StatusBadRequest)
-		return
+		return models.ResumeUpload{}, false
 	}
 	defer file.Close()
+	var documentID uuid.UUID
+	if value := r.FormValue("document_id"); value != "" {
+		documentID, err = uuid.Parse(value)
+		if err != nil {
+			response.Error(w, r, "Bad request", "Invalid document id", 400, http.StatusBadRequest)
+			return models.ResumeUpload{}, false
+		}
+		// Checked before the parse so a wrong document costs no quota
+		if err := u.srv.ResumeServices.CheckDocument(r.Context(), documentID, userID); err != nil {
+			if errors.Is(err, repositories.ErrResumeDocumentNotFound) {
+				response.Error(w, r, "File not processed", "Resume document does not exist", 404, http.StatusNotFound)
+				return models.ResumeUpload{}, false
+			}
+			u.cfg.Logger.Errorw("Could not check the resume document", "error : ", err.Error())
+			response.Error(w, r, "File not processed", "Internal server error", 500, http.StatusInternalServerError)
+			return models.ResumeUpload{}, false
+		}
+	}
 
 	fileBytes, err := io.ReadAll(file)
 
 	if err != nil {
 		u.cfg.Logger.Warnw("Could not read file", "error : ", err.Error())
 		response.Error(w, r, "File unreadable", "Could not read the file content", 400, http.StatusBadRequest)
+		return models.ResumeUpload{}, false
+	}
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(fileBytes)
+	}
+	u.cfg.Logger.Infow("Reading file successfull, sending to parser", "filename", header.Filename, "size", len(fileBytes))
+	return models.ResumeUpload{
+		UserID:      userID,
+		DocumentID:  documentID,
+		Title:       r.FormValue("title"),
+		FileName:    header.Filename,
+		ContentType: contentType,
+		Content:     fileBytes,
+	}, true
+}
+
+// reserveParse counts the parse against the user's quota, writing the error
+// response itself when it cannot.
+func (u Resume) reserveParse(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (func(), bool) {
+	quota, release, err := u.srv.QuotaServices.ReserveParse(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrQuotaExceeded) {
+			w.Header().Set("Retry-After", retryAfter(time.Until(quota.RetryAfter())))
+			response.Error(w, r, "File not processed", "Resume parsing quota exceeded", 429, http.StatusTooManyRequests)
+			return nil, false
+		}
+		u.cfg.Logger.Errorw("Could not reserve parse quota", "error : ", err.Error())
+		response.Error(w, r, "File not processed", "Internal server error", 500, http.StatusInternalServerError)
+		return nil, false
+	}
+	return release, true
+}
+
+func (u Resume) ProcessResume(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	upload, ok := u.readUpload(w, r, user.ID)
+	if !ok {
+		return
+	}
+	release, ok := u.reserveParse(w, r, user.ID)
+	if !ok {
 		return
 	}
-	u.cfg.Logger.Infow("Reading file successfull, sending to python service", "filename", header.Filename, "size", len(fileBytes))
 	ctx := r.Context()
-	res, err := u.cfg.Grpc.ParseResume(ctx, &protos.ParseResumeRequest{
-		ResumeFileContent: fileBytes,
-		FileName:          header.Filename,
-	})
+	res, err := u.cfg.Parser.Parse(ctx, upload.FileName, upload.Content)
 	if err != nil {
-		u.cfg.Logger.Warnw("Could not send request to python service", "error : ", err.Error())
+		release()
+		u.cfg.Logger.Warnw("Could not parse the resume", "error : ", err.Error())
+		if errors.Is(err, limiter.ErrQueueFull) || errors.Is(err, limiter.ErrQueueTimeout) {
+			w.Header().Set("Retry-After", retryAfter(jobServiceRetryAfter))
+			response.Error(w, r, "File not processed", "The resume parser is busy, try again shortly", 503, http.StatusServiceUnavailable)
+			return
+		}
+		if errors.Is(err, parser.ErrUnsupportedFormat) || errors.Is(err, parser.ErrEmptyResume) || errors.Is(err, pdf.ErrNoText) ||
+			errors.Is(err, pdf.ErrTooLarge) {
+			response.Error(w, r, "File not processed", err.Error(), 422, http.StatusUnprocessableEntity)
+			return
+		}
 		st, _ := status.FromError(err)
 		response.Error(w, r, "File not processed", st.Message(), int(status.Code(err)), http.StatusInternalServerError)
 		return
 	}
-	response.Success(w, r, "File proccessed", struct {
-		JobTitles  []string `json:"job_titles"`
-		Skills     []string `json:"skills"`
-		Experience int32    `json:"experience"`
+	resume, err := u.srv.ResumeServices.StoreResume(ctx, upload, res)
+	if err != nil {
+		release()
+		if errors.Is(err, repositories.ErrResumeDocumentNotFound) {
+			response.Error(w, r, "File not processed", "Resume document does not exist", 404, http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, repositories.ErrResumeConflict) {
+			w.Header().Set("Retry-After", "1")
+			response.Error(w, r, "File not processed", repositories.ErrResumeConflict.Error(), 409, http.StatusConflict)
+			return
+		}
+		response.Error(w, r, "File not processed", "Could not store the resume", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "File proccessed", resume, http.StatusOK)
+
+}
+
+func (u Resume) ListResumes(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	resumes, err := u.srv.ResumeServices.ListResumes(r.Context(), user.ID, pagination)
+	if errors.Is(err, repositories.ErrInvalidCursor) {
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		u.cfg.Logger.Errorw("Could not list resumes", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Could not fetch resumes", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Page(w, r, "Resumes fetched", resumes, pagination.Next, http.StatusOK)
+}
+
+func (u Resume) GetResume(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	resume, err := u.srv.ResumeServices.GetResume(r.Context(), id, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrResumeNotFound) {
+			response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+			return
+		}
+		response.Error(w, r, "Failed", "Could not fetch resume", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Resume fetched", resume, http.StatusOK)
+}
+
+func (u Resume) UpdateResumeProfile(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.ResumeProfilePatch
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	resume, err := u.srv.ResumeServices.UpdateProfile(r.Context(), id, user.ID, payload)
+	if err != nil {
+		if errors.Is(err, repositories.ErrResumeNotFound) {
+			response.Error(w, r, "Update failed", "Resume does not exist", 404, http.StatusNotFound)
+			return
+		}
+		response.Error(w, r, "Update failed", "Could not update the resume", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Resume updated", resume, http.StatusOK)
+}
+
+func (u Resume) ListResumeDocuments(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	documents, err := u.srv.ResumeServices.ListDocuments(r.Context(), user.ID, pagination)
+	if errors.Is(err, repositories.ErrInvalidCursor) {
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		u.cfg.Logger.Errorw("Could not list resume documents", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Could not fetch resumes", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Page(w, r, "Resume documents fetched", documents, pagination.Next, http.StatusOK)
+}
+
+func (u Resume) DiffResumeVersions(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	documentID, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid document id", 400, http.StatusBadRequest)
+		return
+	}
+	from, errFrom := strconv.Atoi(r.URL.Query().Get("from"))
+	to, errTo := strconv.Atoi(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil || from < 1 || to < 1 {
+		response.Error(w, r, "Bad request", "from and to must be version numbers", 400, http.StatusBadRequest)
+		return
+	}
+	diff, err := u.srv.ResumeServices.DiffVersions(r.Context(), documentID, from, to, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrResumeNotFound) {
+			response.Error(w, r, "Failed", "Resume version does not exist", 404, http.StatusNotFound)
+			return
+		}
+		response.Error(w, r, "Failed", "Could not compare the versions", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Resume versions compared", diff, http.StatusOK)
+}
+
+func (u Resume) SetPrimaryResume(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.ResumeServices.SetPrimary(r.Context(), id, user.ID); err != nil {
+		if errors.Is(err, repositories.ErrResumeNotFound) {
+			response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, repositories.ErrResumeConflict) {
+			w.Header().Set("Retry-After", "1")
+			response.Error(w, r, "Failed", repositories.ErrResumeConflict.Error(), 409, http.StatusConflict)
+			return
+		}
+		response.Error(w, r, "Failed", "Could not update the resume", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Primary resume updated", nil, http.StatusOK)
+}
+
+// ATSReport checks the resume against ATS rules. The target job is given as
+// ?keywords=go,docker and/or ?job= with the job description.
+func (u Resume) ATSReport(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	q := r.URL.Query()
+	target := models.ATSTarget{JobDescription: strings.TrimSpace(q.Get("job"))}
+	for _, keyword := range strings.Split(q.Get("keywords"), ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			target.Keywords = append(target.Keywords, keyword)
+		}
+	}
+	if err := json.Validate.Struct(target); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	report, err := u.srv.ATSServices.Report(r.Context(), id, user.ID, target)
+	if err != nil {
+		if errors.Is(err, repositories.ErrResumeNotFound) {
+			response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+			return
+		}
+		u.cfg.Logger.Errorw("Could not build the ATS report", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Could not analyse the resume", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "ATS report generated", report, http.StatusOK)
+}
+
+type batchRelevancyPayload struct {
+	ResumeSkills     []string              `json:"resume_skills" validate:"required,min=1,dive,required,max=100"`
+	ResumeExperience string                `json:"resume_experience" validate:"max=20"`
+	Jobs             []models.RelevancyJob `json:"jobs" validate:"required,min=1,max=50,unique=ID,dive"`
+}
+
+func (u Resume) BatchRelevancy(w http.ResponseWriter, r *http.Request) {
+	var payload batchRelevancyPayload
+	err := json.Read(w, r, &payload)
+	if err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	results := u.srv.RelevancyServices.BatchCalculateRelevancy(ctx, payload.ResumeSkills, payload.ResumeExperience, payload.Jobs)
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	if failed == len(results) {
+		response.Error(w, r, "Relevancy not calculated", "None of the jobs could be scored", 503, http.StatusServiceUnavailable)
+		return
+	}
+	response.Success(w, r, "Relevancy calculated", struct {
+		Results []models.RelevancyResult `json:"results"`
+		Scored  int                      `json:"scored"`
+		Failed  int                      `json:"failed"`
 	}{
-		JobTitles:  res.JobTitles,
-		Skills:     res.Skills,
-		Experience: res.Experience,
+		Results: results,
+		Scored:  len(results) - failed,
+		Failed:  failed,
 	}, http.StatusOK)
-
 }
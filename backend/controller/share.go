@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"net/http"
+)
+
+type Share struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Share) shareError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrResumeNotFound):
+		response.Error(w, r, "Failed", "Resume does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrUserNotFound):
+		response.Error(w, r, "Failed", "Mentor does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrShareNotFound):
+		response.Error(w, r, "Failed", "Share does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, services.ErrNoRedactedFile):
+		response.Error(w, r, "Failed", err.Error(), 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Share request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+func (u Share) ShareResume(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	var payload models.ResumeSharePayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	share, err := u.srv.ShareServices.ShareResume(r.Context(), user, id, payload)
+	if err != nil {
+		u.shareError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Resume shared", share, http.StatusCreated)
+}
+
+func (u Share) ListResumeShares(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	shares, err := u.srv.ShareServices.ListShares(r.Context(), id, user.ID, pagination)
+	if err != nil {
+		u.shareError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Shares fetched", shares, pagination.Next, http.StatusOK)
+}
+
+func (u Share) RevokeResumeShare(w http.ResponseWriter, r *http.Request) {
+	user, _ := middlewares.SessionUser(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid resume id", 400, http.StatusBadRequest)
+		return
+	}
+	shareID, err := uuidParam(r, "shareId")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid share id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.ShareServices.RevokeShare(r.Context(), shareID, id, user.ID); err != nil {
+		u.shareError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Share revoked", nil, http.StatusOK)
+}
+
+func (u Share) ListSharedResumes(w http.ResponseWriter, r *http.Request) {
+	mentor, _ := middlewares.SessionMentor(r.Context())
+	pagination, ok := readPagination(w, r)
+	if !ok {
+		return
+	}
+	shared, err := u.srv.ShareServices.ListSharedWithMentor(r.Context(), mentor.ID, pagination)
+	if err != nil {
+		u.shareError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Shared resumes fetched", shared, pagination.Next, http.StatusOK)
+}
+
+func (u Share) GetSharedResume(w http.ResponseWriter, r *http.Request) {
+	mentor, _ := middlewares.SessionMentor(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid share id", 400, http.StatusBadRequest)
+		return
+	}
+	shared, err := u.srv.ShareServices.GetSharedResume(r.Context(), id, mentor.ID)
+	if err != nil {
+		u.shareError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Shared resume fetched", shared, http.StatusOK)
+}
+
+func (u Share) DownloadSharedResume(w http.ResponseWriter, r *http.Request) {
+	mentor, _ := middlewares.SessionMentor(r.Context())
+	id, err := uuidParam(r, "id")
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid share id", 400, http.StatusBadRequest)
+		return
+	}
+	fileName, content, err := u.srv.ShareServices.GetSharedFile(r.Context(), id, mentor.ID)
+	if err != nil {
+		u.shareError(w, r, err)
+		return
+	}
+	response.File(w, r, fileName, "application/pdf", content)
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/services"
+	"Inquiro/utils/json"
+	"Inquiro/utils/response"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Skill struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func skillIDParam(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+}
+
+func (u Skill) skillError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrSkillNotFound):
+		response.Error(w, r, "Failed", "Skill does not exist", 404, http.StatusNotFound)
+	case errors.Is(err, repositories.ErrDuplicateSkill):
+		response.Error(w, r, "Failed", err.Error(), 409, http.StatusConflict)
+	case errors.Is(err, repositories.ErrDuplicateSkillAlias):
+		response.Error(w, r, "Failed", err.Error(), 409, http.StatusConflict)
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		response.Error(w, r, "Bad request", "Invalid cursor", 400, http.StatusBadRequest)
+	default:
+		u.cfg.Logger.Errorw("Skill request failed", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+	}
+}
+
+func (u Skill) readSkillPayload(w http.ResponseWriter, r *http.Request) (*models.SkillPayload, bool) {
+	var payload models.SkillPayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return nil, false
+	}
+	return &payload, true
+}
+
+func (u Skill) ListSkills(w http.ResponseWriter, r *http.Request) {
+	pagination := &models.PaginatedQuery{}
+	if err := pagination.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	pagination.SetDefaults()
+	if err := json.Validate.Struct(pagination); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	skills, err := u.srv.SkillServices.ListSkills(r.Context(), r.URL.Query().Get("category"), pagination)
+	if err != nil {
+		u.skillError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Skills fetched", skills, pagination.Next, http.StatusOK)
+}
+
+func (u Skill) CreateSkill(w http.ResponseWriter, r *http.Request) {
+	payload, ok := u.readSkillPayload(w, r)
+	if !ok {
+		return
+	}
+	skill, err := u.srv.SkillServices.CreateSkill(r.Context(), *payload)
+	if err != nil {
+		u.skillError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Skill created", skill, http.StatusCreated)
+}
+
+func (u Skill) UpdateSkill(w http.ResponseWriter, r *http.Request) {
+	id, err := skillIDParam(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid skill id", 400, http.StatusBadRequest)
+		return
+	}
+	payload, ok := u.readSkillPayload(w, r)
+	if !ok {
+		return
+	}
+	skill, err := u.srv.SkillServices.UpdateSkill(r.Context(), id, *payload)
+	if err != nil {
+		u.skillError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Skill updated", skill, http.StatusOK)
+}
+
+func (u Skill) DeleteSkill(w http.ResponseWriter, r *http.Request) {
+	id, err := skillIDParam(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", "Invalid skill id", 400, http.StatusBadRequest)
+		return
+	}
+	if err := u.srv.SkillServices.DeleteSkill(r.Context(), id); err != nil {
+		u.skillError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Skill deleted", nil, http.StatusOK)
+}
+
+// ImportSkills accepts the CSV either as the "file" field of a multipart
+// form or as a text/csv body.
+func (u Skill) ImportSkills(w http.ResponseWriter, r *http.Request) {
+	var source io.Reader
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(5 << 20); err != nil {
+			response.Error(w, r, "Bad request", "File too large", 400, http.StatusBadRequest)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			response.Error(w, r, "Bad request", "File not found", 400, http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		source = file
+	} else {
+		source = http.MaxBytesReader(w, r.Body, 5<<20)
+	}
+	result, err := u.srv.SkillServices.ImportCSV(r.Context(), source)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSkillCSV) {
+			response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+			return
+		}
+		u.skillError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Skills imported", result, http.StatusOK)
+}
+
+func (u Skill) ListUnknownSkills(w http.ResponseWriter, r *http.Request) {
+	pagination := &models.PaginatedQuery{}
+	if err := pagination.Parse(r); err != nil {
+		response.Error(w, r, "Bad request", "Invalid pagination", 400, http.StatusBadRequest)
+		return
+	}
+	pagination.SetDefaults()
+	if err := json.Validate.Struct(pagination); err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	unknown, err := u.srv.SkillServices.ListUnknown(r.Context(), pagination)
+	if err != nil {
+		u.skillError(w, r, err)
+		return
+	}
+	response.Page(w, r, "Unknown skills fetched", unknown, pagination.Next, http.StatusOK)
+}
+
+func (u Skill) NormalizeSkills(w http.ResponseWriter, r *http.Request) {
+	var payload models.NormalizeSkillsPayload
+	if err := json.Read(w, r, &payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	if err := json.Validate.Struct(payload); err != nil {
+		u.cfg.Logger.Warnw("Bad request", "error : ", err.Error())
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	skills, err := u.srv.SkillServices.Normalize(r.Context(), payload.Skills)
+	if err != nil {
+		u.skillError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Skills normalized", skills, http.StatusOK)
+}
+
+func (u Skill) SuggestSkills(w http.ResponseWriter, r *http.Request) {
+	query, err := suggestQuery(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	suggestions, err := u.srv.SkillServices.Suggest(r.Context(), query)
+	if err != nil {
+		u.skillError(w, r, err)
+		return
+	}
+	response.Success(w, r, "Skills suggested", suggestions, http.StatusOK)
+}
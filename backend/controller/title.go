@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"Inquiro/config"
+	"Inquiro/services"
+	"Inquiro/utils/response"
+	"net/http"
+)
+
+type Title struct {
+	srv services.Service
+	cfg config.Application
+}
+
+func (u Title) SuggestTitles(w http.ResponseWriter, r *http.Request) {
+	query, err := suggestQuery(r)
+	if err != nil {
+		response.Error(w, r, "Bad request", err.Error(), 400, http.StatusBadRequest)
+		return
+	}
+	suggestions, err := u.srv.TitleServices.Suggest(r.Context(), query)
+	if err != nil {
+		u.cfg.Logger.Errorw("Could not suggest titles", "error : ", err.Error())
+		response.Error(w, r, "Failed", "Internal server error", 500, http.StatusInternalServerError)
+		return
+	}
+	response.Success(w, r, "Titles suggested", suggestions, http.StatusOK)
+}
@@ -0,0 +1,293 @@
+// Package export turns the structured data of a resume into documents users
+// can download, and reads JSON Resume documents back in.
+package export
+
+import (
+	"Inquiro/models"
+	"Inquiro/parser"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	SchemaURL    = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+	otherSkills  = "Other"
+	maxResumeLen = 1 << 20
+)
+
+var (
+	ErrInvalidJSONResume = errors.New("invalid JSON Resume document")
+	ErrEmptyJSONResume   = errors.New("JSON Resume document has no skills, titles or work")
+)
+
+// JSONResume follows the JSON Resume schema (https://jsonresume.org/schema).
+// Only the sections Inquirio knows about are modelled.
+type JSONResume struct {
+	Schema    string      `json:"$schema,omitempty"`
+	Basics    Basics      `json:"basics"`
+	Work      []Work      `json:"work,omitempty"`
+	Education []Education `json:"education,omitempty"`
+	Skills    []Skill     `json:"skills,omitempty"`
+	Meta      *Meta       `json:"meta,omitempty"`
+}
+
+type Basics struct {
+	Name     string    `json:"name,omitempty"`
+	Label    string    `json:"label,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Location *Location `json:"location,omitempty"`
+	Profiles []Profile `json:"profiles,omitempty"`
+}
+
+type Location struct {
+	City        string `json:"city,omitempty"`
+	Region      string `json:"region,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+}
+
+type Profile struct {
+	Network  string `json:"network,omitempty"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type Work struct {
+	Name       string   `json:"name,omitempty"`
+	Position   string   `json:"position,omitempty"`
+	StartDate  string   `json:"startDate,omitempty"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+type Education struct {
+	Institution string `json:"institution,omitempty"`
+	Area        string `json:"area,omitempty"`
+	StudyType   string `json:"studyType,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	EndDate     string `json:"endDate,omitempty"`
+}
+
+type Skill struct {
+	Name     string   `json:"name,omitempty"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type Meta struct {
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	// ExperienceYears is not part of the schema, tools ignore unknown fields
+	ExperienceYears int32 `json:"experienceYears,omitempty"`
+}
+
+// FromResume builds a JSON Resume from the edited profile of a resume. Work
+// entries carry only the positions because employers and dates are not
+// stored. categories maps skill names to their catalog category, skills
+// without one are listed under "Other".
+func FromResume(user *models.User, resume *models.Resume, categories map[string]string) JSONResume {
+	doc := JSONResume{
+		Schema: SchemaURL,
+		Basics: Basics{
+			Name:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+			Email: user.Email,
+		},
+		Meta: &Meta{
+			Version:         fmt.Sprintf("v%d", resume.Version),
+			LastModified:    lastModified(resume).Format(time.RFC3339),
+			ExperienceYears: resume.Profile.Experience,
+		},
+	}
+	if doc.Basics.Name == "" {
+		doc.Basics.Name = user.Username
+	}
+	if len(resume.Profile.JobTitles) > 0 {
+		doc.Basics.Label = resume.Profile.JobTitles[0]
+	}
+	if resume.Profile.Experience > 0 {
+		doc.Basics.Summary = fmt.Sprintf("%d years of professional experience.", resume.Profile.Experience)
+	}
+	for _, title := range resume.Profile.JobTitles {
+		doc.Work = append(doc.Work, Work{Position: title})
+	}
+
+	grouped := map[string][]string{}
+	for _, skill := range resume.Profile.Skills {
+		category := categoryName(categories[skill])
+		grouped[category] = append(grouped[category], skill)
+	}
+	names := make([]string, 0, len(grouped))
+	for name := range grouped {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		// Other goes last, the rest alphabetically
+		if (names[i] == otherSkills) != (names[j] == otherSkills) {
+			return names[j] == otherSkills
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		doc.Skills = append(doc.Skills, Skill{Name: name, Keywords: grouped[name]})
+	}
+	return doc
+}
+
+func lastModified(resume *models.Resume) time.Time {
+	if resume.EditedAt != nil {
+		return resume.EditedAt.UTC()
+	}
+	return resume.UpdatedAt.UTC()
+}
+
+func categoryName(category string) string {
+	if category == "" || category == "other" {
+		return otherSkills
+	}
+	return strings.ToUpper(category[:1]) + category[1:]
+}
+
+// ParseJSONResume reads a JSON Resume document. Unknown fields are allowed
+// because the schema is open and other tools add their own.
+func ParseJSONResume(data []byte) (*JSONResume, error) {
+	if len(data) > maxResumeLen {
+		return nil, fmt.Errorf("%w: document too large", ErrInvalidJSONResume)
+	}
+	var doc JSONResume
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSONResume, err)
+	}
+	return &doc, nil
+}
+
+// Profile derives the structured profile of a JSON Resume: titles from the
+// label and work positions, skills from skill names and keywords, and the
+// experience from the merged work periods.
+func (doc *JSONResume) Profile(now time.Time) (models.ResumeProfile, error) {
+	profile := models.ResumeProfile{JobTitles: []string{}, Skills: []string{}}
+	titles := []string{}
+	if doc.Basics.Label != "" {
+		titles = append(titles, doc.Basics.Label)
+	}
+	for _, work := range doc.Work {
+		titles = append(titles, work.Position)
+	}
+	skills := []string{}
+	for _, skill := range doc.Skills {
+		if len(skill.Keywords) == 0 {
+			skills = append(skills, skill.Name)
+			continue
+		}
+		// A skill with keywords is a group such as "Backend": [Go, SQL]
+		skills = append(skills, skill.Keywords...)
+	}
+	profile.JobTitles = unique(titles)
+	profile.Skills = unique(skills)
+	profile.Experience = workExperience(doc.Work, now)
+	if profile.Experience == 0 && doc.Meta != nil {
+		profile.Experience = doc.Meta.ExperienceYears
+	}
+	if len(profile.JobTitles) == 0 && len(profile.Skills) == 0 && profile.Experience == 0 {
+		return profile, ErrEmptyJSONResume
+	}
+	return profile, nil
+}
+
+// Text returns a plain text rendering of the document, stored as the
+// extracted text of imported resumes.
+func (doc *JSONResume) Text() string {
+	var b strings.Builder
+	line := func(parts ...string) {
+		kept := []string{}
+		for _, p := range parts {
+			if p = strings.TrimSpace(p); p != "" {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) > 0 {
+			b.WriteString(strings.Join(kept, " | "))
+			b.WriteString("\n")
+		}
+	}
+	line(doc.Basics.Name)
+	line(doc.Basics.Label)
+	line(doc.Basics.Email, doc.Basics.Phone, doc.Basics.URL)
+	line(doc.Basics.Summary)
+	for _, work := range doc.Work {
+		line(work.Position, work.Name, period(work.StartDate, work.EndDate))
+		line(work.Summary)
+		for _, h := range work.Highlights {
+			line("- " + h)
+		}
+	}
+	for _, edu := range doc.Education {
+		line(edu.StudyType, edu.Area, edu.Institution, period(edu.StartDate, edu.EndDate))
+	}
+	for _, skill := range doc.Skills {
+		line(joinNonEmpty(": ", skill.Name, strings.Join(skill.Keywords, ", ")))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func period(start string, end string) string {
+	if start == "" {
+		return end
+	}
+	if end == "" {
+		end = "Present"
+	}
+	return start + " - " + end
+}
+
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+// workExperience returns the years covered by the work periods, counting
+// overlapping jobs once. Entries without a start date are skipped and a
+// missing end date means the job is current.
+func workExperience(work []Work, now time.Time) int32 {
+	var periods []parser.Period
+	for _, w := range work {
+		start, ok := parseDate(w.StartDate)
+		if !ok {
+			continue
+		}
+		end, ok := parseDate(w.EndDate)
+		if !ok || end.After(now) {
+			end = now
+		}
+		if end.After(start) {
+			periods = append(periods, parser.Period{Start: start, End: end})
+		}
+	}
+	return parser.Experience(periods)
+}
+
+// parseDate accepts the ISO 8601 forms the schema allows: 2020-01-02,
+// 2020-01 and 2020.
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,118 @@
+package export
+
+import (
+	"Inquiro/utils/pdf"
+	"bytes"
+	"embed"
+	"errors"
+	"html/template"
+	"strings"
+)
+
+//go:embed "templates"
+var FS embed.FS
+
+const DefaultTheme = "classic"
+
+var ErrUnknownTheme = errors.New("unknown theme")
+
+// Theme selects the HTML template of an export and the type sizes of its PDF
+// rendition.
+type Theme struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	NameSize    int    `json:"-"`
+	HeadingSize int    `json:"-"`
+	BodySize    int    `json:"-"`
+}
+
+var themes = []Theme{
+	{Name: "classic", Description: "Serif headings and a single column", NameSize: 22, HeadingSize: 13, BodySize: 10},
+	{Name: "modern", Description: "Accent colour with skills in a sidebar", NameSize: 24, HeadingSize: 12, BodySize: 10},
+	{Name: "compact", Description: "Small type that keeps long resumes on one page", NameSize: 16, HeadingSize: 11, BodySize: 9},
+}
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).ParseFS(FS, "templates/*.tmpl"))
+
+func Themes() []Theme {
+	return themes
+}
+
+// LookupTheme returns the named theme, or the default one for an empty name.
+func LookupTheme(name string) (Theme, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	for _, theme := range themes {
+		if strings.EqualFold(theme.Name, name) {
+			return theme, nil
+		}
+	}
+	return Theme{}, ErrUnknownTheme
+}
+
+func RenderHTML(doc JSONResume, theme Theme) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, theme.Name+".tmpl", doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func RenderPDF(doc JSONResume, theme Theme) []byte {
+	d := pdf.NewDocument(doc.Basics.Name)
+	d.Heading(doc.Basics.Name, theme.NameSize)
+	if doc.Basics.Label != "" {
+		d.Text(doc.Basics.Label, theme.HeadingSize, false, 0)
+	}
+	if contact := joinNonEmpty(" | ", doc.Basics.Email, doc.Basics.Phone, doc.Basics.URL); contact != "" {
+		d.Text(contact, theme.BodySize, false, 0)
+	}
+	if doc.Basics.Summary != "" {
+		d.Space(theme.BodySize)
+		d.Text(doc.Basics.Summary, theme.BodySize, false, 0)
+	}
+	if len(doc.Work) > 0 {
+		d.Heading("Experience", theme.HeadingSize)
+		for _, work := range doc.Work {
+			d.Text(joinNonEmpty(", ", work.Position, work.Name), theme.BodySize, true, 0)
+			if p := period(work.StartDate, work.EndDate); p != "" {
+				d.Text(p, theme.BodySize, false, 0)
+			}
+			if work.Summary != "" {
+				d.Text(work.Summary, theme.BodySize, false, 0)
+			}
+			for _, highlight := range work.Highlights {
+				d.Bullet(highlight, theme.BodySize)
+			}
+		}
+	}
+	if len(doc.Education) > 0 {
+		d.Heading("Education", theme.HeadingSize)
+		for _, edu := range doc.Education {
+			d.Text(joinNonEmpty(", ", edu.StudyType, edu.Area, edu.Institution), theme.BodySize, true, 0)
+			if p := period(edu.StartDate, edu.EndDate); p != "" {
+				d.Text(p, theme.BodySize, false, 0)
+			}
+		}
+	}
+	if len(doc.Skills) > 0 {
+		d.Heading("Skills", theme.HeadingSize)
+		for _, skill := range doc.Skills {
+			d.Bullet(joinNonEmpty(": ", skill.Name, strings.Join(skill.Keywords, ", ")), theme.BodySize)
+		}
+	}
+	return d.Bytes()
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	kept := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
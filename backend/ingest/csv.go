@@ -0,0 +1,81 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var ErrEmptyCSV = errors.New("csv file has no header row")
+
+// CSVSource reads postings from a CSV file with a header row, either an
+// http(s) URL or a path inside ImportDir. Fields maps attributes to column headers, compared
+// without case; attributes that are not mapped are read from the column of
+// the same name. Skills are separated by commas, semicolons or pipes.
+type CSVSource struct {
+	Location string
+	Fields   map[string]string
+	client   *http.Client
+}
+
+func (s *CSVSource) Fetch(ctx context.Context) ([]Posting, error) {
+	body, err := open(ctx, s.client, s.Location)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return parseCSV(io.LimitReader(body, MaxFeedSize), s.Fields)
+}
+
+func parseCSV(source io.Reader, fields map[string]string) ([]Posting, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyCSV
+	}
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]int{}
+	for i, name := range header {
+		// Spreadsheets often save a byte order mark before the first header
+		name = strings.TrimPrefix(name, "\ufeff")
+		headers[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	columns := map[string]int{}
+	for _, attribute := range Attributes {
+		name, ok := fields[attribute]
+		if !ok {
+			name = attribute
+		}
+		if i, ok := headers[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[attribute] = i
+		}
+	}
+
+	postings := []Posting{}
+	for len(postings) < MaxPostings {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var posting Posting
+		for attribute, i := range columns {
+			if i < len(record) {
+				posting.set(attribute, record[i])
+			}
+		}
+		postings = append(postings, posting)
+	}
+	return postings, nil
+}
@@ -0,0 +1,148 @@
+package ingest
+
+import (
+	"Inquiro/models"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// shingleSize is the number of words hashed together by SimHash.
+	shingleSize = 2
+	// maxHammingDistance is how many of the 64 bits the SimHashes of two
+	// descriptions may differ by for the descriptions to count as the same
+	// text. Reworded postings of a role differ by about 10, unrelated
+	// descriptions by about 30.
+	maxHammingDistance = 12
+	// minTitleSimilarity is the share of title words two postings of a role
+	// must have in common.
+	minTitleSimilarity = 0.6
+)
+
+// companySuffixes are dropped from company names, so "Acme Inc." and
+// "ACME" have the same key.
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true, "corp": true,
+	"corporation": true, "co": true, "company": true, "plc": true, "gmbh": true, "ag": true, "sa": true,
+	"bv": true, "pvt": true, "private": true,
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// CompanyKey is the company name in lower case without punctuation or
+// legal suffixes.
+func CompanyKey(company string) string {
+	kept := []string{}
+	for _, word := range words(company) {
+		if !companySuffixes[word] {
+			kept = append(kept, word)
+		}
+	}
+	if len(kept) == 0 {
+		return strings.Join(words(company), " ")
+	}
+	return strings.Join(kept, " ")
+}
+
+// SimHash hashes the overlapping word shingles of text into 64 bits so that
+// similar texts get hashes differing in few bits. Text without words hashes
+// to zero.
+func SimHash(text string) uint64 {
+	tokens := words(text)
+	if len(tokens) == 0 {
+		return 0
+	}
+	size := shingleSize
+	if len(tokens) < size {
+		size = len(tokens)
+	}
+	var weights [64]int
+	for i := 0; i+size <= len(tokens); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(tokens[i:i+size], " ")))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	var hash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			hash |= 1 << bit
+		}
+	}
+	return hash
+}
+
+// Fingerprint returns the fingerprint of the job as written.
+func Fingerprint(job *models.Job) models.JobFingerprint {
+	return models.JobFingerprint{
+		SimHash:    SimHash(job.Description),
+		CompanyKey: CompanyKey(job.Company),
+	}
+}
+
+// TitleSimilarity is the Jaccard index of the words of two titles.
+func TitleSimilarity(a string, b string) float64 {
+	left := map[string]bool{}
+	for _, word := range words(a) {
+		left[word] = true
+	}
+	right := map[string]bool{}
+	for _, word := range words(b) {
+		right[word] = true
+	}
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range left {
+		if right[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(left)+len(right)-shared)
+}
+
+// IsDuplicate reports whether two jobs are postings of the same role: the
+// same company, similar titles, no conflicting city, and descriptions whose
+// SimHashes are close. Postings without descriptions need equal titles.
+func IsDuplicate(a *models.Job, b *models.Job) bool {
+	if a.Fingerprint.CompanyKey == "" || a.Fingerprint.CompanyKey != b.Fingerprint.CompanyKey {
+		return false
+	}
+	if a.City != "" && b.City != "" && !strings.EqualFold(a.City, b.City) {
+		return false
+	}
+	if TitleSimilarity(a.Title, b.Title) < minTitleSimilarity {
+		return false
+	}
+	if a.Fingerprint.SimHash == 0 || b.Fingerprint.SimHash == 0 {
+		return a.Fingerprint.SimHash == b.Fingerprint.SimHash && strings.Join(words(a.Title), " ") == strings.Join(words(b.Title), " ")
+	}
+	return bits.OnesCount64(a.Fingerprint.SimHash^b.Fingerprint.SimHash) <= maxHammingDistance
+}
+
+// FindCanonical returns the first candidate the job duplicates, or nil.
+// Candidates come oldest first, so a role is clustered under its earliest
+// posting.
+func FindCanonical(job *models.Job, candidates []models.Job) *uuid.UUID {
+	for i := range candidates {
+		if candidates[i].ID != job.ID && IsDuplicate(job, &candidates[i]) {
+			return &candidates[i].ID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// JSONSource reads postings from a JSON document served over HTTP.
+// ItemsPath is the dot path of the array of postings, empty when the
+// document is the array itself. Fields maps attributes to dot paths inside
+// a posting; path segments that are numbers index arrays, so
+// "locations.0.country" reads the country of the first location. Attributes
+// that are not mapped are read from the key of the same name.
+type JSONSource struct {
+	URL       string
+	ItemsPath string
+	Fields    map[string]string
+	client    *http.Client
+}
+
+func (s *JSONSource) Fetch(ctx context.Context) ([]Posting, error) {
+	data, err := readAll(ctx, s.client, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(data, s.ItemsPath, s.Fields)
+}
+
+func parseJSON(data []byte, itemsPath string, fields map[string]string) ([]Posting, error) {
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keeps ids such as 12345678901234567890 exact
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	items, ok := lookup(doc, itemsPath).([]any)
+	if !ok {
+		return nil, fmt.Errorf("no array of postings at %q", itemsPath)
+	}
+	if len(items) > MaxPostings {
+		items = items[:MaxPostings]
+	}
+	postings := make([]Posting, 0, len(items))
+	for _, item := range items {
+		var posting Posting
+		for _, attribute := range Attributes {
+			path, ok := fields[attribute]
+			if !ok {
+				path = attribute
+			}
+			value := lookup(item, path)
+			if list, ok := value.([]any); ok && attribute == "skills" {
+				for _, skill := range list {
+					posting.set(attribute, scalar(skill))
+				}
+				continue
+			}
+			posting.set(attribute, scalar(value))
+		}
+		postings = append(postings, posting)
+	}
+	return postings, nil
+}
+
+// lookup follows the dot path from value, returning nil when it leads
+// nowhere.
+func lookup(value any, path string) any {
+	if path == "" {
+		return value
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			value = v[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			value = v[i]
+		default:
+			return nil
+		}
+	}
+	return value
+}
+
+// scalar returns the text of a string, number or boolean. Objects have no
+// text, except for a "name" key, as in {"name": "Go"}.
+func scalar(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]any:
+		if name, ok := v["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
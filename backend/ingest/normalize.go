@@ -0,0 +1,238 @@
+package ingest
+
+import (
+	"Inquiro/models"
+	"errors"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrMissingExternalID = errors.New("posting has no id or url")
+	ErrMissingTitle      = errors.New("posting has no title")
+	ErrMissingCompany    = errors.New("posting has no company")
+)
+
+// Limits matching the columns of jobs and the checks of models.JobPayload.
+const (
+	maxTextLength        = 255
+	maxPlaceLength       = 100
+	maxDescriptionLength = 20000
+	maxSkills            = 50
+	maxExperience        = 60
+)
+
+var (
+	tagExpr      = regexp.MustCompile(`(?s)<[^>]*>`)
+	blockTagExpr = regexp.MustCompile(`(?i)<\s*(?:br|/p|/div|/li|/h[1-6])\b[^>]*>`)
+	blankExpr    = regexp.MustCompile(`[ \t\r\f\v]+`)
+	linesExpr    = regexp.MustCompile(`\n\s*\n+`)
+	// "3-5 years", "3 to 5 yrs", "5+ years", "at least 2 years"
+	rangeExperienceExpr = regexp.MustCompile(`(?i)(\d{1,2})\s*(?:(?:-|–|to)\s*(\d{1,2})|(\+))?\s*\+?\s*(?:years?|yrs?)`)
+	// Descriptions mention years for other reasons, so there the years must
+	// be followed by "experience" within a few words
+	describedExperienceExpr = regexp.MustCompile(`(?i)(\d{1,2})\s*(?:(?:-|–|to)\s*(\d{1,2})|(\+))?\s*\+?\s*(?:years?|yrs?)(?:\s+\S+){0,3}?\s+experience`)
+	remoteWords             = []string{"remote", "anywhere", "work from home", "wfh", "distributed", "telecommute"}
+	dateLayouts             = []string{time.RFC3339, time.RFC1123Z, time.RFC1123, time.RFC822Z, time.RFC822,
+		"Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST", "2006-01-02T15:04:05", "2006-01-02 15:04:05",
+		"2006-01-02"}
+)
+
+// Normalize turns a posting into a job with the attributes job listings
+// filter on: country and city, one of the remote values, and the range of
+// years of experience. Company is used when the posting names none. Skills
+// are trimmed and deduplicated but not yet matched against the catalog.
+func Normalize(posting Posting, company string, now time.Time) (*models.Job, string, error) {
+	externalID := firstOf(posting.ExternalID, posting.URL)
+	if externalID == "" {
+		return nil, "", ErrMissingExternalID
+	}
+	job := &models.Job{
+		Title:       truncate(plainText(posting.Title), maxTextLength),
+		Company:     truncate(plainText(firstOf(posting.Company, company)), maxTextLength),
+		Description: truncate(plainText(posting.Description), maxDescriptionLength),
+		URL:         strings.TrimSpace(posting.URL),
+		PostedAt:    parseDate(posting.PostedAt, now),
+	}
+	if job.Title == "" {
+		return nil, externalID, ErrMissingTitle
+	}
+	if job.Company == "" {
+		return nil, externalID, ErrMissingCompany
+	}
+	if !strings.HasPrefix(job.URL, "http://") && !strings.HasPrefix(job.URL, "https://") {
+		job.URL = ""
+	}
+
+	city, country := splitLocation(posting.Location)
+	job.City = truncate(firstOf(posting.City, city), maxPlaceLength)
+	job.Country = truncate(firstOf(posting.Country, country), maxPlaceLength)
+	job.Remote = remoteValue(posting.Remote, posting.Location)
+
+	job.MinExperience, job.MaxExperience = experienceRange(posting)
+	job.Skills = uniqueSkills(posting.Skills)
+	return job, externalID, nil
+}
+
+// plainText strips HTML from feed text, keeping paragraphs as blank lines.
+func plainText(text string) string {
+	text = blockTagExpr.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(tagExpr.ReplaceAllString(text, ""))
+	text = blankExpr.ReplaceAllString(text, " ")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(linesExpr.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+func truncate(text string, length int) string {
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:length]))
+}
+
+// splitLocation reads "City, Region, Country" as its first and last parts.
+// A single place is taken as the city, and remote markers are no place.
+func splitLocation(location string) (string, string) {
+	parts := []string{}
+	for _, part := range strings.FieldsFunc(location, func(r rune) bool { return r == ',' || r == '/' || r == '(' || r == ')' }) {
+		if part = strings.TrimSpace(part); part != "" && !isRemote(part) {
+			parts = append(parts, part)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return parts[0], ""
+	}
+	return parts[0], parts[len(parts)-1]
+}
+
+func isRemote(text string) bool {
+	text = strings.ToLower(text)
+	for _, word := range remoteWords {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteValue maps the free form remote field, such as "Remote (US)",
+// "yes" or "Hybrid - 2 days", to onsite, hybrid or remote. A location of
+// "Remote" counts when the field is empty.
+func remoteValue(remote string, location string) string {
+	value := strings.ToLower(strings.TrimSpace(remote))
+	switch {
+	case strings.Contains(value, "hybrid"):
+		return models.RemoteHybrid
+	case value == "true" || value == "yes" || value == "1" || isRemote(value):
+		return models.RemoteFull
+	case value == "" && strings.Contains(strings.ToLower(location), "hybrid"):
+		return models.RemoteHybrid
+	case value == "" && isRemote(location):
+		return models.RemoteFull
+	}
+	return models.RemoteOnsite
+}
+
+// experienceRange prefers the mapped bounds, then the experience text, then
+// a mention of experience in the description.
+func experienceRange(posting Posting) (int, *int) {
+	if min, err := strconv.Atoi(strings.TrimSpace(posting.MinExperience)); err == nil {
+		min = clamp(min)
+		if max, err := strconv.Atoi(strings.TrimSpace(posting.MaxExperience)); err == nil && clamp(max) >= min {
+			max = clamp(max)
+			return min, &max
+		}
+		return min, nil
+	}
+	if m := rangeExperienceExpr.FindStringSubmatch(posting.Experience); m != nil {
+		return experienceMatch(m)
+	}
+	if years, err := strconv.Atoi(strings.TrimSpace(posting.Experience)); err == nil {
+		return clamp(years), nil
+	}
+	if m := describedExperienceExpr.FindStringSubmatch(plainText(posting.Description)); m != nil {
+		return experienceMatch(m)
+	}
+	return 0, nil
+}
+
+// experienceMatch reads the submatches of the experience expressions: the
+// lower bound, the upper bound and the "+" marking an open range.
+func experienceMatch(m []string) (int, *int) {
+	min, _ := strconv.Atoi(m[1])
+	min = clamp(min)
+	if m[2] == "" {
+		return min, nil
+	}
+	max, _ := strconv.Atoi(m[2])
+	if max = clamp(max); max < min {
+		return min, nil
+	}
+	return min, &max
+}
+
+func clamp(years int) int {
+	if years < 0 {
+		return 0
+	}
+	if years > maxExperience {
+		return maxExperience
+	}
+	return years
+}
+
+// parseDate reads the dates feeds commonly use and unix timestamps. Missing,
+// unreadable and future dates become the zero time, so the job is dated
+// when it is first stored.
+func parseDate(value string, now time.Time) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	var date time.Time
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds > 1e12 {
+			seconds /= 1000
+		}
+		date = time.Unix(seconds, 0)
+	} else {
+		for _, layout := range dateLayouts {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				date = parsed
+				break
+			}
+		}
+	}
+	if date.IsZero() || date.After(now) {
+		return time.Time{}
+	}
+	return date.UTC()
+}
+
+func uniqueSkills(skills []string) []string {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, skill := range skills {
+		skill = truncate(plainText(skill), maxPlaceLength)
+		key := models.SkillKey(skill)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, skill)
+		if len(unique) == maxSkills {
+			break
+		}
+	}
+	return unique
+}
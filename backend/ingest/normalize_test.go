@@ -0,0 +1,137 @@
+package ingest
+
+import (
+	"Inquiro/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestNormalize(t *testing.T) {
+	posting := Posting{
+		ExternalID:  "42",
+		Title:       " <b>Senior</b> Go Developer ",
+		Description: "<p>Join us.</p><p>You have 5+ years of professional experience &amp; love Go.</p>",
+		Location:    "Berlin, Germany (Remote)",
+		Skills:      []string{"Go", "go", " PostgreSQL ", ""},
+		URL:         "https://example.com/jobs/42",
+		PostedAt:    "2024-05-30T10:00:00Z",
+	}
+	job, externalID, err := Normalize(posting, "Fallback Inc", now)
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if externalID != "42" {
+		t.Errorf("external id = %q", externalID)
+	}
+	if job.Title != "Senior Go Developer" || job.Company != "Fallback Inc" {
+		t.Errorf("title = %q, company = %q", job.Title, job.Company)
+	}
+	if job.Description != "Join us.\nYou have 5+ years of professional experience & love Go." {
+		t.Errorf("description = %q", job.Description)
+	}
+	if job.City != "Berlin" || job.Country != "Germany" || job.Remote != models.RemoteFull {
+		t.Errorf("city = %q, country = %q, remote = %q", job.City, job.Country, job.Remote)
+	}
+	if job.MinExperience != 5 || job.MaxExperience != nil {
+		t.Errorf("experience = %d-%v", job.MinExperience, job.MaxExperience)
+	}
+	if len(job.Skills) != 2 || job.Skills[0] != "Go" || job.Skills[1] != "PostgreSQL" {
+		t.Errorf("skills = %v", job.Skills)
+	}
+	if !job.PostedAt.Equal(time.Date(2024, 5, 30, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("posted at = %v", job.PostedAt)
+	}
+}
+
+func TestNormalizeExperience(t *testing.T) {
+	tests := []struct {
+		posting  Posting
+		min      int
+		max      int
+		hasUpper bool
+	}{
+		{Posting{MinExperience: "2", MaxExperience: "4"}, 2, 4, true},
+		{Posting{MinExperience: "3", MaxExperience: "1"}, 3, 0, false},
+		{Posting{Experience: "3 to 5 yrs"}, 3, 5, true},
+		{Posting{Experience: "7"}, 7, 0, false},
+		{Posting{Experience: "99"}, 60, 0, false},
+		{Posting{Description: "Founded 10 years ago."}, 0, 0, false},
+	}
+	for _, test := range tests {
+		test.posting.ExternalID, test.posting.Title, test.posting.Company = "1", "Title", "Company"
+		job, _, err := Normalize(test.posting, "", now)
+		if err != nil {
+			t.Fatalf("Normalize(%+v): %v", test.posting, err)
+		}
+		if job.MinExperience != test.min || (job.MaxExperience != nil) != test.hasUpper ||
+			(test.hasUpper && *job.MaxExperience != test.max) {
+			t.Errorf("%+v: experience = %d-%v, want %d-%d", test.posting, job.MinExperience, job.MaxExperience, test.min, test.max)
+		}
+	}
+}
+
+func TestNormalizeRemote(t *testing.T) {
+	tests := map[[2]string]string{
+		{"yes", ""}:               models.RemoteFull,
+		{"Hybrid - 2 days", ""}:   models.RemoteHybrid,
+		{"", "Remote"}:            models.RemoteFull,
+		{"", "London (hybrid)"}:   models.RemoteHybrid,
+		{"no", "Remote friendly"}: models.RemoteOnsite,
+		{"", "Paris"}:             models.RemoteOnsite,
+	}
+	for in, want := range tests {
+		posting := Posting{ExternalID: "1", Title: "Title", Company: "Company", Remote: in[0], Location: in[1]}
+		job, _, err := Normalize(posting, "", now)
+		if err != nil {
+			t.Fatalf("Normalize: %v", err)
+		}
+		if job.Remote != want {
+			t.Errorf("remote %q, location %q: got %q, want %q", in[0], in[1], job.Remote, want)
+		}
+	}
+}
+
+func TestNormalizeDates(t *testing.T) {
+	tests := map[string]time.Time{
+		"Thu, 30 May 2024 10:00:00 +0000": time.Date(2024, 5, 30, 10, 0, 0, 0, time.UTC),
+		"2024-05-30":                      time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC),
+		"1717063200":                      time.Unix(1717063200, 0).UTC(),
+		"1717063200000":                   time.Unix(1717063200, 0).UTC(),
+		"2030-01-01":                      {},
+		"yesterday":                       {},
+	}
+	for in, want := range tests {
+		posting := Posting{ExternalID: "1", Title: "Title", Company: "Company", PostedAt: in}
+		job, _, err := Normalize(posting, "", now)
+		if err != nil {
+			t.Fatalf("Normalize: %v", err)
+		}
+		if !job.PostedAt.Equal(want) {
+			t.Errorf("%q: got %v, want %v", in, job.PostedAt, want)
+		}
+	}
+}
+
+func TestNormalizeRequiredFields(t *testing.T) {
+	tests := []struct {
+		posting Posting
+		err     error
+	}{
+		{Posting{Title: "Title", Company: "Company"}, ErrMissingExternalID},
+		{Posting{ExternalID: "1", Title: "<br>", Company: "Company"}, ErrMissingTitle},
+		{Posting{ExternalID: "1", Title: "Title"}, ErrMissingCompany},
+	}
+	for _, test := range tests {
+		if _, _, err := Normalize(test.posting, "", now); !errors.Is(err, test.err) {
+			t.Errorf("%+v: err = %v, want %v", test.posting, err, test.err)
+		}
+	}
+	// Only http(s) links are kept
+	job, externalID, err := Normalize(Posting{URL: "javascript:alert(1)", Title: "Title", Company: "Company"}, "", now)
+	if err != nil || externalID != "javascript:alert(1)" || job.URL != "" {
+		t.Errorf("job = %+v, external id = %q, err = %v", job, externalID, err)
+	}
+}
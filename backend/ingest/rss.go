@@ -0,0 +1,148 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var ErrNotAFeed = errors.New("document is neither an RSS nor an Atom feed")
+
+// RSSSource reads RSS 2.0 and Atom feeds. Entries map to postings by the
+// elements both formats share: id or guid, title, link, summary or content,
+// category, author and publication date. The author is taken as the
+// company, since job boards publish each posting under the employer.
+type RSSSource struct {
+	URL    string
+	client *http.Client
+}
+
+type rssItem struct {
+	GUID        string   `xml:"guid"`
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomEntry struct {
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Links      []atomLink `xml:"link"`
+	Summary    string     `xml:"summary"`
+	Content    string     `xml:"content"`
+	Author     string     `xml:"author>name"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+}
+
+type feed struct {
+	XMLName xml.Name
+	// RSS
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	// RSS 1.0 keeps its items next to the channel
+	Items []rssItem `xml:"item"`
+	// Atom
+	Entries []atomEntry `xml:"entry"`
+}
+
+func (s *RSSSource) Fetch(ctx context.Context) ([]Posting, error) {
+	data, err := readAll(ctx, s.client, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	return parseFeed(data)
+}
+
+func parseFeed(data []byte) ([]Posting, error) {
+	var doc feed
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	// Feeds in other encodings are read as UTF-8, which keeps ASCII intact
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	postings := []Posting{}
+	switch doc.XMLName.Local {
+	case "rss", "RDF":
+		for _, item := range append(doc.Channel.Items, doc.Items...) {
+			postings = append(postings, item.posting())
+		}
+	case "feed":
+		for _, entry := range doc.Entries {
+			postings = append(postings, entry.posting())
+		}
+	default:
+		return nil, ErrNotAFeed
+	}
+	if len(postings) > MaxPostings {
+		postings = postings[:MaxPostings]
+	}
+	return postings, nil
+}
+
+func (item rssItem) posting() Posting {
+	posting := Posting{
+		ExternalID:  firstOf(item.GUID, item.Link),
+		Title:       strings.TrimSpace(item.Title),
+		Company:     firstOf(item.Creator, item.Author),
+		Description: firstOf(item.Content, item.Description),
+		URL:         strings.TrimSpace(item.Link),
+		PostedAt:    strings.TrimSpace(item.PubDate),
+	}
+	for _, category := range item.Categories {
+		posting.Skills = append(posting.Skills, splitList(category)...)
+	}
+	return posting
+}
+
+func (entry atomEntry) posting() Posting {
+	link := ""
+	for _, l := range entry.Links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			link = l.Href
+			break
+		}
+	}
+	posting := Posting{
+		ExternalID:  firstOf(entry.ID, link),
+		Title:       strings.TrimSpace(entry.Title),
+		Company:     strings.TrimSpace(entry.Author),
+		Description: firstOf(entry.Content, entry.Summary),
+		URL:         strings.TrimSpace(link),
+		PostedAt:    firstOf(entry.Published, entry.Updated),
+	}
+	for _, category := range entry.Categories {
+		posting.Skills = append(posting.Skills, splitList(category.Term)...)
+	}
+	return posting
+}
+
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,234 @@
+package ingest
+
+import (
+	"Inquiro/config/env"
+	"Inquiro/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrInvalidSource = errors.New("invalid job source")
+	ErrFeedTooLarge  = errors.New("job feed is too large")
+)
+
+// MaxFeedSize bounds how much of a feed is read, and MaxPostings how many
+// postings are taken from it.
+var (
+	MaxFeedSize int64 = 20 << 20
+	MaxPostings       = 5000
+)
+
+// ImportDir is the only directory CSV sources may read local files from.
+// Local files are refused when it is empty.
+var ImportDir = env.GetString("JOB_IMPORT_DIR", "")
+
+// Attributes a posting can be mapped from. Experience takes free text such
+// as "3-5 years" and is only used when the bounds are not mapped directly.
+var Attributes = []string{"external_id", "title", "company", "description", "location", "country", "city",
+	"remote", "experience", "min_experience", "max_experience", "skills", "url", "posted_at"}
+
+// Posting is a job as a feed states it, before normalization.
+type Posting struct {
+	ExternalID    string
+	Title         string
+	Company       string
+	Description   string
+	Location      string
+	Country       string
+	City          string
+	Remote        string
+	Experience    string
+	MinExperience string
+	MaxExperience string
+	Skills        []string
+	URL           string
+	PostedAt      string
+}
+
+// set stores value under the attribute name, splitting skill lists.
+func (p *Posting) set(attribute string, value string) {
+	value = strings.TrimSpace(value)
+	switch attribute {
+	case "external_id":
+		p.ExternalID = value
+	case "title":
+		p.Title = value
+	case "company":
+		p.Company = value
+	case "description":
+		p.Description = value
+	case "location":
+		p.Location = value
+	case "country":
+		p.Country = value
+	case "city":
+		p.City = value
+	case "remote":
+		p.Remote = value
+	case "experience":
+		p.Experience = value
+	case "min_experience":
+		p.MinExperience = value
+	case "max_experience":
+		p.MaxExperience = value
+	case "skills":
+		p.Skills = append(p.Skills, splitList(value)...)
+	case "url":
+		p.URL = value
+	case "posted_at":
+		p.PostedAt = value
+	}
+}
+
+// JobSource reads the postings of a feed.
+type JobSource interface {
+	Fetch(ctx context.Context) ([]Posting, error)
+}
+
+// New returns the adapter for the kind of the source. Feeds are fetched
+// with client, so tests can point sources at local servers.
+func New(source *models.JobSource, client *http.Client) (JobSource, error) {
+	for attribute := range source.Fields {
+		if !isAttribute(attribute) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSource, attribute)
+		}
+	}
+	remote := strings.HasPrefix(source.URL, "http://") || strings.HasPrefix(source.URL, "https://")
+	switch source.Kind {
+	case models.JobSourceRSS:
+		if !remote {
+			return nil, fmt.Errorf("%w: feed url must be http or https", ErrInvalidSource)
+		}
+		return &RSSSource{URL: source.URL, client: client}, nil
+	case models.JobSourceJSON:
+		if !remote {
+			return nil, fmt.Errorf("%w: feed url must be http or https", ErrInvalidSource)
+		}
+		return &JSONSource{URL: source.URL, ItemsPath: source.ItemsPath, Fields: source.Fields, client: client}, nil
+	case models.JobSourceCSV:
+		if !remote {
+			if _, err := localPath(source.URL); err != nil {
+				return nil, err
+			}
+		}
+		return &CSVSource{Location: source.URL, Fields: source.Fields, client: client}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidSource, source.Kind)
+}
+
+func isAttribute(name string) bool {
+	for _, attribute := range Attributes {
+		if attribute == name {
+			return true
+		}
+	}
+	return false
+}
+
+// localPath returns the path of location inside ImportDir, relative
+// locations being read from it. Paths leading outside of it are refused.
+func localPath(location string) (string, error) {
+	if ImportDir == "" {
+		return "", fmt.Errorf("%w: local files are not enabled, use an http or https url", ErrInvalidSource)
+	}
+	root, err := filepath.Abs(ImportDir)
+	if err != nil {
+		return "", err
+	}
+	path := location
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+	if !within(root, path) {
+		return "", fmt.Errorf("%w: file must be inside the import directory", ErrInvalidSource)
+	}
+	return path, nil
+}
+
+func within(root string, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// openLocal opens the file at location inside ImportDir. Links are followed
+// before checking, so one placed in the directory cannot lead out of it.
+func openLocal(location string) (io.ReadCloser, error) {
+	path, err := localPath(location)
+	if err != nil {
+		return nil, err
+	}
+	root, err := filepath.EvalSymlinks(ImportDir)
+	if err != nil {
+		return nil, err
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return nil, err
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: file not found in the import directory", ErrInvalidSource)
+	}
+	if !within(root, resolved) {
+		return nil, fmt.Errorf("%w: file must be inside the import directory", ErrInvalidSource)
+	}
+	return os.Open(resolved)
+}
+
+// open reads location over http(s), or from ImportDir when it is not a URL.
+func open(ctx context.Context, client *http.Client, location string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return openLocal(location)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Inquiro-JobImporter/1.0")
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		res.Body.Close()
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", location, res.Status)
+	}
+	return res.Body, nil
+}
+
+// readAll reads the whole feed, up to MaxFeedSize.
+func readAll(ctx context.Context, client *http.Client, location string) ([]byte, error) {
+	body, err := open(ctx, client, location)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, MaxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > MaxFeedSize {
+		return nil, ErrFeedTooLarge
+	}
+	return data, nil
+}
+
+// splitList splits a list of skills or tags on commas, semicolons and pipes.
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|'
+	}) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,223 @@
+package ingest
+
+import (
+	"Inquiro/models"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// serve returns a server answering every request with body as contentType.
+func serve(t *testing.T, contentType string, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func fetch(t *testing.T, source *models.JobSource, client *http.Client) []Posting {
+	t.Helper()
+	adapter, err := New(source, client)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	postings, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	return postings
+}
+
+func TestRSSSource(t *testing.T) {
+	server := serve(t, "application/rss+xml", `<?xml version="1.0"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+<item>
+	<guid>job-1</guid>
+	<title>Go Developer</title>
+	<link>https://example.com/jobs/1</link>
+	<description>&lt;p&gt;Build APIs&lt;/p&gt;</description>
+	<dc:creator>Acme</dc:creator>
+	<category>Go, PostgreSQL</category>
+	<pubDate>Mon, 02 Jan 2006 15:04:05 -0700</pubDate>
+</item>
+</channel>
+</rss>`)
+	postings := fetch(t, &models.JobSource{Kind: models.JobSourceRSS, URL: server.URL}, server.Client())
+	if len(postings) != 1 {
+		t.Fatalf("got %d postings, want 1", len(postings))
+	}
+	p := postings[0]
+	if p.ExternalID != "job-1" || p.Title != "Go Developer" || p.Company != "Acme" || p.URL != "https://example.com/jobs/1" {
+		t.Errorf("unexpected posting %+v", p)
+	}
+	if p.Description != "<p>Build APIs</p>" {
+		t.Errorf("description = %q", p.Description)
+	}
+	if len(p.Skills) != 2 || p.Skills[0] != "Go" || p.Skills[1] != "PostgreSQL" {
+		t.Errorf("skills = %v", p.Skills)
+	}
+}
+
+func TestAtomSource(t *testing.T) {
+	server := serve(t, "application/atom+xml", `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+<entry>
+	<id>urn:job:2</id>
+	<title>Data Engineer</title>
+	<link rel="alternate" href="https://example.com/jobs/2"/>
+	<summary>Pipelines</summary>
+	<author><name>Globex</name></author>
+	<category term="Python"/>
+	<updated>2006-01-02T15:04:05Z</updated>
+</entry>
+</feed>`)
+	postings := fetch(t, &models.JobSource{Kind: models.JobSourceRSS, URL: server.URL}, server.Client())
+	if len(postings) != 1 {
+		t.Fatalf("got %d postings, want 1", len(postings))
+	}
+	p := postings[0]
+	if p.ExternalID != "urn:job:2" || p.Company != "Globex" || p.URL != "https://example.com/jobs/2" ||
+		p.PostedAt != "2006-01-02T15:04:05Z" || len(p.Skills) != 1 || p.Skills[0] != "Python" {
+		t.Errorf("unexpected posting %+v", p)
+	}
+}
+
+func TestRSSSourceRejectsOtherDocuments(t *testing.T) {
+	server := serve(t, "application/xml", `<html><body>Not a feed</body></html>`)
+	adapter, err := New(&models.JobSource{Kind: models.JobSourceRSS, URL: server.URL}, server.Client())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := adapter.Fetch(context.Background()); !errors.Is(err, ErrNotAFeed) {
+		t.Errorf("err = %v, want ErrNotAFeed", err)
+	}
+}
+
+func TestJSONSourceFieldMapping(t *testing.T) {
+	server := serve(t, "application/json", `{"data": {"jobs": [
+		{"id": 12345678901234567890, "name": "Backend Engineer", "employer": {"name": "Initech"},
+		 "locations": [{"city": "Berlin", "country": "Germany"}], "tags": [{"name": "Go"}, "Kubernetes"],
+		 "remote": true, "url": "https://example.com/jobs/3"}
+	]}}`)
+	source := &models.JobSource{
+		Kind:      models.JobSourceJSON,
+		URL:       server.URL,
+		ItemsPath: "data.jobs",
+		Fields: map[string]string{
+			"external_id": "id",
+			"title":       "name",
+			"company":     "employer",
+			"city":        "locations.0.city",
+			"country":     "locations.0.country",
+			"skills":      "tags",
+		},
+	}
+	postings := fetch(t, source, server.Client())
+	if len(postings) != 1 {
+		t.Fatalf("got %d postings, want 1", len(postings))
+	}
+	p := postings[0]
+	if p.ExternalID != "12345678901234567890" || p.Title != "Backend Engineer" || p.Company != "Initech" {
+		t.Errorf("unexpected posting %+v", p)
+	}
+	if p.City != "Berlin" || p.Country != "Germany" || p.Remote != "true" || p.URL != "https://example.com/jobs/3" {
+		t.Errorf("unexpected posting %+v", p)
+	}
+	if len(p.Skills) != 2 || p.Skills[0] != "Go" || p.Skills[1] != "Kubernetes" {
+		t.Errorf("skills = %v", p.Skills)
+	}
+}
+
+func TestJSONSourceRejectsUnknownFields(t *testing.T) {
+	_, err := New(&models.JobSource{Kind: models.JobSourceJSON, URL: "http://localhost",
+		Fields: map[string]string{"salary": "pay"}}, http.DefaultClient)
+	if !errors.Is(err, ErrInvalidSource) {
+		t.Errorf("err = %v, want ErrInvalidSource", err)
+	}
+}
+
+const csvFeed = "\ufeffJob ID,Position,Company,Location,Years,Skills\n" +
+	"7,QA Engineer,Umbrella,\"Lyon, France\",3-5 years,Selenium|Java\n"
+
+func TestCSVSource(t *testing.T) {
+	server := serve(t, "text/csv", csvFeed)
+	source := &models.JobSource{
+		Kind:   models.JobSourceCSV,
+		URL:    server.URL,
+		Fields: map[string]string{"external_id": "job id", "title": "Position", "experience": "Years"},
+	}
+	postings := fetch(t, source, server.Client())
+	if len(postings) != 1 {
+		t.Fatalf("got %d postings, want 1", len(postings))
+	}
+	p := postings[0]
+	if p.ExternalID != "7" || p.Title != "QA Engineer" || p.Company != "Umbrella" || p.Location != "Lyon, France" ||
+		p.Experience != "3-5 years" {
+		t.Errorf("unexpected posting %+v", p)
+	}
+	if len(p.Skills) != 2 || p.Skills[0] != "Selenium" || p.Skills[1] != "Java" {
+		t.Errorf("skills = %v", p.Skills)
+	}
+}
+
+func TestCSVSourceLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "jobs.csv"), []byte(csvFeed), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "secret.csv")
+	if err := os.WriteFile(outside, []byte(csvFeed), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "link.csv")); err != nil {
+		t.Fatal(err)
+	}
+	previous := ImportDir
+	t.Cleanup(func() { ImportDir = previous })
+
+	ImportDir = ""
+	if _, err := New(&models.JobSource{Kind: models.JobSourceCSV, URL: "jobs.csv"}, nil); !errors.Is(err, ErrInvalidSource) {
+		t.Errorf("without an import directory err = %v, want ErrInvalidSource", err)
+	}
+
+	ImportDir = dir
+	for _, location := range []string{"jobs.csv", filepath.Join(dir, "jobs.csv")} {
+		if postings := fetch(t, &models.JobSource{Kind: models.JobSourceCSV, URL: location}, nil); len(postings) != 1 {
+			t.Errorf("%s: got %d postings, want 1", location, len(postings))
+		}
+	}
+	for _, location := range []string{outside, "../secret.csv", "/etc/passwd"} {
+		if _, err := New(&models.JobSource{Kind: models.JobSourceCSV, URL: location}, nil); !errors.Is(err, ErrInvalidSource) {
+			t.Errorf("%s: err = %v, want ErrInvalidSource", location, err)
+		}
+	}
+	adapter, err := New(&models.JobSource{Kind: models.JobSourceCSV, URL: "link.csv"}, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := adapter.Fetch(context.Background()); !errors.Is(err, ErrInvalidSource) {
+		t.Errorf("link out of the directory: err = %v, want ErrInvalidSource", err)
+	}
+}
+
+func TestFeedSizeLimit(t *testing.T) {
+	previous := MaxFeedSize
+	MaxFeedSize = 16
+	t.Cleanup(func() { MaxFeedSize = previous })
+	server := serve(t, "application/json", `[{"title": "A very long posting"}]`)
+	adapter, err := New(&models.JobSource{Kind: models.JobSourceJSON, URL: server.URL}, server.Client())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := adapter.Fetch(context.Background()); !errors.Is(err, ErrFeedTooLarge) {
+		t.Errorf("err = %v, want ErrFeedTooLarge", err)
+	}
+}
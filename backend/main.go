@@ -6,16 +6,23 @@ import (
 	"Inquiro/config/env"
 	"Inquiro/controller"
 	"Inquiro/db"
+	"Inquiro/middlewares"
+	"Inquiro/parser"
 	jobpb "Inquiro/protos"
 	"Inquiro/repositories"
 	"Inquiro/routes"
 	"Inquiro/services"
+	"Inquiro/utils/cursor"
+	"Inquiro/utils/grpcauth"
+	"Inquiro/utils/limiter"
 	"Inquiro/utils/mailer"
 	_ "Inquiro/utils/token"
+	"context"
 	"time"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -26,11 +33,28 @@ const (
 	PythonServerAddress = "localhost:50051"
 )
 
+// Limits on concurrent calls to the job service, see limiter.Semaphore.
+var (
+	jobServiceMaxInFlight = env.GetInt("JOB_SERVICE_MAX_INFLIGHT", 8)
+	jobServiceMaxQueue    = env.GetInt("JOB_SERVICE_MAX_QUEUE", 32)
+	jobServiceQueueWait   = time.Duration(env.GetInt("JOB_SERVICE_QUEUE_WAIT_SECONDS", 10)) * time.Second
+)
+
+// How often due job sources, due job alerts, recommendations outdated by
+// job or resume changes and due bookmark reminders are looked for.
+var (
+	jobIngestTick        = time.Duration(env.GetInt("JOB_INGEST_TICK_SECONDS", 60)) * time.Second
+	jobAlertTick         = time.Duration(env.GetInt("JOB_ALERT_TICK_SECONDS", 60)) * time.Second
+	recommendationTick   = time.Duration(env.GetInt("RECOMMENDATION_TICK_SECONDS", 300)) * time.Second
+	bookmarkReminderTick = time.Duration(env.GetInt("BOOKMARK_REMINDER_TICK_SECONDS", 60)) * time.Second
+)
+
 func main() {
 	logger := zap.Must(zap.NewProduction()).Sugar()
 	configuration := config.Config{
 		Addr:        "localhost:8080",
 		FrontendURL: "http://localhost:3000",
+		APIURL:      env.GetString("API_URL", "http://localhost:8080"),
 		DBConfig: config.DBConfig{
 			Host:     env.GetString("DB_HOST", "postgres"),
 			User:     env.GetString("DB_USER", "admin"),
@@ -42,9 +66,43 @@ func main() {
 			APIKey:    env.GetString("RESEND_API", "re_2fo8WcM7_6uNEbMPou98kjNKoMZpoFsxw"),
 			FromEmail: env.GetString("RESEND_FROM_EMAIL", "support@bloggerspot.xyz"),
 		},
+		JobService: config.JobServiceConfig{
+			Address:     env.GetString("JOB_SERVICE_ADDR", PythonServerAddress),
+			CAFile:      env.GetString("JOB_SERVICE_CA_FILE", ""),
+			CertFile:    env.GetString("JOB_SERVICE_CERT_FILE", ""),
+			KeyFile:     env.GetString("JOB_SERVICE_KEY_FILE", ""),
+			ServerName:  env.GetString("JOB_SERVICE_SERVER_NAME", ""),
+			TokenSecret: env.GetString("JOB_SERVICE_TOKEN_SECRET", ""),
+		},
+	}
+	if cursor.Ephemeral {
+		logger.Warnw("CURSOR_SECRET not set, page cursors are signed with a random secret and stop working on restart")
+	}
+	jobServiceLimit := limiter.NewSemaphore(jobServiceMaxInFlight, jobServiceMaxQueue, jobServiceQueueWait)
+	creds := insecure.NewCredentials()
+	if configuration.JobService.CAFile != "" {
+		tlsCreds, err := grpcauth.TLSCredentials(configuration.JobService.CAFile, configuration.JobService.CertFile,
+			configuration.JobService.KeyFile, configuration.JobService.ServerName)
+		if err != nil {
+			logger.Fatalf("failed to load job service certificates: %v", err.Error())
+		}
+		creds = tlsCreds
+	} else {
+		logger.Warnw("JOB_SERVICE_CA_FILE not set, connecting to the job service without TLS")
+	}
+	var signer *grpcauth.Signer
+	if configuration.JobService.TokenSecret != "" {
+		signer = grpcauth.NewSigner(configuration.JobService.TokenSecret)
+	} else {
+		logger.Warnw("JOB_SERVICE_TOKEN_SECRET not set, calls to the job service are unauthenticated")
 	}
-	conn, err := grpc.NewClient(PythonServerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	logger.Infow("Connecting to python service", "address : ", PythonServerAddress)
+	// The limiter runs first so tokens are minted after waiting for a slot
+	conn, err := grpc.NewClient(configuration.JobService.Address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(limiter.UnaryClientInterceptor(jobServiceLimit), grpcauth.UnaryClientInterceptor(signer)),
+		grpc.WithChainStreamInterceptor(limiter.StreamClientInterceptor(jobServiceLimit), grpcauth.StreamClientInterceptor(signer)),
+	)
+	logger.Infow("Connecting to python service", "address : ", configuration.JobService.Address)
 	if err != nil {
 		logger.Fatalf("failed to connect to job service: %v", err.Error())
 	}
@@ -57,6 +115,7 @@ func main() {
 		Mail:   mailer,
 		Logger: logger,
 		Grpc:   grpcClient,
+		Parser: parser.NewFallbackParser(parser.NewGrpcParser(grpcClient), parser.NewLocalParser(), logger),
 	}
 	defer logger.Sync()
 
@@ -71,12 +130,13 @@ func main() {
 	}
 	defer db_conn.Close()
 	r := chi.NewRouter()
+	r.Use(chimiddleware.RequestID)
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Link-Password", "Last-Event-ID"},
+		ExposedHeaders:   []string{"Link", "Retry-After", "Location"},
 		AllowCredentials: true,
 		MaxAge:           300, // Maximum value not ignored by any major browsers
 	}))
@@ -96,22 +156,61 @@ func main() {
 		cfg.Store,
 		cfg.Logger,
 		cfg.Mail,
+		cfg.Grpc,
+		cfg.Parser,
+		cfg.Config.FrontendURL,
+		cfg.Config.APIURL,
 	)
+	go srv.IngestServices.Schedule(context.Background(), jobIngestTick)
+	go srv.AlertServices.Schedule(context.Background(), jobAlertTick)
+	go srv.RecommendationServices.Schedule(context.Background(), recommendationTick)
+	go srv.BookmarkServices.Schedule(context.Background(), bookmarkReminderTick)
+	middleware := middlewares.NewMiddleware(cfg)
 	userController := controller.NewController(srv, cfg)
 	userRoutes := routes.NewUserRoutes(userController)
 	userRoutes.RegisterUserRoutes(apiRouter)
 
 	logger.Infof("registering mentor routes")
 	mentorController := controller.NewController(srv, cfg)
-	mentorRoutes := routes.NewMentorRoutes(mentorController)
+	mentorRoutes := routes.NewMentorRoutes(mentorController, middleware)
 	mentorRoutes.RegisterMentorRoutes(apiRouter)
 
 	// Handling resumes
 	logger.Infof("regiter resume routes")
 	resumeController := controller.NewController(srv, cfg)
-	resumeRoutes := routes.NewResumeRoutes(resumeController)
+	resumeRoutes := routes.NewResumeRoutes(resumeController, middleware)
 	resumeRoutes.RegisterResumeRoutes(apiRouter)
 
+	logger.Infof("registering skill routes")
+	skillController := controller.NewController(srv, cfg)
+	skillRoutes := routes.NewSkillRoutes(skillController, middleware)
+	skillRoutes.RegisterSkillRoutes(apiRouter)
+
+	logger.Infof("registering job routes")
+	jobController := controller.NewController(srv, cfg)
+	jobRoutes := routes.NewJobRoutes(jobController, middleware)
+	jobRoutes.RegisterJobRoutes(apiRouter)
+
+	logger.Infof("registering application routes")
+	applicationController := controller.NewController(srv, cfg)
+	applicationRoutes := routes.NewApplicationRoutes(applicationController, middleware)
+	applicationRoutes.RegisterApplicationRoutes(apiRouter)
+
+	logger.Infof("registering company routes")
+	companyController := controller.NewController(srv, cfg)
+	companyRoutes := routes.NewCompanyRoutes(companyController, middleware)
+	companyRoutes.RegisterCompanyRoutes(apiRouter)
+
+	logger.Infof("registering me routes")
+	meController := controller.NewController(srv, cfg)
+	meRoutes := routes.NewMeRoutes(meController, middleware)
+	meRoutes.RegisterMeRoutes(apiRouter)
+
+	logger.Infof("registering public routes")
+	publicController := controller.NewController(srv, cfg)
+	publicRoutes := routes.NewPublicRoutes(publicController)
+	publicRoutes.RegisterPublicRoutes(apiRouter)
+
 	r.Mount("/api", apiRouter)
 
 	Run(cfg, r)
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"Inquiro/config"
+	"Inquiro/models"
 	"Inquiro/utils/response"
 	"context"
 	"net/http"
@@ -50,3 +51,64 @@ func (a Auth) LoadUser() func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// LoadMentor is LoadUser for mentors, who log in with a separate account.
+func (a Auth) LoadMentor() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if !a.cfg.Session.Exists(ctx, "mentorId") {
+				a.cfg.Logger.Errorw("mentor not logged in", "error :", "mentor not logged in")
+				response.Error(w, r, "Failed", "Not authorized", 401, http.StatusUnauthorized)
+				return
+			}
+			id, err := uuid.Parse(a.cfg.Session.GetString(ctx, "mentorId"))
+			if err != nil {
+				a.cfg.Session.Clear(ctx)
+				a.cfg.Logger.Errorw("invalid session data in request", "error :", err.Error())
+				response.Error(w, r, "Failed", "Not authorized", 401, http.StatusUnauthorized)
+				return
+			}
+			mentor, err := a.cfg.Store.Mentor.GetByID(ctx, id)
+			if err != nil {
+				a.cfg.Session.Clear(ctx)
+				a.cfg.Logger.Errorw("no mentor found with this id", "error :", err.Error())
+				response.Error(w, r, "Failed", "No mentor found", 401, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, "sessionMentor", mentor)))
+		})
+	}
+}
+
+// SessionMentor returns the mentor stored in the context by LoadMentor.
+func SessionMentor(ctx context.Context) (*models.Mentor, bool) {
+	mentor, ok := ctx.Value("sessionMentor").(*models.Mentor)
+	return mentor, ok
+}
+
+// SessionUser returns the user stored in the context by LoadUser.
+func SessionUser(ctx context.Context) (*models.User, bool) {
+	user, ok := ctx.Value("sessionUser").(*models.User)
+	return user, ok
+}
+
+// RequireRole rejects users whose role level is below level. It must run
+// after LoadUser.
+func (a Auth) RequireRole(level int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := SessionUser(r.Context())
+			if !ok {
+				response.Error(w, r, "Failed", "Not authorized", 401, http.StatusUnauthorized)
+				return
+			}
+			if user.Role.Level < level {
+				a.cfg.Logger.Warnw("user lacks the required role", "user", user.ID, "level", user.Role.Level, "required", level)
+				response.Error(w, r, "Failed", "Forbidden", 403, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
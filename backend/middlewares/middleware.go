@@ -8,6 +8,8 @@ import (
 type Middleware struct {
 	Auth interface {
 		LoadUser() func(http.Handler) http.Handler
+		LoadMentor() func(http.Handler) http.Handler
+		RequireRole(level int) func(http.Handler) http.Handler
 	}
 }
 
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS resumes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    file_content BYTEA NOT NULL,
+    extracted_text TEXT NOT NULL DEFAULT '',
+    degraded BOOLEAN NOT NULL DEFAULT FALSE,
+    -- machine output as returned by the parser, never edited
+    parsed_job_titles TEXT[] NOT NULL DEFAULT '{}',
+    parsed_skills TEXT[] NOT NULL DEFAULT '{}',
+    parsed_experience INT NOT NULL DEFAULT 0,
+    -- profile shown to the user and used for matching, editable
+    job_titles TEXT[] NOT NULL DEFAULT '{}',
+    skills TEXT[] NOT NULL DEFAULT '{}',
+    experience INT NOT NULL DEFAULT 0,
+    edited_at timestamp(0) WITH time zone,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS resumes_user_id_idx ON resumes (user_id);
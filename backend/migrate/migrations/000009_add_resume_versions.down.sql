@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS resumes_primary_idx;
+DROP INDEX IF EXISTS resumes_document_version_idx;
+ALTER TABLE resumes
+    DROP COLUMN IF EXISTS is_primary,
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS document_id;
+DROP TABLE IF EXISTS resume_documents;
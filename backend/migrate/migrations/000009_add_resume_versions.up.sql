@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS resume_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+ALTER TABLE resumes
+    ADD COLUMN document_id UUID REFERENCES resume_documents(id) ON DELETE CASCADE,
+    ADD COLUMN version INT NOT NULL DEFAULT 1,
+    ADD COLUMN is_primary BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Resumes uploaded before versioning become single version documents
+INSERT INTO resume_documents (id, user_id, title, created_at, updated_at)
+SELECT id, user_id, file_name, created_at, updated_at FROM resumes;
+
+UPDATE resumes SET document_id = id;
+
+ALTER TABLE resumes ALTER COLUMN document_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS resume_documents_user_id_idx ON resume_documents (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS resumes_document_version_idx ON resumes (document_id, version);
+CREATE UNIQUE INDEX IF NOT EXISTS resumes_primary_idx ON resumes (user_id) WHERE is_primary;
@@ -0,0 +1,5 @@
+ALTER TABLE resumes
+    DROP COLUMN IF EXISTS skill_ids;
+DROP TABLE IF EXISTS unknown_skills;
+DROP TABLE IF EXISTS skill_aliases;
+DROP TABLE IF EXISTS skills;
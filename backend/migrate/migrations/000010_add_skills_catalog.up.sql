@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS skills (
+    id BIGSERIAL PRIMARY KEY,
+    name CITEXT UNIQUE NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT 'other',
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+-- alias holds the normalised key of a spelling, e.g. "golang" for "Go lang"
+CREATE TABLE IF NOT EXISTS skill_aliases (
+    alias VARCHAR(255) PRIMARY KEY,
+    skill_id BIGINT NOT NULL REFERENCES skills(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS skill_aliases_skill_id_idx ON skill_aliases (skill_id);
+
+CREATE TABLE IF NOT EXISTS unknown_skills (
+    alias VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    occurrences INT NOT NULL DEFAULT 1,
+    first_seen_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    last_seen_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+ALTER TABLE resumes
+    ADD COLUMN skill_ids BIGINT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS job_titles;
+DROP INDEX IF EXISTS skill_aliases_alias_trgm_idx;
+ALTER TABLE skills
+    DROP COLUMN IF EXISTS popularity;
@@ -0,0 +1,29 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE skills
+    ADD COLUMN popularity INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS skill_aliases_alias_trgm_idx ON skill_aliases USING GIN (alias gin_trgm_ops);
+
+-- popularity counts how often a title was seen in parsed resumes and jobs
+CREATE TABLE IF NOT EXISTS job_titles (
+    id BIGSERIAL PRIMARY KEY,
+    name CITEXT UNIQUE NOT NULL,
+    popularity INT NOT NULL DEFAULT 0,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS job_titles_name_trgm_idx ON job_titles USING GIN (lower(name::text) gin_trgm_ops);
+
+UPDATE skills s SET popularity = counts.total
+FROM (
+    SELECT skill_id, count(*) AS total FROM resumes, unnest(skill_ids) AS skill_id GROUP BY skill_id
+) counts
+WHERE counts.skill_id = s.id;
+
+INSERT INTO job_titles (name, popularity)
+SELECT min(btrim(title)), count(*) FROM resumes, unnest(parsed_job_titles) AS title
+WHERE btrim(title) <> ''
+GROUP BY lower(btrim(title))
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,18 @@
+-- Mentors only ever read the redacted columns of a share, the original file
+-- and text stay in resumes
+CREATE TABLE IF NOT EXISTS resume_shares (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resume_id UUID NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mentor_id UUID NOT NULL REFERENCES mentor(id) ON DELETE CASCADE,
+    reveal TEXT[] NOT NULL DEFAULT '{}',
+    redactions JSONB NOT NULL DEFAULT '{}',
+    redacted_text TEXT NOT NULL DEFAULT '',
+    redacted_file BYTEA,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    revoked_at timestamp(0) WITH time zone
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS resume_shares_active_idx ON resume_shares (resume_id, mentor_id) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS resume_shares_mentor_idx ON resume_shares (mentor_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS resume_link_views;
+DROP TABLE IF EXISTS resume_links;
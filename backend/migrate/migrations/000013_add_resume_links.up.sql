@@ -0,0 +1,26 @@
+-- Like user_invitation only the sha256 of the token is stored, the token
+-- itself is shown to the owner once
+CREATE TABLE IF NOT EXISTS resume_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resume_id UUID NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    redaction VARCHAR(20) NOT NULL DEFAULT 'contact',
+    password BYTEA,
+    expires_at timestamp(0) WITH time zone,
+    revoked_at timestamp(0) WITH time zone,
+    view_count INT NOT NULL DEFAULT 0,
+    last_viewed_at timestamp(0) WITH time zone,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS resume_links_resume_idx ON resume_links (resume_id);
+
+CREATE TABLE IF NOT EXISTS resume_link_views (
+    id BIGSERIAL PRIMARY KEY,
+    link_id UUID NOT NULL REFERENCES resume_links(id) ON DELETE CASCADE,
+    referrer VARCHAR(255) NOT NULL DEFAULT 'direct',
+    viewed_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS resume_link_views_link_idx ON resume_link_views (link_id, viewed_at DESC);
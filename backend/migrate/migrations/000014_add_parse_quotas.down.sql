@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS parse_usage;
+DROP TABLE IF EXISTS parse_quotas;
@@ -0,0 +1,19 @@
+-- NULL limits mean the role is not limited for that period
+CREATE TABLE IF NOT EXISTS parse_quotas (
+    role_id BIGINT PRIMARY KEY REFERENCES role(id) ON DELETE CASCADE,
+    daily_limit INT CHECK (daily_limit >= 0),
+    monthly_limit INT CHECK (monthly_limit >= 0)
+);
+
+INSERT INTO parse_quotas (role_id, daily_limit, monthly_limit)
+SELECT id, CASE level WHEN 1 THEN 10 WHEN 2 THEN 50 END, CASE level WHEN 1 THEN 100 WHEN 2 THEN 500 END
+FROM role
+ON CONFLICT (role_id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS parse_usage (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS parse_usage_user_id_created_at_idx ON parse_usage (user_id, created_at);
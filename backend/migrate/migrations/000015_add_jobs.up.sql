@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(255) NOT NULL,
+    company VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    country CITEXT NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    remote VARCHAR(10) NOT NULL DEFAULT 'onsite' CHECK (remote IN ('onsite', 'hybrid', 'remote')),
+    min_experience INT NOT NULL DEFAULT 0 CHECK (min_experience >= 0),
+    max_experience INT CHECK (max_experience >= min_experience),
+    skills TEXT[] NOT NULL DEFAULT '{}',
+    -- skill_keys holds models.SkillKey of every skill for filtering
+    skill_keys TEXT[] NOT NULL DEFAULT '{}',
+    url TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    posted_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS jobs_posted_at_idx ON jobs (posted_at DESC);
+CREATE INDEX IF NOT EXISTS jobs_country_idx ON jobs (country);
+CREATE INDEX IF NOT EXISTS jobs_skill_keys_idx ON jobs USING GIN (skill_keys);
+CREATE INDEX IF NOT EXISTS jobs_title_trgm_idx ON jobs USING GIN (lower(title) gin_trgm_ops);
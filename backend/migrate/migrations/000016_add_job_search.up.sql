@@ -0,0 +1,23 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+-- Title matches weigh most, then company and skills, then the description
+CREATE OR REPLACE FUNCTION jobs_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.company, '')), 'B') ||
+        setweight(to_tsvector('english', array_to_string(NEW.skills, ' ')), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS jobs_search_vector_trigger ON jobs;
+CREATE TRIGGER jobs_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, company, skills, description ON jobs
+    FOR EACH ROW EXECUTE FUNCTION jobs_search_vector_update();
+
+-- Fire the trigger for existing rows
+UPDATE jobs SET title = title;
+
+CREATE INDEX IF NOT EXISTS jobs_search_vector_idx ON jobs USING GIN (search_vector);
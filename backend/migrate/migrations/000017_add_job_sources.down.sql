@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS job_import_runs;
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_source_external_id_key;
+ALTER TABLE jobs DROP COLUMN IF EXISTS external_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS source_id;
+DROP TABLE IF EXISTS job_sources;
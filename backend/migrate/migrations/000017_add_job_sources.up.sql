@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS job_sources (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('rss', 'json', 'csv')),
+    -- url is an http(s) URL, csv sources may also name a local file
+    url TEXT NOT NULL,
+    -- items_path is the dot path to the array of postings in a json feed
+    items_path TEXT NOT NULL DEFAULT '',
+    -- fields maps job attributes to json paths or csv column names
+    fields JSONB NOT NULL DEFAULT '{}',
+    -- company is used for postings that do not name one
+    company VARCHAR(255) NOT NULL DEFAULT '',
+    interval_minutes INT NOT NULL DEFAULT 60 CHECK (interval_minutes >= 5),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    last_run_at timestamp(0) WITH time zone,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS job_sources_next_run_at_idx ON job_sources (next_run_at) WHERE enabled;
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS source_id BIGINT REFERENCES job_sources(id) ON DELETE SET NULL;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS external_id TEXT;
+-- Jobs created by hand have no source and never conflict
+ALTER TABLE jobs ADD CONSTRAINT jobs_source_external_id_key UNIQUE (source_id, external_id);
+
+CREATE TABLE IF NOT EXISTS job_import_runs (
+    id BIGSERIAL PRIMARY KEY,
+    source_id BIGINT NOT NULL REFERENCES job_sources(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'succeeded', 'partial', 'failed')),
+    fetched INT NOT NULL DEFAULT 0,
+    created INT NOT NULL DEFAULT 0,
+    updated INT NOT NULL DEFAULT 0,
+    unchanged INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    errors TEXT[] NOT NULL DEFAULT '{}',
+    started_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    finished_at timestamp(0) WITH time zone
+);
+
+CREATE INDEX IF NOT EXISTS job_import_runs_source_idx ON job_import_runs (source_id, started_at DESC);
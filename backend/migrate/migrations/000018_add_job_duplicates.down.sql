@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS jobs_canonical_id_idx;
+DROP INDEX IF EXISTS jobs_company_key_idx;
+ALTER TABLE jobs DROP COLUMN IF EXISTS canonical_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS company_key;
+ALTER TABLE jobs DROP COLUMN IF EXISTS fingerprint;
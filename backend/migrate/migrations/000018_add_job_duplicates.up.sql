@@ -0,0 +1,10 @@
+-- fingerprint is the SimHash of the description, company_key the company
+-- name without case, punctuation or legal suffixes. Jobs stored before this
+-- migration get both the next time they are written.
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS fingerprint BIGINT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS company_key TEXT NOT NULL DEFAULT '';
+-- canonical_id points duplicates at the first posting of the same role
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS canonical_id UUID REFERENCES jobs(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS jobs_company_key_idx ON jobs (company_key) WHERE canonical_id IS NULL;
+CREATE INDEX IF NOT EXISTS jobs_canonical_id_idx ON jobs (canonical_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_alert_items;
+DROP TABLE IF EXISTS job_alerts;
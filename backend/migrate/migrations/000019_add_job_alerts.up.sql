@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS job_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    experience_band VARCHAR(5) NOT NULL DEFAULT '',
+    -- filter holds a models.JobFilter
+    filter JSONB NOT NULL DEFAULT '{}',
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('instant', 'daily', 'weekly')),
+    -- the token is mailed in every digest and only allows unsubscribing,
+    -- so it is stored as is
+    unsubscribe_token TEXT NOT NULL UNIQUE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    -- jobs created after last_run_at are new to the next digest
+    last_run_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    next_run_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    CONSTRAINT job_alerts_user_name_key UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS job_alerts_next_run_at_idx ON job_alerts (next_run_at) WHERE active;
+
+-- job_alert_items keeps a job from being sent twice by the same alert
+CREATE TABLE IF NOT EXISTS job_alert_items (
+    alert_id UUID NOT NULL REFERENCES job_alerts(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    sent_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (alert_id, job_id)
+);
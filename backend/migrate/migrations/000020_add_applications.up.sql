@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS applications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    -- resume_id is the resume version sent with the application
+    resume_id UUID NOT NULL REFERENCES resumes(id),
+    cover_note TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'applied'
+        CHECK (status IN ('applied', 'screening', 'interview', 'offer', 'rejected', 'withdrawn')),
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+-- a candidate may apply again once they withdrew
+CREATE UNIQUE INDEX IF NOT EXISTS applications_user_job_active_idx ON applications (user_id, job_id)
+    WHERE status <> 'withdrawn';
+CREATE INDEX IF NOT EXISTS applications_job_id_idx ON applications (job_id, created_at);
+
+CREATE TABLE IF NOT EXISTS application_events (
+    id BIGSERIAL PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    -- from_status is empty for the event creating the application
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS application_events_application_id_idx ON application_events (application_id, id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS jobs_updated_at_idx;
+DROP TABLE IF EXISTS recommendation_states;
+DROP TABLE IF EXISTS job_recommendations;
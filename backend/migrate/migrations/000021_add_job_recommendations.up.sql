@@ -0,0 +1,28 @@
+-- job_recommendations caches the jobs recommended from a user's primary
+-- resume with their scores
+CREATE TABLE IF NOT EXISTS job_recommendations (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    matched_skills TEXT[] NOT NULL DEFAULT '{}',
+    missing_skills TEXT[] NOT NULL DEFAULT '{}',
+    -- relevancy is the score of the job service, NULL when it failed
+    relevancy DOUBLE PRECISION,
+    score DOUBLE PRECISION NOT NULL,
+    -- job_updated_at is the version of the job that was scored
+    job_updated_at timestamp(0) WITH time zone NOT NULL,
+    PRIMARY KEY (user_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS job_recommendations_score_idx ON job_recommendations (user_id, score DESC);
+
+-- recommendation_states records which resume the cache was computed from
+-- and when, so it is refreshed once the resume or the jobs change
+CREATE TABLE IF NOT EXISTS recommendation_states (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    resume_id UUID NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+    resume_updated_at timestamp(0) WITH time zone NOT NULL,
+    refreshed_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    requested_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS jobs_updated_at_idx ON jobs (updated_at DESC);
@@ -0,0 +1,24 @@
+-- recommendation_feedback holds what users think of recommended jobs. The
+-- job is copied so similar jobs can be ranked by it, and feedback stays
+-- available to evaluate recommendations once the job is gone.
+CREATE TABLE IF NOT EXISTS recommendation_feedback (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id UUID REFERENCES jobs(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('up', 'down', 'not_interested')),
+    reason VARCHAR(20) NOT NULL DEFAULT '' CHECK (reason IN ('', 'location', 'seniority', 'skills', 'other')),
+    -- score is the recommendation score when the feedback was given, NULL
+    -- when the job was not recommended
+    score DOUBLE PRECISION,
+    company_key TEXT NOT NULL DEFAULT '',
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    country CITEXT NOT NULL DEFAULT '',
+    remote VARCHAR(10) NOT NULL DEFAULT '',
+    min_experience INT NOT NULL DEFAULT 0,
+    missing_skill_keys TEXT[] NOT NULL DEFAULT '{}',
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    CONSTRAINT recommendation_feedback_user_job_key UNIQUE (user_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS recommendation_feedback_created_at_idx ON recommendation_feedback (created_at);
@@ -0,0 +1,8 @@
+-- mentor_skills lists the catalog skills a mentor can help with
+CREATE TABLE IF NOT EXISTS mentor_skills (
+    mentor_id UUID NOT NULL REFERENCES mentor(id) ON DELETE CASCADE,
+    skill_id BIGINT NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    PRIMARY KEY (mentor_id, skill_id)
+);
+
+CREATE INDEX IF NOT EXISTS mentor_skills_skill_id_idx ON mentor_skills (skill_id);
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS job_collections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    CONSTRAINT job_collections_user_name_key UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    collection_id UUID NOT NULL REFERENCES job_collections(id) ON DELETE CASCADE,
+    -- job_id is cleared when the job is removed; the copy of its title,
+    -- company and url keeps the bookmark meaningful
+    job_id UUID REFERENCES jobs(id) ON DELETE SET NULL,
+    job_title VARCHAR(255) NOT NULL,
+    company VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    -- remind_at is cleared once the reminder is mailed
+    remind_at timestamp(0) WITH time zone,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    CONSTRAINT bookmarks_collection_job_key UNIQUE (collection_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS bookmarks_collection_id_idx ON bookmarks (collection_id, created_at);
+CREATE INDEX IF NOT EXISTS bookmarks_job_id_idx ON bookmarks (job_id);
+CREATE INDEX IF NOT EXISTS bookmarks_remind_at_idx ON bookmarks (remind_at) WHERE remind_at IS NOT NULL;
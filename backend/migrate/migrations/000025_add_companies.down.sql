@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS jobs_company_id_idx;
+ALTER TABLE jobs DROP COLUMN IF EXISTS company_id;
+DROP TABLE IF EXISTS company_members;
+DROP TABLE IF EXISTS companies;
+UPDATE users SET role_id = (SELECT id FROM role WHERE name = 'user')
+WHERE role_id = (SELECT id FROM role WHERE name = 'employer');
+DELETE FROM role WHERE name = 'employer';
@@ -0,0 +1,39 @@
+-- Employers hold the same rights as users; what they may manage follows
+-- from their company membership
+INSERT INTO role (name, level, description)
+SELECT 'employer', 1, 'Employer user'
+WHERE NOT EXISTS (SELECT 1 FROM role WHERE name = 'employer');
+
+INSERT INTO parse_quotas (role_id, daily_limit, monthly_limit)
+SELECT id, 10, 100 FROM role WHERE name = 'employer'
+ON CONFLICT (role_id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS companies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    website TEXT NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    -- verified_at is set by an admin; only verified companies post jobs
+    verified_at timestamp(0) WITH time zone,
+    verified_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    CONSTRAINT companies_slug_key UNIQUE (slug)
+);
+
+-- A user belongs to at most one company
+CREATE TABLE IF NOT EXISTS company_members (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'recruiter')),
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (company_id, user_id),
+    CONSTRAINT company_members_user_id_key UNIQUE (user_id)
+);
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS company_id UUID REFERENCES companies(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS jobs_company_id_idx ON jobs (company_id, posted_at);
+CREATE INDEX IF NOT EXISTS companies_verified_at_idx ON companies (verified_at);
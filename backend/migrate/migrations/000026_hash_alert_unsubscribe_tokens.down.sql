@@ -0,0 +1,7 @@
+-- The mailed tokens cannot be recovered from their hashes, so the alerts get
+-- new ones and the links of earlier digests stop working
+ALTER TABLE job_alerts ADD COLUMN IF NOT EXISTS unsubscribe_token TEXT;
+UPDATE job_alerts SET unsubscribe_token = md5(random()::text || id::text) WHERE unsubscribe_token IS NULL;
+ALTER TABLE job_alerts ALTER COLUMN unsubscribe_token SET NOT NULL;
+ALTER TABLE job_alerts ADD CONSTRAINT job_alerts_unsubscribe_token_key UNIQUE (unsubscribe_token);
+DROP TABLE IF EXISTS job_alert_unsubscribe_tokens;
@@ -0,0 +1,15 @@
+-- Each digest is mailed with a token of its own, and like resume links only
+-- the sha256 of the token is stored. Tokens already mailed keep working.
+CREATE TABLE IF NOT EXISTS job_alert_unsubscribe_tokens (
+    token_hash TEXT PRIMARY KEY,
+    alert_id UUID NOT NULL REFERENCES job_alerts(id) ON DELETE CASCADE,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS job_alert_unsubscribe_tokens_alert_id_idx ON job_alert_unsubscribe_tokens (alert_id);
+
+INSERT INTO job_alert_unsubscribe_tokens (token_hash, alert_id)
+SELECT encode(sha256(convert_to(unsubscribe_token, 'UTF8')), 'hex'), id FROM job_alerts
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE job_alerts DROP COLUMN IF EXISTS unsubscribe_token;
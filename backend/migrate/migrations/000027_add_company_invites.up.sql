@@ -0,0 +1,16 @@
+-- Owners invite people by email; they join the company when they accept
+-- with an account holding that email. Only the sha256 of the token mailed
+-- to them is stored.
+CREATE TABLE IF NOT EXISTS company_invites (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    email CITEXT NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'recruiter')),
+    token_hash TEXT NOT NULL,
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at timestamp(0) WITH time zone NOT NULL,
+    created_at timestamp(0) WITH time zone NOT NULL DEFAULT now(),
+    CONSTRAINT company_invites_token_hash_key UNIQUE (token_hash),
+    -- inviting an email again replaces its pending invite
+    CONSTRAINT company_invites_company_email_key UNIQUE (company_id, email)
+);
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How often an alert mails its digest. Instant alerts are checked every
+// time the scheduler runs.
+const (
+	AlertInstant = "instant"
+	AlertDaily   = "daily"
+	AlertWeekly  = "weekly"
+)
+
+// JobAlert is a saved search mailed to its owner as a digest of the jobs
+// posted since the previous one.
+type JobAlert struct {
+	ID             uuid.UUID `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Name           string    `json:"name"`
+	Query          string    `json:"q"`
+	ExperienceBand string    `json:"experience_band"`
+	Filter         JobFilter `json:"filter"`
+	Frequency      string    `json:"frequency"`
+	Active         bool      `json:"active"`
+	LastRunAt      time.Time `json:"last_run_at"`
+	NextRunAt      time.Time `json:"next_run_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// JobAlertPayload creates or replaces an alert. Filter.Skills is read like
+// the skills query parameter when RequiredSkills is empty.
+type JobAlertPayload struct {
+	Name           string    `json:"name" validate:"required,max=100"`
+	Query          string    `json:"q" validate:"max=200"`
+	ExperienceBand string    `json:"experience_band" validate:"omitempty,oneof=0-1 2-4 5-7 8+"`
+	Filter         JobFilter `json:"filter"`
+	Frequency      string    `json:"frequency" validate:"required,oneof=instant daily weekly"`
+	Active         *bool     `json:"active"`
+}
+
+// SearchQuery returns the search the alert runs.
+func (a *JobAlert) SearchQuery() *JobSearchQuery {
+	return &JobSearchQuery{Query: a.Query, Band: a.ExperienceBand, Filter: a.Filter}
+}
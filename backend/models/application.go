@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a job application.
+const (
+	ApplicationApplied   = "applied"
+	ApplicationScreening = "screening"
+	ApplicationInterview = "interview"
+	ApplicationOffer     = "offer"
+	ApplicationRejected  = "rejected"
+	ApplicationWithdrawn = "withdrawn"
+)
+
+// applicationTransitions lists the statuses each status may move to. An
+// application moves forward one step at a time and can be rejected or
+// withdrawn until it is closed.
+var applicationTransitions = map[string][]string{
+	ApplicationApplied:   {ApplicationScreening, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationScreening: {ApplicationInterview, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationInterview: {ApplicationOffer, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationOffer:     {ApplicationRejected, ApplicationWithdrawn},
+}
+
+// CanTransition reports whether an application may move from one status to
+// the other.
+func CanTransition(from string, to string) bool {
+	for _, status := range applicationTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Application is a candidate's application to a job with one version of
+// their resume. JobTitle and Company are read from the job, History only
+// when a single application is fetched.
+type Application struct {
+	ID            uuid.UUID          `json:"id"`
+	UserID        uuid.UUID          `json:"user_id"`
+	JobID         uuid.UUID          `json:"job_id"`
+	JobTitle      string             `json:"job_title"`
+	Company       string             `json:"company"`
+	ResumeID      uuid.UUID          `json:"resume_id"`
+	ResumeVersion int                `json:"resume_version"`
+	CoverNote     string             `json:"cover_note"`
+	Status        string             `json:"status"`
+	History       []ApplicationEvent `json:"history,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// ApplicationEvent is a status change of an application. FromStatus is empty
+// for the event creating it.
+type ApplicationEvent struct {
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	ChangedBy  *uuid.UUID `json:"changed_by"`
+	Note       string     `json:"note"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ApplicationPayload struct {
+	JobID     uuid.UUID `json:"job_id" validate:"required"`
+	ResumeID  uuid.UUID `json:"resume_id" validate:"required"`
+	CoverNote string    `json:"cover_note" validate:"max=5000"`
+}
+
+// ApplicationStatusPayload moves an application along the pipeline. Only the
+// candidate withdraws, so withdrawn is not accepted here.
+type ApplicationStatusPayload struct {
+	Status string `json:"status" validate:"required,oneof=screening interview offer rejected"`
+	Note   string `json:"note" validate:"max=1000"`
+}
+
+type ApplicationWithdrawPayload struct {
+	Note string `json:"note" validate:"max=1000"`
+}
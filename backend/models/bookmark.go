@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// States of a bookmarked job. Expired jobs were posted too long ago to be
+// open still; removed jobs no longer exist and only their copy is left.
+const (
+	BookmarkActive  = "active"
+	BookmarkExpired = "expired"
+	BookmarkRemoved = "removed"
+)
+
+// JobCollection is a named group of bookmarked jobs of a user.
+type JobCollection struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Bookmarks   int       `json:"bookmarks"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type JobCollectionPayload struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+}
+
+// Bookmark is a job saved to a collection with a private note and an
+// optional reminder. JobTitle, Company and URL are copied from the job when
+// it is bookmarked; Job is nil once the job is removed.
+type Bookmark struct {
+	ID           uuid.UUID  `json:"id"`
+	CollectionID uuid.UUID  `json:"collection_id"`
+	UserID       uuid.UUID  `json:"-"`
+	JobID        *uuid.UUID `json:"job_id"`
+	Status       string     `json:"status"`
+	JobTitle     string     `json:"job_title"`
+	Company      string     `json:"company"`
+	URL          string     `json:"url"`
+	Job          *Job       `json:"job"`
+	Note         string     `json:"note"`
+	RemindAt     *time.Time `json:"remind_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type BookmarkPayload struct {
+	JobID    uuid.UUID  `json:"job_id" validate:"required"`
+	Note     string     `json:"note" validate:"max=5000"`
+	RemindAt *time.Time `json:"remind_at"`
+}
+
+// BookmarkUpdatePayload replaces the note and reminder of a bookmark. A nil
+// RemindAt cancels the reminder.
+type BookmarkUpdatePayload struct {
+	Note     string     `json:"note" validate:"max=5000"`
+	RemindAt *time.Time `json:"remind_at"`
+}
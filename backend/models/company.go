@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Roles of a member in their company. Owners manage the profile and the
+// members, recruiters only the jobs.
+const (
+	CompanyOwner     = "owner"
+	CompanyRecruiter = "recruiter"
+)
+
+// Company is the employer behind job postings. MemberRole is the role of
+// the user reading it, empty on public profiles.
+type Company struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Website     string     `json:"website"`
+	Location    string     `json:"location"`
+	Verified    bool       `json:"verified"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	Jobs        int        `json:"jobs"`
+	MemberRole  string     `json:"member_role,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type CompanyPayload struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"max=5000"`
+	Website     string `json:"website" validate:"omitempty,url,max=2000"`
+	Location    string `json:"location" validate:"max=255"`
+}
+
+type CompanyMember struct {
+	CompanyID uuid.UUID `json:"company_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CompanyInvite asks the person with the email to join the company. They
+// become a member once they accept it with an account holding the email.
+type CompanyInvite struct {
+	ID        uuid.UUID  `json:"id"`
+	CompanyID uuid.UUID  `json:"company_id"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	InvitedBy *uuid.UUID `json:"invited_by,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CompanyInvitePayload invites the person with the email to the company.
+type CompanyInvitePayload struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"required,oneof=owner recruiter"`
+}
+
+type CompanyVerificationPayload struct {
+	Verified *bool `json:"verified" validate:"required"`
+}
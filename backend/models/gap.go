@@ -0,0 +1,39 @@
+package models
+
+import "github.com/google/uuid"
+
+// AdjacentSkill is a skill a job asks for that is missing from a resume,
+// which lists skills of the same category of the catalog instead.
+type AdjacentSkill struct {
+	Skill    string   `json:"skill"`
+	Category string   `json:"category"`
+	Related  []string `json:"related"`
+}
+
+// ExperienceGap compares the years of experience of a resume with those a
+// job asks for. Shortfall is how many years are missing.
+type ExperienceGap struct {
+	Required  int  `json:"required"`
+	Maximum   *int `json:"maximum"`
+	Resume    int  `json:"resume"`
+	Shortfall int  `json:"shortfall"`
+}
+
+// SkillMentors are mentors who can help with a skill.
+type SkillMentors struct {
+	Skill   string          `json:"skill"`
+	Mentors []MentorProfile `json:"mentors"`
+}
+
+// SkillGap compares the skills a job asks for with those on a resume.
+// Present are on the resume, Adjacent are related to skills on it and
+// Missing are neither.
+type SkillGap struct {
+	JobID      uuid.UUID       `json:"job_id"`
+	ResumeID   uuid.UUID       `json:"resume_id"`
+	Present    []string        `json:"present"`
+	Adjacent   []AdjacentSkill `json:"adjacent"`
+	Missing    []string        `json:"missing"`
+	Experience ExperienceGap   `json:"experience"`
+	Mentors    []SkillMentors  `json:"mentors"`
+}
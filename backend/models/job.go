@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Work arrangements of a job.
+const (
+	RemoteOnsite = "onsite"
+	RemoteHybrid = "hybrid"
+	RemoteFull   = "remote"
+)
+
+type Job struct {
+	ID            uuid.UUID  `json:"id"`
+	Title         string     `json:"title"`
+	Company       string     `json:"company"`
+	Description   string     `json:"description"`
+	Country       string     `json:"country"`
+	City          string     `json:"city"`
+	Remote        string     `json:"remote"`
+	MinExperience int        `json:"min_experience"`
+	MaxExperience *int       `json:"max_experience"`
+	Skills        []string   `json:"skills"`
+	URL           string     `json:"url"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty"`
+	SourceID      *int64     `json:"source_id,omitempty"`
+	CanonicalID   *uuid.UUID `json:"canonical_id,omitempty"`
+	CompanyID     *uuid.UUID `json:"company_id,omitempty"`
+	PostedAt      time.Time  `json:"posted_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	// Fingerprint is only set on jobs being written
+	Fingerprint JobFingerprint `json:"-"`
+}
+
+// JobFingerprint identifies near duplicate postings of a role: the SimHash
+// of the description, zero when there is none, and the company key.
+type JobFingerprint struct {
+	SimHash    uint64
+	CompanyKey string
+}
+
+// DuplicatePosting is another posting of a job found by search.
+type DuplicatePosting struct {
+	JobID   uuid.UUID `json:"job_id"`
+	Source  string    `json:"source"`
+	Company string    `json:"company"`
+	URL     string    `json:"url"`
+}
+
+// JobPayload creates or replaces a job. Skills are rewritten to their
+// catalog names.
+type JobPayload struct {
+	Title         string   `json:"title" validate:"required,max=255"`
+	Company       string   `json:"company" validate:"required,max=255"`
+	Description   string   `json:"description" validate:"required,max=20000"`
+	Country       string   `json:"country" validate:"max=100"`
+	City          string   `json:"city" validate:"max=100"`
+	Remote        string   `json:"remote" validate:"omitempty,oneof=onsite hybrid remote"`
+	MinExperience int      `json:"min_experience" validate:"min=0,max=60"`
+	MaxExperience *int     `json:"max_experience" validate:"omitempty,min=0,max=60,gtefield=MinExperience"`
+	Skills        []string `json:"skills" validate:"max=50,dive,required,max=100"`
+	URL           string   `json:"url" validate:"omitempty,url,max=2000"`
+}
+
+// Experience bands used by job search facets, by the years a job asks for.
+var ExperienceBands = []string{"0-1", "2-4", "5-7", "8+"}
+
+// JobSearchQuery is a full-text query over job titles, companies, skills and
+// descriptions, narrowed by the same filters as a job listing.
+type JobSearchQuery struct {
+	Query  string `json:"q" validate:"max=200"`
+	Band   string `json:"experience_band" validate:"omitempty,oneof=0-1 2-4 5-7 8+"`
+	Filter JobFilter
+}
+
+// JobHit is a job found by search. Headline holds description fragments
+// around the matched words as safe HTML: the text is escaped and only the
+// matched words are wrapped in <b> tags. Duplicates of the job are not hits
+// of their own but listed in AlsoPostedAt.
+type JobHit struct {
+	Job
+	Score        float64            `json:"score"`
+	Headline     string             `json:"headline,omitempty"`
+	AlsoPostedAt []DuplicatePosting `json:"also_posted_at"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// JobFacets counts the jobs matching a search by attribute, so clients can
+// offer them as further filters.
+type JobFacets struct {
+	Country    []FacetCount `json:"country"`
+	Remote     []FacetCount `json:"remote"`
+	Experience []FacetCount `json:"experience"`
+	Skills     []FacetCount `json:"skills"`
+}
+
+type JobSearchResult struct {
+	Hits   []JobHit  `json:"hits"`
+	Total  int       `json:"total"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+	Facets JobFacets `json:"facets"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of job feed a source can read.
+const (
+	JobSourceRSS  = "rss"
+	JobSourceJSON = "json"
+	JobSourceCSV  = "csv"
+)
+
+// Statuses of an import run. A partial run stored some postings and
+// rejected others, a failed run could not read the feed at all.
+const (
+	ImportRunning   = "running"
+	ImportSucceeded = "succeeded"
+	ImportPartial   = "partial"
+	ImportFailed    = "failed"
+)
+
+// JobSource is a feed the importer reads jobs from. Fields maps job
+// attributes such as "title" or "skills" to the dot path of the value in a
+// JSON posting, or to the column header of a CSV file. RSS and Atom feeds
+// have a fixed layout and ignore it.
+type JobSource struct {
+	ID              int64             `json:"id"`
+	Name            string            `json:"name"`
+	Kind            string            `json:"kind"`
+	URL             string            `json:"url"`
+	ItemsPath       string            `json:"items_path"`
+	Fields          map[string]string `json:"fields"`
+	Company         string            `json:"company"`
+	IntervalMinutes int               `json:"interval_minutes"`
+	Enabled         bool              `json:"enabled"`
+	NextRunAt       time.Time         `json:"next_run_at"`
+	LastRunAt       *time.Time        `json:"last_run_at"`
+	CreatedBy       *uuid.UUID        `json:"created_by,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// JobSourcePayload creates or replaces a source. URL may be the path of a
+// file inside the import directory for CSV sources.
+type JobSourcePayload struct {
+	Name            string            `json:"name" validate:"required,max=100"`
+	Kind            string            `json:"kind" validate:"required,oneof=rss json csv"`
+	URL             string            `json:"url" validate:"required,max=2000"`
+	ItemsPath       string            `json:"items_path" validate:"max=200"`
+	Fields          map[string]string `json:"fields" validate:"max=20,dive,keys,required,max=50,endkeys,required,max=200"`
+	Company         string            `json:"company" validate:"max=255"`
+	IntervalMinutes int               `json:"interval_minutes" validate:"omitempty,min=5,max=10080"`
+	Enabled         *bool             `json:"enabled"`
+}
+
+// JobImportRun records one read of a source: how many postings it held and
+// what became of them. Errors holds the first problems met, by posting.
+type JobImportRun struct {
+	ID         int64      `json:"id"`
+	SourceID   int64      `json:"source_id"`
+	Status     string     `json:"status"`
+	Fetched    int        `json:"fetched"`
+	Created    int        `json:"created"`
+	Updated    int        `json:"updated"`
+	Unchanged  int        `json:"unchanged"`
+	Failed     int        `json:"failed"`
+	Errors     []string   `json:"errors"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// Outcomes of storing an imported job.
+const (
+	UpsertCreated   = "created"
+	UpsertUpdated   = "updated"
+	UpsertUnchanged = "unchanged"
+)
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Redaction levels of a public link. "profile" shows only the structured
+// profile, "contact" the text without contact details and "none" everything.
+const (
+	RedactionNone    = "none"
+	RedactionContact = "contact"
+	RedactionProfile = "profile"
+)
+
+// ResumeLink is a public link to a resume version. Token and URL are only
+// set when the link is created.
+type ResumeLink struct {
+	ID           uuid.UUID    `json:"id"`
+	ResumeID     uuid.UUID    `json:"resume_id"`
+	UserID       uuid.UUID    `json:"user_id"`
+	Token        string       `json:"token,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	Redaction    string       `json:"redaction"`
+	Password     PasswordType `json:"-"`
+	HasPassword  bool         `json:"has_password"`
+	ExpiresAt    *time.Time   `json:"expires_at"`
+	RevokedAt    *time.Time   `json:"revoked_at,omitempty"`
+	ViewCount    int          `json:"view_count"`
+	LastViewedAt *time.Time   `json:"last_viewed_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type ResumeLinkPayload struct {
+	ExpiresInHours int    `json:"expires_in_hours" validate:"omitempty,min=1,max=8760"`
+	Password       string `json:"password" validate:"omitempty,min=6,max=72"`
+	Redaction      string `json:"redaction" validate:"omitempty,oneof=none contact profile"`
+}
+
+// PublicResume is what anybody holding a link sees.
+type PublicResume struct {
+	Name      string        `json:"name"`
+	Version   int           `json:"version"`
+	Redaction string        `json:"redaction"`
+	Profile   ResumeProfile `json:"profile"`
+	Text      string        `json:"text,omitempty"`
+	ExpiresAt *time.Time    `json:"expires_at"`
+}
+
+// LinkedResume is a link with the resume and owner data needed to render
+// it. It is never sent as is.
+type LinkedResume struct {
+	Link          ResumeLink
+	Owner         User
+	Version       int
+	FileName      string
+	Profile       ResumeProfile
+	ExtractedText string
+}
+
+type ResumeLinkView struct {
+	Referrer string    `json:"referrer"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+type ResumeLinkStats struct {
+	LinkID    uuid.UUID        `json:"link_id"`
+	ViewCount int              `json:"view_count"`
+	Referrers map[string]int   `json:"referrers"`
+	Recent    []ResumeLinkView `json:"recent"`
+}
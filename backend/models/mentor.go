@@ -24,3 +24,19 @@ type Mentor struct {
 	CreatedAt       time.Time    `json:"created_at"`
 	UpdatedAt       time.Time    `json:"updated_at"`
 }
+
+// MentorProfile is what candidates see of a mentor.
+type MentorProfile struct {
+	ID              uuid.UUID `json:"id"`
+	Username        string    `json:"username"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	ExperienceYears float32   `json:"experience_years"`
+	Bio             string    `json:"bio"`
+}
+
+// MentorSkillsPayload replaces the skills a mentor can help with. Skills
+// missing from the catalog are dropped.
+type MentorSkillsPayload struct {
+	Skills []string `json:"skills" validate:"max=50,dive,required,max=100"`
+}
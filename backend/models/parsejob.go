@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Events sent for a parse job. A job ends with exactly one of
+// ParseEventDone, whose data is the stored Resume, or ParseEventError.
+const (
+	ParseEventProgress = "progress"
+	ParseEventDone     = "done"
+	ParseEventError    = "error"
+)
+
+// ParseJob is a resume upload being parsed in the background.
+type ParseJob struct {
+	ID        uuid.UUID `json:"id"`
+	EventsURL string    `json:"events_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParseEvent is one Server-Sent Event of a parse job. IDs start at 1 and
+// increase by one, so clients resume with the last id they saw.
+type ParseEvent struct {
+	ID    int    `json:"id"`
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
@@ -3,11 +3,19 @@ package models
 import (
 	"net/http"
 	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
+// PaginatedQuery selects a page of a list. Cursor is the next_cursor of the
+// previous page and takes precedence over Offset. Next is set by the
+// repository to the cursor of the following page, empty on the last page.
 type PaginatedQuery struct {
-	Limit  int `json:"limit,omitempty" validate:"min=1,max=100"`
-	Offset int `json:"offset,omitempty" validate:"min=0"`
+	Limit  int    `json:"limit,omitempty" validate:"min=1,max=100"`
+	Offset int    `json:"offset,omitempty" validate:"min=0"`
+	Cursor string `json:"cursor,omitempty" validate:"max=1024"`
+	Next   string `json:"-"`
 }
 
 func (pq *PaginatedQuery) Parse(r *http.Request) error {
@@ -28,6 +36,7 @@ func (pq *PaginatedQuery) Parse(r *http.Request) error {
 		}
 		pq.Offset = o
 	}
+	pq.Cursor = q.Get("cursor")
 	return nil
 }
 
@@ -40,12 +49,38 @@ func (pq *PaginatedQuery) SetDefaults() {
 	}
 }
 
+// JobFilter narrows a job listing. Experience is the candidate's years of
+// experience, matched against the range a job asks for. Skills is the comma
+// separated form of RequiredSkills, as sent in the query string.
 type JobFilter struct {
-	Position       string   `json:"position,omitempty"`
-	Country        string   `json:"country,omitempty"`
-	Remote         string   `json:"remote,omitempty"`
-	Experience     string   `json:"experience,omitempty"`
-	Skills         string   `json:"skills,omitempty"`
-	RequiredSkills []string `json:"required_skills,omitempty"`
-	Paginatin      *PaginatedQuery
+	Position       string          `json:"position,omitempty" validate:"max=255"`
+	Country        string          `json:"country,omitempty" validate:"max=100"`
+	Remote         string          `json:"remote,omitempty" validate:"omitempty,oneof=onsite hybrid remote"`
+	Experience     string          `json:"experience,omitempty" validate:"omitempty,number,max=2"`
+	Skills         string          `json:"skills,omitempty"`
+	RequiredSkills []string        `json:"required_skills,omitempty" validate:"max=20,dive,max=100"`
+	Paginatin      *PaginatedQuery `json:"-"`
+	// CompanyID only lists the jobs of one company, for its profile
+	CompanyID *uuid.UUID `json:"-"`
+}
+
+func (jf *JobFilter) Parse(r *http.Request) error {
+	q := r.URL.Query()
+	jf.Position = strings.TrimSpace(q.Get("position"))
+	jf.Country = strings.TrimSpace(q.Get("country"))
+	jf.Remote = strings.ToLower(strings.TrimSpace(q.Get("remote")))
+	jf.Experience = strings.TrimSpace(q.Get("experience"))
+	jf.Skills = q.Get("skills")
+	jf.RequiredSkills = nil
+	for _, skill := range strings.Split(jf.Skills, ",") {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			jf.RequiredSkills = append(jf.RequiredSkills, skill)
+		}
+	}
+	jf.Paginatin = &PaginatedQuery{}
+	if err := jf.Paginatin.Parse(r); err != nil {
+		return err
+	}
+	jf.Paginatin.SetDefaults()
+	return nil
 }
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// QuotaWindow is the parse usage of a user in one period. Limit and
+// Remaining are nil when the period is unlimited for the user's role.
+type QuotaWindow struct {
+	Limit     *int      `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining *int      `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// Exceeded reports whether another parse would go over the limit.
+func (q QuotaWindow) Exceeded() bool {
+	return q.Limit != nil && q.Used >= *q.Limit
+}
+
+func (q *QuotaWindow) setRemaining() {
+	if q.Limit == nil {
+		q.Remaining = nil
+		return
+	}
+	remaining := max(*q.Limit-q.Used, 0)
+	q.Remaining = &remaining
+}
+
+// ParseQuota is the resume parsing quota of a user. Periods are calendar days
+// and months in UTC.
+type ParseQuota struct {
+	Role    string      `json:"role"`
+	Daily   QuotaWindow `json:"daily"`
+	Monthly QuotaWindow `json:"monthly"`
+}
+
+// SetRemaining fills Remaining from Limit and Used for both periods.
+func (q *ParseQuota) SetRemaining() {
+	q.Daily.setRemaining()
+	q.Monthly.setRemaining()
+}
+
+// RetryAfter is when the exceeded period resets, the later one if both are.
+func (q *ParseQuota) RetryAfter() time.Time {
+	if q.Monthly.Exceeded() {
+		return q.Monthly.ResetsAt
+	}
+	return q.Daily.ResetsAt
+}
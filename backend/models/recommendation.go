@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRecommendation is a job recommended from a resume. MatchedSkills are
+// the job's skills found on the resume and MissingSkills the others, which
+// explain the match. Relevancy is nil when the job service could not score
+// the job. Feedback is the kind of feedback the user gave on it, if any.
+type JobRecommendation struct {
+	Job           Job       `json:"job"`
+	Score         float64   `json:"score"`
+	Relevancy     *float64  `json:"relevancy"`
+	MatchedSkills []string  `json:"matched_skills"`
+	MissingSkills []string  `json:"missing_skills"`
+	Feedback      string    `json:"feedback,omitempty"`
+	JobUpdatedAt  time.Time `json:"-"`
+}
+
+// RecommendationState tells which resume version the cached recommendations
+// of a user were computed from, and when.
+type RecommendationState struct {
+	UserID          uuid.UUID
+	ResumeID        uuid.UUID
+	ResumeUpdatedAt time.Time
+	RefreshedAt     time.Time
+}
+
+// RecommendationPage is a page of recommendations with the resume they
+// were computed from.
+type RecommendationPage struct {
+	ResumeID        uuid.UUID           `json:"resume_id"`
+	RefreshedAt     time.Time           `json:"refreshed_at"`
+	Recommendations []JobRecommendation `json:"recommendations"`
+}
+
+// Kinds of feedback on a recommended job. Jobs marked not interested are no
+// longer recommended.
+const (
+	FeedbackUp            = "up"
+	FeedbackDown          = "down"
+	FeedbackNotInterested = "not_interested"
+)
+
+// RecommendationFeedback is a user's opinion of a recommended job, with a
+// copy of the parts of the job that similar jobs are ranked by.
+type RecommendationFeedback struct {
+	ID            int64     `json:"id"`
+	UserID        uuid.UUID `json:"user_id"`
+	JobID         uuid.UUID `json:"job_id"`
+	Kind          string    `json:"kind"`
+	Reason        string    `json:"reason"`
+	Score         *float64  `json:"score"`
+	CompanyKey    string    `json:"-"`
+	Title         string    `json:"-"`
+	Country       string    `json:"-"`
+	Remote        string    `json:"-"`
+	MinExperience int       `json:"-"`
+	MissingSkills []string  `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type RecommendationFeedbackPayload struct {
+	Kind   string `json:"kind" validate:"required,oneof=up down not_interested"`
+	Reason string `json:"reason" validate:"omitempty,oneof=location seniority skills other"`
+}
+
+// FeedbackBand counts the feedback given on jobs recommended with a score
+// in [Min, Max).
+type FeedbackBand struct {
+	Min    float64        `json:"min"`
+	Max    float64        `json:"max"`
+	Counts map[string]int `json:"counts"`
+}
+
+// RecommendationFeedbackStats sums up the feedback given since Since to
+// evaluate recommendations. Good recommendations get more thumbs up in the
+// higher score bands.
+type RecommendationFeedbackStats struct {
+	Since        time.Time                 `json:"since"`
+	Total        int                       `json:"total"`
+	ByKind       map[string]int            `json:"by_kind"`
+	ByReason     map[string]map[string]int `json:"by_reason"`
+	AverageScore map[string]float64        `json:"average_score"`
+	Bands        []FeedbackBand            `json:"bands"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type RelevancyJob struct {
+	ID          string `json:"id" validate:"required,max=100"`
+	Description string `json:"description" validate:"required,max=20000"`
+}
+
+type RelevancyResult struct {
+	JobID string  `json:"job_id"`
+	Rank  int     `json:"rank,omitempty"`
+	Score float64 `json:"score"`
+	Error string  `json:"error,omitempty"`
+}
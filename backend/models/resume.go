@@ -0,0 +1,167 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ResumeProfile struct {
+	JobTitles  []string `json:"job_titles"`
+	Skills     []string `json:"skills"`
+	Experience int32    `json:"experience"`
+}
+
+type Resume struct {
+	ID            uuid.UUID     `json:"id"`
+	UserID        uuid.UUID     `json:"user_id"`
+	DocumentID    uuid.UUID     `json:"document_id"`
+	Version       int           `json:"version"`
+	IsPrimary     bool          `json:"is_primary"`
+	FileName      string        `json:"file_name"`
+	ContentType   string        `json:"content_type"`
+	FileContent   []byte        `json:"-"`
+	ExtractedText string        `json:"-"`
+	Degraded      bool          `json:"degraded"`
+	Parsed        ResumeProfile `json:"parsed"`
+	Profile       ResumeProfile `json:"profile"`
+	SkillIDs      []int64       `json:"skill_ids"`
+	EditedAt      *time.Time    `json:"edited_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// ResumeUpload is a resume file sent by a user. Without a DocumentID the
+// upload starts a new document named Title. Text is set when the text of the
+// file is already known, otherwise it is extracted from Content.
+type ResumeUpload struct {
+	UserID      uuid.UUID
+	DocumentID  uuid.UUID
+	Title       string
+	FileName    string
+	ContentType string
+	Content     []byte
+	Text        string
+}
+
+// ATSTarget describes the job a resume is checked against.
+type ATSTarget struct {
+	Keywords       []string `validate:"max=50,dive,required,max=100"`
+	JobDescription string   `validate:"max=5000"`
+}
+
+// ExportFile is a generated document ready to be downloaded.
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ResumeDocument groups the uploaded versions of the same resume.
+type ResumeDocument struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Title     string    `json:"title"`
+	Versions  []Resume  `json:"versions"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ResumeDiff struct {
+	DocumentID      uuid.UUID `json:"document_id"`
+	FromVersion     int       `json:"from_version"`
+	ToVersion       int       `json:"to_version"`
+	SkillsAdded     []string  `json:"skills_added"`
+	SkillsRemoved   []string  `json:"skills_removed"`
+	TitlesAdded     []string  `json:"titles_added"`
+	TitlesRemoved   []string  `json:"titles_removed"`
+	ExperienceFrom  int32     `json:"experience_from"`
+	ExperienceTo    int32     `json:"experience_to"`
+	ExperienceDelta int32     `json:"experience_delta"`
+}
+
+// DiffResumes compares the profiles of two versions of a document.
+func DiffResumes(from *Resume, to *Resume) ResumeDiff {
+	return ResumeDiff{
+		DocumentID:      to.DocumentID,
+		FromVersion:     from.Version,
+		ToVersion:       to.Version,
+		SkillsAdded:     subtractFold(to.Profile.Skills, from.Profile.Skills),
+		SkillsRemoved:   subtractFold(from.Profile.Skills, to.Profile.Skills),
+		TitlesAdded:     subtractFold(to.Profile.JobTitles, from.Profile.JobTitles),
+		TitlesRemoved:   subtractFold(from.Profile.JobTitles, to.Profile.JobTitles),
+		ExperienceFrom:  from.Profile.Experience,
+		ExperienceTo:    to.Profile.Experience,
+		ExperienceDelta: to.Profile.Experience - from.Profile.Experience,
+	}
+}
+
+// ResumeProfilePatch edits a resume profile. Skills replaces the whole list
+// in the given order, AddSkills and RemoveSkills edit the current list.
+// Fields left out are not changed.
+type ResumeProfilePatch struct {
+	Skills       []string `json:"skills,omitempty" validate:"omitempty,max=100,dive,required,max=100"`
+	AddSkills    []string `json:"add_skills,omitempty" validate:"omitempty,max=100,dive,required,max=100"`
+	RemoveSkills []string `json:"remove_skills,omitempty" validate:"omitempty,max=100,dive,required,max=100"`
+	JobTitles    []string `json:"job_titles,omitempty" validate:"omitempty,max=50,dive,required,max=150"`
+	Experience   *int32   `json:"experience,omitempty" validate:"omitempty,min=0,max=70"`
+}
+
+func (p ResumeProfilePatch) Apply(profile *ResumeProfile) {
+	if p.Skills != nil {
+		profile.Skills = uniqueFold(p.Skills)
+	}
+	if len(p.RemoveSkills) > 0 {
+		kept := make([]string, 0, len(profile.Skills))
+		for _, skill := range profile.Skills {
+			if !containsFold(p.RemoveSkills, skill) {
+				kept = append(kept, skill)
+			}
+		}
+		profile.Skills = kept
+	}
+	if len(p.AddSkills) > 0 {
+		profile.Skills = uniqueFold(append(profile.Skills, p.AddSkills...))
+	}
+	if p.JobTitles != nil {
+		profile.JobTitles = uniqueFold(p.JobTitles)
+	}
+	if p.Experience != nil {
+		profile.Experience = *p.Experience
+	}
+}
+
+// uniqueFold trims the values and drops case-insensitive duplicates, keeping
+// the first occurrence so the order chosen by the user is preserved.
+func uniqueFold(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || containsFold(out, v) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func containsFold(values []string, v string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), strings.TrimSpace(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// subtractFold returns the values of a that are not in b, ignoring case.
+func subtractFold(a []string, b []string) []string {
+	out := []string{}
+	for _, v := range a {
+		if !containsFold(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
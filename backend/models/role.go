@@ -6,3 +6,17 @@ type Role struct {
 	Level       int    `json:"level"`
 	Description string `json:"description"`
 }
+
+// Role levels as seeded in the role table.
+const (
+	RoleLevelUser      = 1
+	RoleLevelModerator = 2
+	RoleLevelAdmin     = 3
+)
+
+// Role names of accounts moved between the user and employer roles as they
+// join and leave companies.
+const (
+	RoleNameUser     = "user"
+	RoleNameEmployer = "employer"
+)
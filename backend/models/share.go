@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResumeShare gives a mentor access to a redacted copy of a resume. Reveal
+// lists the kinds of personal data the candidate chose to show.
+type ResumeShare struct {
+	ID             uuid.UUID      `json:"id"`
+	ResumeID       uuid.UUID      `json:"resume_id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	MentorID       uuid.UUID      `json:"mentor_id"`
+	MentorUsername string         `json:"mentor_username,omitempty"`
+	Reveal         []string       `json:"reveal"`
+	Redactions     map[string]int `json:"redactions"`
+	HasFile        bool           `json:"has_file"`
+	RedactedText   string         `json:"-"`
+	RedactedFile   []byte         `json:"-"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	RevokedAt      *time.Time     `json:"revoked_at,omitempty"`
+}
+
+// SharedResume is what a mentor sees of a shared resume.
+type SharedResume struct {
+	Share    ResumeShare   `json:"share"`
+	FileName string        `json:"file_name"`
+	Profile  ResumeProfile `json:"profile"`
+	Text     string        `json:"text,omitempty"`
+}
+
+type ResumeSharePayload struct {
+	Mentor string   `json:"mentor" validate:"required,max=255"`
+	Reveal []string `json:"reveal" validate:"omitempty,max=4,unique,dive,oneof=email phone address link"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+type Skill struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Category   string    `json:"category"`
+	Aliases    []string  `json:"aliases"`
+	Popularity int       `json:"popularity"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SkillSuggestion is a catalog entry matching a typeahead query. Alias is
+// the spelling that matched and Exact is set when it matched fully.
+type SkillSuggestion struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Category   string  `json:"category"`
+	Alias      string  `json:"alias"`
+	Popularity int     `json:"popularity"`
+	Score      float64 `json:"score"`
+	Exact      bool    `json:"exact"`
+}
+
+type UnknownSkill struct {
+	Alias       string    `json:"alias"`
+	Name        string    `json:"name"`
+	Occurrences int       `json:"occurrences"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// NormalizedSkill is the catalog entry a free text skill resolved to.
+// SkillID is 0 when the text is not in the catalog.
+type NormalizedSkill struct {
+	Input   string `json:"input"`
+	SkillID int64  `json:"skill_id,omitempty"`
+	Name    string `json:"name"`
+	Known   bool   `json:"known"`
+}
+
+type SkillImportResult struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors"`
+}
+
+// SkillKey normalises a skill spelling so that "Go lang", "go-lang" and
+// "GoLang" share a key. Symbols that change the meaning of a skill, such as
+// "+" and "#", are kept.
+func SkillKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsSpace(r) || r == '-' || r == '_' || r == '.' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+type SkillPayload struct {
+	Name     string   `json:"name" validate:"required,max=100"`
+	Category string   `json:"category" validate:"omitempty,max=100"`
+	Aliases  []string `json:"aliases" validate:"omitempty,max=50,dive,required,max=100"`
+}
+
+type SuggestQuery struct {
+	Query string `validate:"required,max=100"`
+	Limit int    `validate:"min=1,max=25"`
+}
+
+type NormalizeSkillsPayload struct {
+	Skills []string `json:"skills" validate:"required,min=1,max=200,dive,required,max=100"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type TitleSuggestion struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Popularity int     `json:"popularity"`
+	Score      float64 `json:"score"`
+}
@@ -0,0 +1,134 @@
+package parser
+
+// term is a dictionary entry. Name is what the parser reports, Aliases are
+// the spellings looked for in the resume text (case-insensitive). Terms that
+// are also common English words are only matched with their exact casing.
+type term struct {
+	Name          string
+	Aliases       []string
+	CaseSensitive bool
+}
+
+var skillDictionary = []term{
+	{Name: "Go", Aliases: []string{"Go", "Golang"}, CaseSensitive: true},
+	{Name: "Python", Aliases: []string{"python"}},
+	{Name: "Java", Aliases: []string{"java"}},
+	{Name: "JavaScript", Aliases: []string{"javascript", "es6"}},
+	{Name: "TypeScript", Aliases: []string{"typescript"}},
+	{Name: "C++", Aliases: []string{"c++", "cpp"}},
+	{Name: "C#", Aliases: []string{"c#", "csharp"}},
+	{Name: "Rust", Aliases: []string{"Rust"}, CaseSensitive: true},
+	{Name: "Ruby", Aliases: []string{"ruby"}},
+	{Name: "PHP", Aliases: []string{"php"}},
+	{Name: "Kotlin", Aliases: []string{"kotlin"}},
+	{Name: "Swift", Aliases: []string{"Swift"}, CaseSensitive: true},
+	{Name: "Scala", Aliases: []string{"scala"}},
+	{Name: "SQL", Aliases: []string{"sql"}},
+	{Name: "Bash", Aliases: []string{"bash", "shell scripting"}},
+	{Name: "HTML", Aliases: []string{"html", "html5"}},
+	{Name: "CSS", Aliases: []string{"css", "css3"}},
+	{Name: "Sass", Aliases: []string{"sass", "scss"}},
+	{Name: "React", Aliases: []string{"react", "reactjs", "react.js"}},
+	{Name: "Next.js", Aliases: []string{"next.js", "nextjs"}},
+	{Name: "Angular", Aliases: []string{"angular", "angularjs"}},
+	{Name: "Vue.js", Aliases: []string{"vue", "vuejs", "vue.js"}},
+	{Name: "Svelte", Aliases: []string{"svelte"}},
+	{Name: "Redux", Aliases: []string{"redux"}},
+	{Name: "Tailwind CSS", Aliases: []string{"tailwind", "tailwindcss"}},
+	{Name: "Node.js", Aliases: []string{"Node", "NodeJS", "Nodejs", "Node.js", "node.js"}, CaseSensitive: true},
+	{Name: "Express", Aliases: []string{"Express", "ExpressJS", "Express.js"}, CaseSensitive: true},
+	{Name: "Django", Aliases: []string{"django"}},
+	{Name: "Flask", Aliases: []string{"flask"}},
+	{Name: "FastAPI", Aliases: []string{"fastapi"}},
+	{Name: "Spring", Aliases: []string{"Spring", "Spring Boot", "SpringBoot"}, CaseSensitive: true},
+	{Name: "Ruby on Rails", Aliases: []string{"rails", "ruby on rails"}},
+	{Name: "Laravel", Aliases: []string{"laravel"}},
+	{Name: ".NET", Aliases: []string{".net", "dotnet", "asp.net"}},
+	{Name: "GraphQL", Aliases: []string{"graphql"}},
+	{Name: "REST", Aliases: []string{"REST", "RESTful", "Restful"}, CaseSensitive: true},
+	{Name: "gRPC", Aliases: []string{"grpc"}},
+	{Name: "Protocol Buffers", Aliases: []string{"protobuf", "protocol buffers"}},
+	{Name: "PostgreSQL", Aliases: []string{"postgres", "postgresql"}},
+	{Name: "MySQL", Aliases: []string{"mysql"}},
+	{Name: "SQLite", Aliases: []string{"sqlite"}},
+	{Name: "MongoDB", Aliases: []string{"mongo", "mongodb"}},
+	{Name: "Redis", Aliases: []string{"redis"}},
+	{Name: "Elasticsearch", Aliases: []string{"elasticsearch", "elastic search"}},
+	{Name: "Cassandra", Aliases: []string{"cassandra"}},
+	{Name: "DynamoDB", Aliases: []string{"dynamodb"}},
+	{Name: "Kafka", Aliases: []string{"kafka"}},
+	{Name: "RabbitMQ", Aliases: []string{"rabbitmq"}},
+	{Name: "Docker", Aliases: []string{"docker"}},
+	{Name: "Kubernetes", Aliases: []string{"kubernetes", "k8s"}},
+	{Name: "Helm", Aliases: []string{"helm"}},
+	{Name: "Terraform", Aliases: []string{"terraform"}},
+	{Name: "Ansible", Aliases: []string{"ansible"}},
+	{Name: "AWS", Aliases: []string{"aws", "amazon web services"}},
+	{Name: "Google Cloud", Aliases: []string{"gcp", "google cloud"}},
+	{Name: "Azure", Aliases: []string{"azure"}},
+	{Name: "Linux", Aliases: []string{"linux"}},
+	{Name: "Git", Aliases: []string{"git"}},
+	{Name: "CI/CD", Aliases: []string{"ci/cd", "continuous integration"}},
+	{Name: "Jenkins", Aliases: []string{"jenkins"}},
+	{Name: "GitHub Actions", Aliases: []string{"github actions"}},
+	{Name: "Microservices", Aliases: []string{"microservices", "microservice"}},
+	{Name: "Nginx", Aliases: []string{"nginx"}},
+	{Name: "Prometheus", Aliases: []string{"prometheus"}},
+	{Name: "Grafana", Aliases: []string{"grafana"}},
+	{Name: "Machine Learning", Aliases: []string{"machine learning"}},
+	{Name: "Deep Learning", Aliases: []string{"deep learning"}},
+	{Name: "NLP", Aliases: []string{"nlp", "natural language processing"}},
+	{Name: "TensorFlow", Aliases: []string{"tensorflow"}},
+	{Name: "PyTorch", Aliases: []string{"pytorch"}},
+	{Name: "scikit-learn", Aliases: []string{"scikit-learn", "sklearn"}},
+	{Name: "Pandas", Aliases: []string{"pandas"}},
+	{Name: "NumPy", Aliases: []string{"numpy"}},
+	{Name: "Spark", Aliases: []string{"spark", "pyspark", "apache spark"}},
+	{Name: "Hadoop", Aliases: []string{"hadoop"}},
+	{Name: "Airflow", Aliases: []string{"airflow"}},
+	{Name: "Tableau", Aliases: []string{"tableau"}},
+	{Name: "Power BI", Aliases: []string{"power bi", "powerbi"}},
+	{Name: "Excel", Aliases: []string{"Excel", "MS Excel"}, CaseSensitive: true},
+	{Name: "Android", Aliases: []string{"android"}},
+	{Name: "iOS", Aliases: []string{"ios"}},
+	{Name: "Flutter", Aliases: []string{"flutter"}},
+	{Name: "React Native", Aliases: []string{"react native"}},
+	{Name: "Figma", Aliases: []string{"figma"}},
+	{Name: "Jira", Aliases: []string{"jira"}},
+	{Name: "Agile", Aliases: []string{"Agile", "Scrum", "Kanban"}, CaseSensitive: true},
+	{Name: "Unit Testing", Aliases: []string{"unit testing", "tdd", "jest", "pytest", "junit"}},
+	{Name: "Selenium", Aliases: []string{"selenium"}},
+	{Name: "OAuth", Aliases: []string{"oauth", "oauth2"}},
+	{Name: "Webpack", Aliases: []string{"webpack"}},
+}
+
+var titleDictionary = []term{
+	{Name: "Software Engineer", Aliases: []string{"software engineer", "software developer", "sde"}},
+	{Name: "Senior Software Engineer", Aliases: []string{"senior software engineer", "senior software developer", "sr. software engineer", "sr software engineer"}},
+	{Name: "Staff Engineer", Aliases: []string{"staff engineer", "staff software engineer"}},
+	{Name: "Principal Engineer", Aliases: []string{"principal engineer", "principal software engineer"}},
+	{Name: "Backend Developer", Aliases: []string{"backend developer", "back-end developer", "backend engineer", "back-end engineer"}},
+	{Name: "Frontend Developer", Aliases: []string{"frontend developer", "front-end developer", "frontend engineer", "front-end engineer"}},
+	{Name: "Full Stack Developer", Aliases: []string{"full stack developer", "full-stack developer", "fullstack developer", "full stack engineer", "full-stack engineer"}},
+	{Name: "Web Developer", Aliases: []string{"web developer"}},
+	{Name: "Mobile Developer", Aliases: []string{"mobile developer", "android developer", "ios developer"}},
+	{Name: "DevOps Engineer", Aliases: []string{"devops engineer"}},
+	{Name: "Site Reliability Engineer", Aliases: []string{"site reliability engineer", "sre"}},
+	{Name: "Cloud Engineer", Aliases: []string{"cloud engineer", "cloud architect"}},
+	{Name: "Data Engineer", Aliases: []string{"data engineer"}},
+	{Name: "Data Scientist", Aliases: []string{"data scientist"}},
+	{Name: "Data Analyst", Aliases: []string{"data analyst"}},
+	{Name: "Machine Learning Engineer", Aliases: []string{"machine learning engineer", "ml engineer"}},
+	{Name: "QA Engineer", Aliases: []string{"qa engineer", "quality assurance engineer", "test engineer", "sdet"}},
+	{Name: "Security Engineer", Aliases: []string{"security engineer", "security analyst"}},
+	{Name: "Solutions Architect", Aliases: []string{"solutions architect", "software architect"}},
+	{Name: "Engineering Manager", Aliases: []string{"engineering manager"}},
+	{Name: "Technical Lead", Aliases: []string{"technical lead", "tech lead", "team lead"}},
+	{Name: "Product Manager", Aliases: []string{"product manager"}},
+	{Name: "Project Manager", Aliases: []string{"project manager"}},
+	{Name: "Business Analyst", Aliases: []string{"business analyst"}},
+	{Name: "UI/UX Designer", Aliases: []string{"ui/ux designer", "ux designer", "ui designer", "product designer"}},
+	{Name: "Database Administrator", Aliases: []string{"database administrator", "dba"}},
+	{Name: "System Administrator", Aliases: []string{"system administrator", "systems administrator", "sysadmin"}},
+	{Name: "Intern", Aliases: []string{"Intern", "Internship", "Software Engineering Intern"}, CaseSensitive: true},
+}
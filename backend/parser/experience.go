@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	monthPattern = `jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?`
+	datePattern  = `(?:(?:` + monthPattern + `)\.?,?\s+\d{4}|\d{1,2}/\d{4}|\d{4})`
+	rangeExpr    = regexp.MustCompile(`(?i)(` + datePattern + `)\s*(?:-|–|—|to|until)\s*(` + datePattern + `|present|current|now|today|ongoing)`)
+	monthExpr    = regexp.MustCompile(`(?i)^(` + monthPattern + `)`)
+	months       = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+)
+
+// Period is a stretch of time worked, such as one job.
+type Period struct {
+	Start, End time.Time
+}
+
+// estimateExperience sums the date ranges found in the text, counting
+// overlapping ranges once, and returns the total in whole years.
+func estimateExperience(text string, now time.Time) int32 {
+	var periods []Period
+	for _, m := range rangeExpr.FindAllStringSubmatch(text, -1) {
+		start, ok := parseDate(m[1], now, false)
+		if !ok {
+			continue
+		}
+		end, ok := parseDate(m[2], now, true)
+		if !ok || !end.After(start) || start.After(now) {
+			continue
+		}
+		if end.After(now) {
+			end = now
+		}
+		periods = append(periods, Period{Start: start, End: end})
+	}
+	return Experience(periods)
+}
+
+// Experience returns the time covered by the periods, counting overlapping
+// ones once, rounded to whole years.
+func Experience(periods []Period) int32 {
+	if len(periods) == 0 {
+		return 0
+	}
+	periods = append([]Period(nil), periods...)
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Start.Before(periods[j].Start)
+	})
+	total := time.Duration(0)
+	current := periods[0]
+	for _, p := range periods[1:] {
+		if !p.Start.After(current.End) {
+			if p.End.After(current.End) {
+				current.End = p.End
+			}
+			continue
+		}
+		total += current.End.Sub(current.Start)
+		current = p
+	}
+	total += current.End.Sub(current.Start)
+	years := total.Hours() / 24 / 365.25
+	return int32(years + 0.5)
+}
+
+// parseDate reads "Jan 2020", "01/2020" or "2020". A bare year is taken as
+// January for a start date and December for an end date.
+func parseDate(s string, now time.Time, end bool) (time.Time, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "present", "current", "now", "today", "ongoing":
+		return now, true
+	}
+	month := 1
+	if end {
+		month = 12
+	}
+	yearPart := s
+	if m := monthExpr.FindString(s); m != "" {
+		for i, name := range months {
+			if strings.HasPrefix(m, name) {
+				month = i + 1
+				break
+			}
+		}
+		fields := strings.Fields(s)
+		yearPart = fields[len(fields)-1]
+	} else if slash := strings.Index(s, "/"); slash > 0 {
+		v, err := strconv.Atoi(s[:slash])
+		if err != nil || v < 1 || v > 12 {
+			return time.Time{}, false
+		}
+		month = v
+		yearPart = s[slash+1:]
+	}
+	year, err := strconv.Atoi(yearPart)
+	if err != nil || year < 1950 || year > now.Year()+1 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	if end {
+		// Ranges are inclusive of the end month
+		date = date.AddDate(0, 1, 0)
+	}
+	return date, true
+}
@@ -0,0 +1,31 @@
+package parser
+
+import (
+	jobpb "Inquiro/protos"
+	"context"
+)
+
+type GrpcParser struct {
+	client jobpb.JobServiceClient
+}
+
+func NewGrpcParser(client jobpb.JobServiceClient) *GrpcParser {
+	return &GrpcParser{
+		client: client,
+	}
+}
+
+func (g *GrpcParser) Parse(ctx context.Context, fileName string, content []byte) (*ParsedResume, error) {
+	res, err := g.client.ParseResume(ctx, &jobpb.ParseResumeRequest{
+		ResumeFileContent: content,
+		FileName:          fileName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ParsedResume{
+		JobTitles:  res.JobTitles,
+		Skills:     res.Skills,
+		Experience: res.Experience,
+	}, nil
+}
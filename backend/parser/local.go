@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"Inquiro/utils/pdf"
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LocalParser parses resumes without the job service. It extracts the text,
+// looks up known skills and titles in it and estimates the experience from
+// the date ranges it finds. Its results are always marked as degraded.
+type LocalParser struct {
+	skills []matcher
+	titles []matcher
+}
+
+type matcher struct {
+	name string
+	expr *regexp.Regexp
+}
+
+func NewLocalParser() *LocalParser {
+	return &LocalParser{
+		skills: compileDictionary(skillDictionary),
+		titles: compileDictionary(titleDictionary),
+	}
+}
+
+func (l *LocalParser) Parse(ctx context.Context, fileName string, content []byte) (*ParsedResume, error) {
+	text, err := ExtractText(content)
+	if err != nil {
+		return nil, err
+	}
+	return &ParsedResume{
+		JobTitles:  match(l.titles, text),
+		Skills:     match(l.skills, text),
+		Experience: estimateExperience(text, time.Now().UTC()),
+		Degraded:   true,
+	}, nil
+}
+
+// ExtractText returns the text of a PDF or plain text resume.
+func ExtractText(content []byte) (string, error) {
+	if len(content) == 0 {
+		return "", ErrEmptyResume
+	}
+	if pdf.IsPDF(content) {
+		text, err := pdf.ExtractText(content)
+		if err != nil {
+			return "", err
+		}
+		return text, nil
+	}
+	if !utf8.Valid(content) || strings.ContainsRune(string(content), 0) {
+		return "", ErrUnsupportedFormat
+	}
+	text := strings.TrimSpace(string(content))
+	if text == "" {
+		return "", ErrEmptyResume
+	}
+	return text, nil
+}
+
+func compileDictionary(terms []term) []matcher {
+	matchers := make([]matcher, 0, len(terms))
+	for _, t := range terms {
+		aliases := make([]string, 0, len(t.Aliases))
+		for _, alias := range t.Aliases {
+			aliases = append(aliases, regexp.QuoteMeta(alias))
+		}
+		flags := "(?i)"
+		if t.CaseSensitive {
+			flags = ""
+		}
+		// Skill names contain symbols such as "c++" and ".net", so word
+		// boundaries are spelled out instead of using \b
+		expr := regexp.MustCompile(flags + `(?:^|[^\pL\pN+#])(?:` + strings.Join(aliases, "|") + `)(?:$|[^\pL\pN+#])`)
+		matchers = append(matchers, matcher{name: t.Name, expr: expr})
+	}
+	return matchers
+}
+
+// match returns the names of the matchers found in text, ordered by where
+// they first appear.
+func match(matchers []matcher, text string) []string {
+	type found struct {
+		name string
+		at   int
+	}
+	var hits []found
+	for _, m := range matchers {
+		if loc := m.expr.FindStringIndex(text); loc != nil {
+			hits = append(hits, found{name: m.name, at: loc[0]})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].at < hits[j].at
+	})
+	names := make([]string, 0, len(hits))
+	for _, h := range hits {
+		names = append(names, h.name)
+	}
+	return names
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported resume format")
+	ErrEmptyResume       = errors.New("resume has nothing to process")
+)
+
+// ParsedResume has the same shape as protos.ParseResumeResponse. Degraded is
+// set when the result comes from the fallback parser instead of the job
+// service, so clients can warn that the data is less accurate.
+type ParsedResume struct {
+	JobTitles  []string `json:"job_titles"`
+	Skills     []string `json:"skills"`
+	Experience int32    `json:"experience"`
+	Degraded   bool     `json:"degraded"`
+}
+
+type ResumeParser interface {
+	Parse(ctx context.Context, fileName string, content []byte) (*ParsedResume, error)
+}
+
+// FallbackParser uses the primary parser and switches to the fallback parser
+// when the primary one cannot be reached.
+type FallbackParser struct {
+	primary  ResumeParser
+	fallback ResumeParser
+	logger   *zap.SugaredLogger
+}
+
+func NewFallbackParser(primary ResumeParser, fallback ResumeParser, logger *zap.SugaredLogger) *FallbackParser {
+	return &FallbackParser{
+		primary:  primary,
+		fallback: fallback,
+		logger:   logger,
+	}
+}
+
+func (f *FallbackParser) Parse(ctx context.Context, fileName string, content []byte) (*ParsedResume, error) {
+	parsed, err := f.primary.Parse(ctx, fileName, content)
+	if err == nil {
+		return parsed, nil
+	}
+	if !isUnreachable(err) {
+		return nil, err
+	}
+	f.logger.Warnw("job service unreachable, using fallback parser", "error : ", err.Error())
+	return f.fallback.Parse(ctx, fileName, content)
+}
+
+// isUnreachable reports whether err means the service is down rather than
+// the resume being rejected.
+func isUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Unimplemented:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	jobpb "Inquiro/protos"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// Stage is a step of parsing a resume, reported to clients while they wait.
+type Stage string
+
+const (
+	StageQueued     Stage = "queued"
+	StageExtracting Stage = "extracting"
+	StageParsing    Stage = "parsing"
+	StageScoring    Stage = "scoring"
+	StageDone       Stage = "done"
+)
+
+type Progress struct {
+	Stage   Stage  `json:"stage"`
+	Percent int    `json:"percent"`
+	Message string `json:"message,omitempty"`
+}
+
+type ProgressFunc func(Progress)
+
+// ProgressParser is a ResumeParser that reports its stages as it goes.
+type ProgressParser interface {
+	ResumeParser
+	ParseWithProgress(ctx context.Context, fileName string, content []byte, progress ProgressFunc) (*ParsedResume, error)
+}
+
+// ParseWithProgress uses p's own progress reporting when it has one, and
+// otherwise reports the start and end of p.Parse.
+func ParseWithProgress(ctx context.Context, p ResumeParser, fileName string, content []byte, progress ProgressFunc) (*ParsedResume, error) {
+	if pp, ok := p.(ProgressParser); ok {
+		return pp.ParseWithProgress(ctx, fileName, content, progress)
+	}
+	progress(Progress{Stage: StageParsing, Percent: 10})
+	parsed, err := p.Parse(ctx, fileName, content)
+	if err != nil {
+		return nil, err
+	}
+	progress(Progress{Stage: StageDone, Percent: 100})
+	return parsed, nil
+}
+
+var ErrIncompleteProgress = errors.New("job service ended the progress stream without a result")
+
+func (g *GrpcParser) ParseWithProgress(ctx context.Context, fileName string, content []byte, progress ProgressFunc) (*ParsedResume, error) {
+	stream, err := g.client.ParseResumeWithProgress(ctx, &jobpb.ParseResumeRequest{
+		ResumeFileContent: content,
+		FileName:          fileName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrIncompleteProgress
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res.Stage == jobpb.ParseResumeProgress_STAGE_UNSPECIFIED {
+			continue
+		}
+		progress(Progress{
+			Stage:   Stage(strings.ToLower(res.Stage.String())),
+			Percent: int(res.Percent),
+			Message: res.Message,
+		})
+		if res.Stage == jobpb.ParseResumeProgress_DONE {
+			result := res.GetResult()
+			return &ParsedResume{
+				JobTitles:  result.GetJobTitles(),
+				Skills:     result.GetSkills(),
+				Experience: result.GetExperience(),
+			}, nil
+		}
+	}
+}
+
+func (l *LocalParser) ParseWithProgress(ctx context.Context, fileName string, content []byte, progress ProgressFunc) (*ParsedResume, error) {
+	progress(Progress{Stage: StageExtracting, Percent: 10, Message: "Extracting text from the resume"})
+	text, err := ExtractText(content)
+	if err != nil {
+		return nil, err
+	}
+	progress(Progress{Stage: StageParsing, Percent: 40, Message: "Reading titles and skills"})
+	parsed := &ParsedResume{
+		JobTitles: match(l.titles, text),
+		Skills:    match(l.skills, text),
+		Degraded:  true,
+	}
+	progress(Progress{Stage: StageScoring, Percent: 80, Message: "Calculating experience"})
+	parsed.Experience = estimateExperience(text, time.Now().UTC())
+	progress(Progress{Stage: StageDone, Percent: 100, Message: "Done"})
+	return parsed, nil
+}
+
+// ParseWithProgress switches to the fallback parser the same way Parse does.
+// Stages already reported by the primary parser are reported again.
+func (f *FallbackParser) ParseWithProgress(ctx context.Context, fileName string, content []byte, progress ProgressFunc) (*ParsedResume, error) {
+	parsed, err := ParseWithProgress(ctx, f.primary, fileName, content, progress)
+	if err == nil {
+		return parsed, nil
+	}
+	if !isUnreachable(err) {
+		return nil, err
+	}
+	f.logger.Warnw("job service unreachable, using fallback parser", "error : ", err.Error())
+	return ParseWithProgress(ctx, f.fallback, fileName, content, progress)
+}
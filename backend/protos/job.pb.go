@@ -21,6 +21,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ParseResumeProgress_Stage int32
+
+const (
+	ParseResumeProgress_STAGE_UNSPECIFIED ParseResumeProgress_Stage = 0
+	ParseResumeProgress_EXTRACTING        ParseResumeProgress_Stage = 1
+	ParseResumeProgress_PARSING           ParseResumeProgress_Stage = 2
+	ParseResumeProgress_SCORING           ParseResumeProgress_Stage = 3
+	ParseResumeProgress_DONE              ParseResumeProgress_Stage = 4
+)
+
+// Enum value maps for ParseResumeProgress_Stage.
+var (
+	ParseResumeProgress_Stage_name = map[int32]string{
+		0: "STAGE_UNSPECIFIED",
+		1: "EXTRACTING",
+		2: "PARSING",
+		3: "SCORING",
+		4: "DONE",
+	}
+	ParseResumeProgress_Stage_value = map[string]int32{
+		"STAGE_UNSPECIFIED": 0,
+		"EXTRACTING":        1,
+		"PARSING":           2,
+		"SCORING":           3,
+		"DONE":              4,
+	}
+)
+
+func (x ParseResumeProgress_Stage) Enum() *ParseResumeProgress_Stage {
+	p := new(ParseResumeProgress_Stage)
+	*p = x
+	return p
+}
+
+func (x ParseResumeProgress_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ParseResumeProgress_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_job_proto_enumTypes[0].Descriptor()
+}
+
+func (ParseResumeProgress_Stage) Type() protoreflect.EnumType {
+	return &file_protos_job_proto_enumTypes[0]
+}
+
+func (x ParseResumeProgress_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ParseResumeProgress_Stage.Descriptor instead.
+func (ParseResumeProgress_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_protos_job_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type ParseResumeRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	ResumeFileContent []byte                 `protobuf:"bytes,1,opt,name=resume_file_content,json=resumeFileContent,proto3" json:"resume_file_content,omitempty"`
@@ -133,6 +188,76 @@ func (x *ParseResumeResponse) GetExperience() int32 {
 	return 0
 }
 
+// ParseResumeProgress reports a stage of ParseResumeWithProgress. The last
+// message has stage DONE and carries the result.
+type ParseResumeProgress struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Stage         ParseResumeProgress_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=job.ParseResumeProgress_Stage" json:"stage,omitempty"`
+	Percent       int32                     `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	Message       string                    `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Result        *ParseResumeResponse      `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseResumeProgress) Reset() {
+	*x = ParseResumeProgress{}
+	mi := &file_protos_job_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseResumeProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseResumeProgress) ProtoMessage() {}
+
+func (x *ParseResumeProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_job_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseResumeProgress.ProtoReflect.Descriptor instead.
+func (*ParseResumeProgress) Descriptor() ([]byte, []int) {
+	return file_protos_job_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ParseResumeProgress) GetStage() ParseResumeProgress_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return ParseResumeProgress_STAGE_UNSPECIFIED
+}
+
+func (x *ParseResumeProgress) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *ParseResumeProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ParseResumeProgress) GetResult() *ParseResumeResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
 type CalculateRelevancyRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	ResumeSkills     []string               `protobuf:"bytes,1,rep,name=resume_skills,json=resumeSkills,proto3" json:"resume_skills,omitempty"`
@@ -144,7 +269,7 @@ type CalculateRelevancyRequest struct {
 
 func (x *CalculateRelevancyRequest) Reset() {
 	*x = CalculateRelevancyRequest{}
-	mi := &file_protos_job_proto_msgTypes[2]
+	mi := &file_protos_job_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -156,7 +281,7 @@ func (x *CalculateRelevancyRequest) String() string {
 func (*CalculateRelevancyRequest) ProtoMessage() {}
 
 func (x *CalculateRelevancyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_job_proto_msgTypes[2]
+	mi := &file_protos_job_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +294,7 @@ func (x *CalculateRelevancyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateRelevancyRequest.ProtoReflect.Descriptor instead.
 func (*CalculateRelevancyRequest) Descriptor() ([]byte, []int) {
-	return file_protos_job_proto_rawDescGZIP(), []int{2}
+	return file_protos_job_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CalculateRelevancyRequest) GetResumeSkills() []string {
@@ -202,7 +327,7 @@ type CalculateRelevancyResponse struct {
 
 func (x *CalculateRelevancyResponse) Reset() {
 	*x = CalculateRelevancyResponse{}
-	mi := &file_protos_job_proto_msgTypes[3]
+	mi := &file_protos_job_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -214,7 +339,7 @@ func (x *CalculateRelevancyResponse) String() string {
 func (*CalculateRelevancyResponse) ProtoMessage() {}
 
 func (x *CalculateRelevancyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_job_proto_msgTypes[3]
+	mi := &file_protos_job_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,7 +352,7 @@ func (x *CalculateRelevancyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateRelevancyResponse.ProtoReflect.Descriptor instead.
 func (*CalculateRelevancyResponse) Descriptor() ([]byte, []int) {
-	return file_protos_job_proto_rawDescGZIP(), []int{3}
+	return file_protos_job_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CalculateRelevancyResponse) GetRelevancyScore() float64 {
@@ -237,6 +362,178 @@ func (x *CalculateRelevancyResponse) GetRelevancyScore() float64 {
 	return 0
 }
 
+type RelevancyJob struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JobId          string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobDescription string                 `protobuf:"bytes,2,opt,name=job_description,json=jobDescription,proto3" json:"job_description,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RelevancyJob) Reset() {
+	*x = RelevancyJob{}
+	mi := &file_protos_job_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelevancyJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelevancyJob) ProtoMessage() {}
+
+func (x *RelevancyJob) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_job_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelevancyJob.ProtoReflect.Descriptor instead.
+func (*RelevancyJob) Descriptor() ([]byte, []int) {
+	return file_protos_job_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RelevancyJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RelevancyJob) GetJobDescription() string {
+	if x != nil {
+		return x.JobDescription
+	}
+	return ""
+}
+
+type BatchCalculateRelevancyRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ResumeSkills     []string               `protobuf:"bytes,1,rep,name=resume_skills,json=resumeSkills,proto3" json:"resume_skills,omitempty"`
+	ResumeExperience string                 `protobuf:"bytes,2,opt,name=resume_experience,json=resumeExperience,proto3" json:"resume_experience,omitempty"`
+	Jobs             []*RelevancyJob        `protobuf:"bytes,3,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BatchCalculateRelevancyRequest) Reset() {
+	*x = BatchCalculateRelevancyRequest{}
+	mi := &file_protos_job_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCalculateRelevancyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCalculateRelevancyRequest) ProtoMessage() {}
+
+func (x *BatchCalculateRelevancyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_job_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCalculateRelevancyRequest.ProtoReflect.Descriptor instead.
+func (*BatchCalculateRelevancyRequest) Descriptor() ([]byte, []int) {
+	return file_protos_job_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchCalculateRelevancyRequest) GetResumeSkills() []string {
+	if x != nil {
+		return x.ResumeSkills
+	}
+	return nil
+}
+
+func (x *BatchCalculateRelevancyRequest) GetResumeExperience() string {
+	if x != nil {
+		return x.ResumeExperience
+	}
+	return ""
+}
+
+func (x *BatchCalculateRelevancyRequest) GetJobs() []*RelevancyJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type BatchCalculateRelevancyResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JobId          string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	RelevancyScore float64                `protobuf:"fixed64,2,opt,name=relevancy_score,json=relevancyScore,proto3" json:"relevancy_score,omitempty"`
+	Error          string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchCalculateRelevancyResponse) Reset() {
+	*x = BatchCalculateRelevancyResponse{}
+	mi := &file_protos_job_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCalculateRelevancyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCalculateRelevancyResponse) ProtoMessage() {}
+
+func (x *BatchCalculateRelevancyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_job_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCalculateRelevancyResponse.ProtoReflect.Descriptor instead.
+func (*BatchCalculateRelevancyResponse) Descriptor() ([]byte, []int) {
+	return file_protos_job_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchCalculateRelevancyResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *BatchCalculateRelevancyResponse) GetRelevancyScore() float64 {
+	if x != nil {
+		return x.RelevancyScore
+	}
+	return 0
+}
+
+func (x *BatchCalculateRelevancyResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_protos_job_proto protoreflect.FileDescriptor
 
 const file_protos_job_proto_rawDesc = "" +
@@ -251,17 +548,42 @@ const file_protos_job_proto_rawDesc = "" +
 	"\x06skills\x18\x02 \x03(\tR\x06skills\x12\x1e\n" +
 	"\n" +
 	"experience\x18\x03 \x01(\x05R\n" +
-	"experience\"\x96\x01\n" +
+	"experience\"\x85\x02\n" +
+	"\x13ParseResumeProgress\x124\n" +
+	"\x05stage\x18\x01 \x01(\x0e2\x1e.job.ParseResumeProgress.StageR\x05stage\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x05R\apercent\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x120\n" +
+	"\x06result\x18\x04 \x01(\v2\x18.job.ParseResumeResponseR\x06result\"R\n" +
+	"\x05Stage\x12\x15\n" +
+	"\x11STAGE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"EXTRACTING\x10\x01\x12\v\n" +
+	"\aPARSING\x10\x02\x12\v\n" +
+	"\aSCORING\x10\x03\x12\b\n" +
+	"\x04DONE\x10\x04\"\x96\x01\n" +
 	"\x19CalculateRelevancyRequest\x12#\n" +
 	"\rresume_skills\x18\x01 \x03(\tR\fresumeSkills\x12+\n" +
 	"\x11resume_experience\x18\x02 \x01(\tR\x10resumeExperience\x12'\n" +
 	"\x0fjob_description\x18\x03 \x01(\tR\x0ejobDescription\"E\n" +
 	"\x1aCalculateRelevancyResponse\x12'\n" +
-	"\x0frelevancy_score\x18\x01 \x01(\x01R\x0erelevancyScore2\xa9\x01\n" +
+	"\x0frelevancy_score\x18\x01 \x01(\x01R\x0erelevancyScore\"N\n" +
+	"\fRelevancyJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12'\n" +
+	"\x0fjob_description\x18\x02 \x01(\tR\x0ejobDescription\"\x99\x01\n" +
+	"\x1eBatchCalculateRelevancyRequest\x12#\n" +
+	"\rresume_skills\x18\x01 \x03(\tR\fresumeSkills\x12+\n" +
+	"\x11resume_experience\x18\x02 \x01(\tR\x10resumeExperience\x12%\n" +
+	"\x04jobs\x18\x03 \x03(\v2\x11.job.RelevancyJobR\x04jobs\"w\n" +
+	"\x1fBatchCalculateRelevancyResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12'\n" +
+	"\x0frelevancy_score\x18\x02 \x01(\x01R\x0erelevancyScore\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error2\xe5\x02\n" +
 	"\n" +
 	"JobService\x12B\n" +
 	"\vParseResume\x12\x17.job.ParseResumeRequest\x1a\x18.job.ParseResumeResponse\"\x00\x12W\n" +
-	"\x12CalculateRelevancy\x12\x1e.job.CalculateRelevancyRequest\x1a\x1f.job.CalculateRelevancyResponse\"\x00B\x11Z\x0f./protos;protosb\x06proto3"
+	"\x12CalculateRelevancy\x12\x1e.job.CalculateRelevancyRequest\x1a\x1f.job.CalculateRelevancyResponse\"\x00\x12h\n" +
+	"\x17BatchCalculateRelevancy\x12#.job.BatchCalculateRelevancyRequest\x1a$.job.BatchCalculateRelevancyResponse\"\x000\x01\x12P\n" +
+	"\x17ParseResumeWithProgress\x12\x17.job.ParseResumeRequest\x1a\x18.job.ParseResumeProgress\"\x000\x01B\x11Z\x0f./protos;protosb\x06proto3"
 
 var (
 	file_protos_job_proto_rawDescOnce sync.Once
@@ -275,23 +597,36 @@ func file_protos_job_proto_rawDescGZIP() []byte {
 	return file_protos_job_proto_rawDescData
 }
 
-var file_protos_job_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protos_job_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_protos_job_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_protos_job_proto_goTypes = []any{
-	(*ParseResumeRequest)(nil),         // 0: job.ParseResumeRequest
-	(*ParseResumeResponse)(nil),        // 1: job.ParseResumeResponse
-	(*CalculateRelevancyRequest)(nil),  // 2: job.CalculateRelevancyRequest
-	(*CalculateRelevancyResponse)(nil), // 3: job.CalculateRelevancyResponse
+	(ParseResumeProgress_Stage)(0),          // 0: job.ParseResumeProgress.Stage
+	(*ParseResumeRequest)(nil),              // 1: job.ParseResumeRequest
+	(*ParseResumeResponse)(nil),             // 2: job.ParseResumeResponse
+	(*ParseResumeProgress)(nil),             // 3: job.ParseResumeProgress
+	(*CalculateRelevancyRequest)(nil),       // 4: job.CalculateRelevancyRequest
+	(*CalculateRelevancyResponse)(nil),      // 5: job.CalculateRelevancyResponse
+	(*RelevancyJob)(nil),                    // 6: job.RelevancyJob
+	(*BatchCalculateRelevancyRequest)(nil),  // 7: job.BatchCalculateRelevancyRequest
+	(*BatchCalculateRelevancyResponse)(nil), // 8: job.BatchCalculateRelevancyResponse
 }
 var file_protos_job_proto_depIdxs = []int32{
-	0, // 0: job.JobService.ParseResume:input_type -> job.ParseResumeRequest
-	2, // 1: job.JobService.CalculateRelevancy:input_type -> job.CalculateRelevancyRequest
-	1, // 2: job.JobService.ParseResume:output_type -> job.ParseResumeResponse
-	3, // 3: job.JobService.CalculateRelevancy:output_type -> job.CalculateRelevancyResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: job.ParseResumeProgress.stage:type_name -> job.ParseResumeProgress.Stage
+	2, // 1: job.ParseResumeProgress.result:type_name -> job.ParseResumeResponse
+	6, // 2: job.BatchCalculateRelevancyRequest.jobs:type_name -> job.RelevancyJob
+	1, // 3: job.JobService.ParseResume:input_type -> job.ParseResumeRequest
+	4, // 4: job.JobService.CalculateRelevancy:input_type -> job.CalculateRelevancyRequest
+	7, // 5: job.JobService.BatchCalculateRelevancy:input_type -> job.BatchCalculateRelevancyRequest
+	1, // 6: job.JobService.ParseResumeWithProgress:input_type -> job.ParseResumeRequest
+	2, // 7: job.JobService.ParseResume:output_type -> job.ParseResumeResponse
+	5, // 8: job.JobService.CalculateRelevancy:output_type -> job.CalculateRelevancyResponse
+	8, // 9: job.JobService.BatchCalculateRelevancy:output_type -> job.BatchCalculateRelevancyResponse
+	3, // 10: job.JobService.ParseResumeWithProgress:output_type -> job.ParseResumeProgress
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_protos_job_proto_init() }
@@ -304,13 +639,14 @@ func file_protos_job_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_job_proto_rawDesc), len(file_protos_job_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_protos_job_proto_goTypes,
 		DependencyIndexes: file_protos_job_proto_depIdxs,
+		EnumInfos:         file_protos_job_proto_enumTypes,
 		MessageInfos:      file_protos_job_proto_msgTypes,
 	}.Build()
 	File_protos_job_proto = out.File
@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	JobService_ParseResume_FullMethodName        = "/job.JobService/ParseResume"
-	JobService_CalculateRelevancy_FullMethodName = "/job.JobService/CalculateRelevancy"
+	JobService_ParseResume_FullMethodName             = "/job.JobService/ParseResume"
+	JobService_CalculateRelevancy_FullMethodName      = "/job.JobService/CalculateRelevancy"
+	JobService_BatchCalculateRelevancy_FullMethodName = "/job.JobService/BatchCalculateRelevancy"
+	JobService_ParseResumeWithProgress_FullMethodName = "/job.JobService/ParseResumeWithProgress"
 )
 
 // JobServiceClient is the client API for JobService service.
@@ -29,6 +31,8 @@ const (
 type JobServiceClient interface {
 	ParseResume(ctx context.Context, in *ParseResumeRequest, opts ...grpc.CallOption) (*ParseResumeResponse, error)
 	CalculateRelevancy(ctx context.Context, in *CalculateRelevancyRequest, opts ...grpc.CallOption) (*CalculateRelevancyResponse, error)
+	BatchCalculateRelevancy(ctx context.Context, in *BatchCalculateRelevancyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchCalculateRelevancyResponse], error)
+	ParseResumeWithProgress(ctx context.Context, in *ParseResumeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ParseResumeProgress], error)
 }
 
 type jobServiceClient struct {
@@ -59,12 +63,52 @@ func (c *jobServiceClient) CalculateRelevancy(ctx context.Context, in *Calculate
 	return out, nil
 }
 
+func (c *jobServiceClient) BatchCalculateRelevancy(ctx context.Context, in *BatchCalculateRelevancyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchCalculateRelevancyResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[0], JobService_BatchCalculateRelevancy_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchCalculateRelevancyRequest, BatchCalculateRelevancyResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_BatchCalculateRelevancyClient = grpc.ServerStreamingClient[BatchCalculateRelevancyResponse]
+
+func (c *jobServiceClient) ParseResumeWithProgress(ctx context.Context, in *ParseResumeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ParseResumeProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[1], JobService_ParseResumeWithProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ParseResumeRequest, ParseResumeProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_ParseResumeWithProgressClient = grpc.ServerStreamingClient[ParseResumeProgress]
+
 // JobServiceServer is the server API for JobService service.
 // All implementations must embed UnimplementedJobServiceServer
 // for forward compatibility.
 type JobServiceServer interface {
 	ParseResume(context.Context, *ParseResumeRequest) (*ParseResumeResponse, error)
 	CalculateRelevancy(context.Context, *CalculateRelevancyRequest) (*CalculateRelevancyResponse, error)
+	BatchCalculateRelevancy(*BatchCalculateRelevancyRequest, grpc.ServerStreamingServer[BatchCalculateRelevancyResponse]) error
+	ParseResumeWithProgress(*ParseResumeRequest, grpc.ServerStreamingServer[ParseResumeProgress]) error
 	mustEmbedUnimplementedJobServiceServer()
 }
 
@@ -81,6 +125,12 @@ func (UnimplementedJobServiceServer) ParseResume(context.Context, *ParseResumeRe
 func (UnimplementedJobServiceServer) CalculateRelevancy(context.Context, *CalculateRelevancyRequest) (*CalculateRelevancyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CalculateRelevancy not implemented")
 }
+func (UnimplementedJobServiceServer) BatchCalculateRelevancy(*BatchCalculateRelevancyRequest, grpc.ServerStreamingServer[BatchCalculateRelevancyResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchCalculateRelevancy not implemented")
+}
+func (UnimplementedJobServiceServer) ParseResumeWithProgress(*ParseResumeRequest, grpc.ServerStreamingServer[ParseResumeProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method ParseResumeWithProgress not implemented")
+}
 func (UnimplementedJobServiceServer) mustEmbedUnimplementedJobServiceServer() {}
 func (UnimplementedJobServiceServer) testEmbeddedByValue()                    {}
 
@@ -138,6 +188,28 @@ func _JobService_CalculateRelevancy_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobService_BatchCalculateRelevancy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchCalculateRelevancyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).BatchCalculateRelevancy(m, &grpc.GenericServerStream[BatchCalculateRelevancyRequest, BatchCalculateRelevancyResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_BatchCalculateRelevancyServer = grpc.ServerStreamingServer[BatchCalculateRelevancyResponse]
+
+func _JobService_ParseResumeWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ParseResumeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).ParseResumeWithProgress(m, &grpc.GenericServerStream[ParseResumeRequest, ParseResumeProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_ParseResumeWithProgressServer = grpc.ServerStreamingServer[ParseResumeProgress]
+
 // JobService_ServiceDesc is the grpc.ServiceDesc for JobService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,6 +226,17 @@ var JobService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _JobService_CalculateRelevancy_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchCalculateRelevancy",
+			Handler:       _JobService_BatchCalculateRelevancy_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ParseResumeWithProgress",
+			Handler:       _JobService_ParseResumeWithProgress_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "protos/job.proto",
 }
@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAlertNotFound  = errors.New("job alert not found")
+	ErrDuplicateAlert = errors.New("job alert name already used")
+)
+
+type AlertRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+// alertInterval is the time between two digests of an alert, for the
+// frequency in column.
+func alertInterval(column string) string {
+	return `CASE ` + column + ` WHEN 'daily' THEN interval '1 day' WHEN 'weekly' THEN interval '7 days'
+	ELSE interval '0' END`
+}
+
+const alertColumns = `a.id, a.user_id, a.name, a.query, a.experience_band, a.filter, a.frequency, a.active, a.last_run_at, a.next_run_at, a.created_at, a.updated_at`
+
+func scanAlert(row scanner, alert *models.JobAlert) error {
+	var filter []byte
+	err := row.Scan(&alert.ID, &alert.UserID, &alert.Name, &alert.Query, &alert.ExperienceBand, &filter,
+		&alert.Frequency, &alert.Active, &alert.LastRunAt, &alert.NextRunAt,
+		&alert.CreatedAt, &alert.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	alert.Filter = models.JobFilter{}
+	return json.Unmarshal(filter, &alert.Filter)
+}
+
+func alertError(err error) error {
+	if strings.Contains(err.Error(), `"job_alerts_user_name_key"`) {
+		return ErrDuplicateAlert
+	}
+	return err
+}
+
+func (a *AlertRepository) Create(ctx context.Context, alert *models.JobAlert) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	filter, err := json.Marshal(alert.Filter)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO job_alerts (user_id, name, query, experience_band, filter, frequency, active)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, last_run_at, next_run_at, created_at, updated_at`
+	err = a.DB.QueryRowContext(ctx, query, alert.UserID, alert.Name, alert.Query, alert.ExperienceBand, filter,
+		alert.Frequency, alert.Active).
+		Scan(&alert.ID, &alert.LastRunAt, &alert.NextRunAt, &alert.CreatedAt, &alert.UpdatedAt)
+	if err != nil {
+		a.logger.Errorw("Failed to insert the job alert", "error :", err.Error())
+		return alertError(err)
+	}
+	return nil
+}
+
+// Update replaces the alert. A new frequency takes effect from the last
+// digest, and reactivating an alert starts its window now so jobs posted
+// while it was paused are not mailed.
+func (a *AlertRepository) Update(ctx context.Context, alert *models.JobAlert) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	filter, err := json.Marshal(alert.Filter)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE job_alerts a SET name = $3, query = $4, experience_band = $5, filter = $6, frequency = $7,
+	active = $8,
+	last_run_at = CASE WHEN $8 AND NOT a.active THEN now() ELSE a.last_run_at END,
+	next_run_at = CASE WHEN $8 AND NOT a.active THEN now() + ` + alertInterval("$7") + `
+		WHEN a.frequency <> $7 THEN a.last_run_at + ` + alertInterval("$7") + ` ELSE a.next_run_at END,
+	updated_at = now()
+	WHERE a.id = $1 AND a.user_id = $2
+	RETURNING a.last_run_at, a.next_run_at, a.created_at, a.updated_at`
+	err = a.DB.QueryRowContext(ctx, query, alert.ID, alert.UserID, alert.Name, alert.Query, alert.ExperienceBand,
+		filter, alert.Frequency, alert.Active).
+		Scan(&alert.LastRunAt, &alert.NextRunAt, &alert.CreatedAt, &alert.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrAlertNotFound
+		}
+		a.logger.Errorw("Failed to update the job alert", "error :", err.Error())
+		return alertError(err)
+	}
+	return nil
+}
+
+func (a *AlertRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := a.DB.ExecContext(ctx, `DELETE FROM job_alerts WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("AlertRepository.Delete failed: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
+
+var alertPages = keyset{scope: "job_alerts", columns: []string{"a.created_at", "a.id"}}
+
+// ListByUser returns a page of the alerts of the user, oldest first.
+func (a *AlertRepository) ListByUser(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobAlert, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := alertPages.after(pagination, []any{userID}, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := alertPages.page(pagination, args)
+	rows, err := a.DB.QueryContext(ctx, `SELECT `+alertColumns+` FROM job_alerts a WHERE a.user_id = $1 AND `+after+page,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := collectAlerts(rows)
+	if err != nil {
+		return nil, err
+	}
+	return nextPage(alertPages, pagination, alerts, func(alert models.JobAlert) []any {
+		return []any{alert.CreatedAt, alert.ID}
+	})
+}
+
+// AddUnsubscribeToken lets the token, mailed with a digest of the alert,
+// unsubscribe from it. Only its hash is stored.
+func (a *AlertRepository) AddUnsubscribeToken(ctx context.Context, alertID uuid.UUID, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := a.DB.ExecContext(ctx, `INSERT INTO job_alert_unsubscribe_tokens (token_hash, alert_id) VALUES ($1, $2)`,
+		hashToken(token), alertID)
+	if err != nil {
+		return fmt.Errorf("AlertRepository.AddUnsubscribeToken failed: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe deactivates the alert the token was mailed for. Unsubscribing
+// twice is not an error, so mail clients can follow the link more than once.
+func (a *AlertRepository) Unsubscribe(ctx context.Context, token string) (*models.JobAlert, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	alert := &models.JobAlert{}
+	err := scanAlert(a.DB.QueryRowContext(ctx, `UPDATE job_alerts a SET active = FALSE, updated_at = now()
+	FROM job_alert_unsubscribe_tokens t WHERE t.token_hash = $1 AND a.id = t.alert_id RETURNING `+alertColumns,
+		hashToken(token)), alert)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAlertNotFound
+		}
+		return nil, err
+	}
+	return alert, nil
+}
+
+// ClaimDue returns the active alerts whose digest is due and moves their
+// next run one interval ahead, skipping alerts claimed by another instance.
+func (a *AlertRepository) ClaimDue(ctx context.Context) ([]models.JobAlert, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `UPDATE job_alerts a SET next_run_at = now() + ` + alertInterval("a.frequency") + `
+	WHERE a.id IN (SELECT id FROM job_alerts WHERE active AND next_run_at <= now()
+	ORDER BY next_run_at FOR UPDATE SKIP LOCKED)
+	RETURNING ` + alertColumns
+	rows, err := a.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return collectAlerts(rows)
+}
+
+func collectAlerts(rows *sql.Rows) ([]models.JobAlert, error) {
+	defer rows.Close()
+	alerts := []models.JobAlert{}
+	for rows.Next() {
+		var alert models.JobAlert
+		if err := scanAlert(rows, &alert); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// NewMatches returns up to limit of the jobs matching the alert that were
+// created since its last digest and not sent by it yet, newest first, with
+// the number of all such jobs. Duplicates of other jobs are left out. Jobs
+// created in the second the last digest ran are included, as last_run_at
+// only keeps whole seconds; those already sent are filtered out.
+func (a *AlertRepository) NewMatches(ctx context.Context, alert *models.JobAlert, query *models.JobSearchQuery, limit int) ([]models.Job, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	conditions, args := searchConditions(query)
+	args = append(args, alert.LastRunAt, alert.ID, limit)
+	conditions = append(conditions, "j.canonical_id IS NULL",
+		fmt.Sprintf("j.created_at >= $%d", len(args)-2),
+		fmt.Sprintf("NOT EXISTS (SELECT 1 FROM job_alert_items i WHERE i.alert_id = $%d AND i.job_id = j.id)", len(args)-1))
+	rows, err := a.DB.QueryContext(ctx, `SELECT `+jobColumns+`, count(*) OVER () FROM jobs j
+	WHERE `+strings.Join(conditions, " AND ")+fmt.Sprintf(` ORDER BY j.posted_at DESC, j.id LIMIT $%d`, len(args)),
+		args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	total := 0
+	for rows.Next() {
+		var job models.Job
+		if err := scanJob(rows, &job, &total); err != nil {
+			return nil, 0, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, total, rows.Err()
+}
+
+// RecordSent marks the jobs as sent by the alert and closes its window at
+// ranAt, the time the digest was assembled.
+func (a *AlertRepository) RecordSent(ctx context.Context, alertID uuid.UUID, jobIDs []uuid.UUID, ranAt time.Time) error {
+	ids := make([]string, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		ids = append(ids, id.String())
+	}
+	return WithTx(a.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		if len(ids) > 0 {
+			_, err := tx.ExecContext(ctx, `INSERT INTO job_alert_items (alert_id, job_id)
+			SELECT $1, unnest($2::uuid[]) ON CONFLICT DO NOTHING`, alertID, pq.Array(ids))
+			if err != nil {
+				return fmt.Errorf("AlertRepository.RecordSent failed: %w", err)
+			}
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE job_alerts SET last_run_at = $2 WHERE id = $1`, alertID, ranAt)
+		return err
+	})
+}
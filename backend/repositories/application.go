@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrApplicationNotFound      = errors.New("application not found")
+	ErrDuplicateApplication     = errors.New("already applied to this job")
+	ErrApplicationStatusChanged = errors.New("application status changed meanwhile")
+)
+
+type ApplicationRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+const applicationColumns = `a.id, a.user_id, a.job_id, j.title, j.company, a.resume_id, r.version, a.cover_note,
+	a.status, a.created_at, a.updated_at`
+
+const applicationTables = `applications a JOIN jobs j ON j.id = a.job_id JOIN resumes r ON r.id = a.resume_id`
+
+func scanApplication(row scanner, application *models.Application) error {
+	return row.Scan(&application.ID, &application.UserID, &application.JobID, &application.JobTitle,
+		&application.Company, &application.ResumeID, &application.ResumeVersion, &application.CoverNote,
+		&application.Status, &application.CreatedAt, &application.UpdatedAt)
+}
+
+func addApplicationEvent(ctx context.Context, tx *sql.Tx, id uuid.UUID, event models.ApplicationEvent) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO application_events (application_id, from_status, to_status, changed_by, note)
+	VALUES ($1, $2, $3, $4, $5)`, id, event.FromStatus, event.ToStatus, event.ChangedBy, event.Note)
+	return err
+}
+
+// Create stores the application as applied and opens its history.
+func (a *ApplicationRepository) Create(ctx context.Context, application *models.Application) error {
+	return WithTx(a.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		application.Status = models.ApplicationApplied
+		err := tx.QueryRowContext(ctx, `INSERT INTO applications (user_id, job_id, resume_id, cover_note, status)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`, application.UserID, application.JobID,
+			application.ResumeID, application.CoverNote, application.Status).
+			Scan(&application.ID, &application.CreatedAt, &application.UpdatedAt)
+		if err != nil {
+			if strings.Contains(err.Error(), `"applications_user_job_active_idx"`) {
+				return ErrDuplicateApplication
+			}
+			a.logger.Errorw("Failed to insert the application", "error :", err.Error())
+			return fmt.Errorf("ApplicationRepository.Create failed: %w", err)
+		}
+		return addApplicationEvent(ctx, tx, application.ID, models.ApplicationEvent{
+			ToStatus:  application.Status,
+			ChangedBy: &application.UserID,
+		})
+	})
+}
+
+// GetByID returns the application with its history.
+func (a *ApplicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	application := &models.Application{}
+	err := scanApplication(a.DB.QueryRowContext(ctx, `SELECT `+applicationColumns+` FROM `+applicationTables+`
+	WHERE a.id = $1`, id), application)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := a.DB.QueryContext(ctx, `SELECT from_status, to_status, changed_by, note, created_at
+	FROM application_events WHERE application_id = $1 ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	application.History = []models.ApplicationEvent{}
+	for rows.Next() {
+		var event models.ApplicationEvent
+		var changedBy uuid.NullUUID
+		if err := rows.Scan(&event.FromStatus, &event.ToStatus, &changedBy, &event.Note, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		if changedBy.Valid {
+			event.ChangedBy = &changedBy.UUID
+		}
+		application.History = append(application.History, event)
+	}
+	return application, rows.Err()
+}
+
+var applicationPages = keyset{scope: "applications", columns: []string{"a.created_at", "a.id"}, desc: true}
+
+func (a *ApplicationRepository) list(ctx context.Context, where string, pagination *models.PaginatedQuery, args ...any) ([]models.Application, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := applicationPages.after(pagination, args, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := applicationPages.page(pagination, args)
+	rows, err := a.DB.QueryContext(ctx, `SELECT `+applicationColumns+` FROM `+applicationTables+` WHERE `+where+
+		` AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applications := []models.Application{}
+	for rows.Next() {
+		var application models.Application
+		if err := scanApplication(rows, &application); err != nil {
+			return nil, err
+		}
+		applications = append(applications, application)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(applicationPages, pagination, applications, func(application models.Application) []any {
+		return []any{application.CreatedAt, application.ID}
+	})
+}
+
+func (a *ApplicationRepository) ListByUser(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Application, error) {
+	return a.list(ctx, `a.user_id = $1`, pagination, userID)
+}
+
+// ListByJob returns the applications to the job, only those in status when
+// it is not empty.
+func (a *ApplicationRepository) ListByJob(ctx context.Context, jobID uuid.UUID, status string, pagination *models.PaginatedQuery) ([]models.Application, error) {
+	return a.list(ctx, `a.job_id = $1 AND ($2 = '' OR a.status = $2)`, pagination, jobID, status)
+}
+
+// SetStatus moves the application to event.ToStatus and records the event.
+// It fails with ErrApplicationStatusChanged when the application is no longer
+// in event.FromStatus, so concurrent changes cannot skip a check.
+func (a *ApplicationRepository) SetStatus(ctx context.Context, application *models.Application, event models.ApplicationEvent) error {
+	return WithTx(a.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		err := tx.QueryRowContext(ctx, `UPDATE applications SET status = $3, updated_at = now()
+		WHERE id = $1 AND status = $2 RETURNING updated_at`, application.ID, event.FromStatus, event.ToStatus).
+			Scan(&application.UpdatedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrApplicationStatusChanged
+			}
+			return fmt.Errorf("ApplicationRepository.SetStatus failed: %w", err)
+		}
+		if err := addApplicationEvent(ctx, tx, application.ID, event); err != nil {
+			return err
+		}
+		application.Status = event.ToStatus
+		event.CreatedAt = application.UpdatedAt
+		application.History = append(application.History, event)
+		return nil
+	})
+}
@@ -0,0 +1,332 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrDuplicateCollection = errors.New("collection name already used")
+	ErrBookmarkNotFound    = errors.New("bookmark not found")
+	ErrDuplicateBookmark   = errors.New("job already in the collection")
+)
+
+type BookmarkRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+const collectionColumns = `c.id, c.user_id, c.name, c.description,
+	(SELECT count(*) FROM bookmarks b WHERE b.collection_id = c.id), c.created_at, c.updated_at`
+
+func scanCollection(row scanner, collection *models.JobCollection) error {
+	return row.Scan(&collection.ID, &collection.UserID, &collection.Name, &collection.Description,
+		&collection.Bookmarks, &collection.CreatedAt, &collection.UpdatedAt)
+}
+
+func collectionError(err error) error {
+	if strings.Contains(err.Error(), `"job_collections_user_name_key"`) {
+		return ErrDuplicateCollection
+	}
+	return err
+}
+
+const bookmarkColumns = `b.id, b.collection_id, c.user_id, b.job_id, b.job_title, b.company, b.url, b.note, b.remind_at,
+	b.created_at, b.updated_at`
+
+func scanBookmark(row scanner, bookmark *models.Bookmark) error {
+	var jobID uuid.NullUUID
+	var remindAt sql.NullTime
+	err := row.Scan(&bookmark.ID, &bookmark.CollectionID, &bookmark.UserID, &jobID, &bookmark.JobTitle,
+		&bookmark.Company, &bookmark.URL, &bookmark.Note, &remindAt, &bookmark.CreatedAt, &bookmark.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	bookmark.JobID = nil
+	if jobID.Valid {
+		bookmark.JobID = &jobID.UUID
+	}
+	bookmark.RemindAt = nil
+	if remindAt.Valid {
+		bookmark.RemindAt = &remindAt.Time
+	}
+	return nil
+}
+
+func (b *BookmarkRepository) CreateCollection(ctx context.Context, collection *models.JobCollection) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	err := b.DB.QueryRowContext(ctx, `INSERT INTO job_collections (user_id, name, description) VALUES ($1, $2, $3)
+	RETURNING id, created_at, updated_at`, collection.UserID, collection.Name, collection.Description).
+		Scan(&collection.ID, &collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		b.logger.Errorw("Failed to insert the collection", "error :", err.Error())
+		return collectionError(err)
+	}
+	collection.Bookmarks = 0
+	return nil
+}
+
+func (b *BookmarkRepository) UpdateCollection(ctx context.Context, collection *models.JobCollection) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	err := scanCollection(b.DB.QueryRowContext(ctx, `UPDATE job_collections c SET name = $3, description = $4,
+	updated_at = now() WHERE c.id = $1 AND c.user_id = $2 RETURNING `+collectionColumns,
+		collection.ID, collection.UserID, collection.Name, collection.Description), collection)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCollectionNotFound
+		}
+		b.logger.Errorw("Failed to update the collection", "error :", err.Error())
+		return collectionError(err)
+	}
+	return nil
+}
+
+// DeleteCollection removes the collection with its bookmarks.
+func (b *BookmarkRepository) DeleteCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := b.DB.ExecContext(ctx, `DELETE FROM job_collections WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("BookmarkRepository.DeleteCollection failed: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (b *BookmarkRepository) GetCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.JobCollection, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	collection := &models.JobCollection{}
+	err := scanCollection(b.DB.QueryRowContext(ctx, `SELECT `+collectionColumns+` FROM job_collections c
+	WHERE c.id = $1 AND c.user_id = $2`, id, userID), collection)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	return collection, nil
+}
+
+var collectionPages = keyset{scope: "collections", columns: []string{"c.name", "c.id"}}
+
+func (b *BookmarkRepository) ListCollections(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobCollection, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var name string
+	var id uuid.UUID
+	after, args, err := collectionPages.after(pagination, []any{userID}, &name, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := collectionPages.page(pagination, args)
+	rows, err := b.DB.QueryContext(ctx, `SELECT `+collectionColumns+` FROM job_collections c WHERE c.user_id = $1
+	AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := []models.JobCollection{}
+	for rows.Next() {
+		var collection models.JobCollection
+		if err := scanCollection(rows, &collection); err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(collectionPages, pagination, collections, func(collection models.JobCollection) []any {
+		return []any{collection.Name, collection.ID}
+	})
+}
+
+// Add bookmarks the job in bookmark.Job to the user's collection, copying
+// the parts of the job shown once it is removed.
+func (b *BookmarkRepository) Add(ctx context.Context, bookmark *models.Bookmark) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var remindAt sql.NullTime
+	err := b.DB.QueryRowContext(ctx, `INSERT INTO bookmarks (collection_id, job_id, job_title, company, url, note, remind_at)
+	SELECT c.id, $3, $4, $5, $6, $7, $8 FROM job_collections c WHERE c.id = $1 AND c.user_id = $2
+	RETURNING id, job_title, company, url, remind_at, created_at, updated_at`,
+		bookmark.CollectionID, bookmark.UserID, bookmark.Job.ID, bookmark.Job.Title, bookmark.Job.Company,
+		bookmark.Job.URL, bookmark.Note, bookmark.RemindAt).
+		Scan(&bookmark.ID, &bookmark.JobTitle, &bookmark.Company, &bookmark.URL, &remindAt, &bookmark.CreatedAt,
+			&bookmark.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCollectionNotFound
+		}
+		if strings.Contains(err.Error(), `"bookmarks_collection_job_key"`) {
+			return ErrDuplicateBookmark
+		}
+		b.logger.Errorw("Failed to insert the bookmark", "error :", err.Error())
+		return err
+	}
+	bookmark.JobID = &bookmark.Job.ID
+	bookmark.RemindAt = nil
+	if remindAt.Valid {
+		bookmark.RemindAt = &remindAt.Time
+	}
+	return nil
+}
+
+// Update replaces the note and reminder of the bookmark.
+func (b *BookmarkRepository) Update(ctx context.Context, bookmark *models.Bookmark) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	err := scanBookmark(b.DB.QueryRowContext(ctx, `UPDATE bookmarks b SET note = $4, remind_at = $5, updated_at = now()
+	FROM job_collections c WHERE b.id = $1 AND b.collection_id = $2 AND c.id = b.collection_id AND c.user_id = $3
+	RETURNING `+bookmarkColumns, bookmark.ID, bookmark.CollectionID, bookmark.UserID, bookmark.Note, bookmark.RemindAt),
+		bookmark)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBookmarkNotFound
+		}
+		return fmt.Errorf("BookmarkRepository.Update failed: %w", err)
+	}
+	return b.attachJobs(ctx, []*models.Bookmark{bookmark})
+}
+
+func (b *BookmarkRepository) Remove(ctx context.Context, id uuid.UUID, collectionID uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := b.DB.ExecContext(ctx, `DELETE FROM bookmarks b USING job_collections c
+	WHERE b.id = $1 AND b.collection_id = $2 AND c.id = b.collection_id AND c.user_id = $3`, id, collectionID, userID)
+	if err != nil {
+		return fmt.Errorf("BookmarkRepository.Remove failed: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrBookmarkNotFound
+	}
+	return nil
+}
+
+var bookmarkPages = keyset{scope: "bookmarks", columns: []string{"b.created_at", "b.id"}, desc: true}
+
+// List returns a page of the bookmarks of the collection, latest first,
+// with the jobs that still exist.
+func (b *BookmarkRepository) List(ctx context.Context, collectionID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Bookmark, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := bookmarkPages.after(pagination, []any{collectionID}, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := bookmarkPages.page(pagination, args)
+	rows, err := b.DB.QueryContext(ctx, `SELECT `+bookmarkColumns+` FROM bookmarks b
+	JOIN job_collections c ON c.id = b.collection_id WHERE b.collection_id = $1 AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	bookmarks, err := b.collectBookmarks(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	return nextPage(bookmarkPages, pagination, bookmarks, func(bookmark models.Bookmark) []any {
+		return []any{bookmark.CreatedAt, bookmark.ID}
+	})
+}
+
+// ClaimDueReminders clears the reminders that are due, up to limit, and
+// returns their bookmarks, skipping those claimed by another instance.
+func (b *BookmarkRepository) ClaimDueReminders(ctx context.Context, limit int) ([]models.Bookmark, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := b.DB.QueryContext(ctx, `UPDATE bookmarks b SET remind_at = NULL
+	FROM job_collections c, (SELECT id, remind_at FROM bookmarks WHERE remind_at <= now()
+		ORDER BY remind_at LIMIT $1 FOR UPDATE SKIP LOCKED) due
+	WHERE b.id = due.id AND c.id = b.collection_id
+	RETURNING b.id, b.collection_id, c.user_id, b.job_id, b.job_title, b.company, b.url, b.note, due.remind_at,
+	b.created_at, b.updated_at`, limit)
+	if err != nil {
+		return nil, err
+	}
+	return b.collectBookmarks(ctx, rows)
+}
+
+func (b *BookmarkRepository) collectBookmarks(ctx context.Context, rows *sql.Rows) ([]models.Bookmark, error) {
+	defer rows.Close()
+	bookmarks := []models.Bookmark{}
+	for rows.Next() {
+		var bookmark models.Bookmark
+		if err := scanBookmark(rows, &bookmark); err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, bookmark)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	pointers := make([]*models.Bookmark, 0, len(bookmarks))
+	for i := range bookmarks {
+		pointers = append(pointers, &bookmarks[i])
+	}
+	return bookmarks, b.attachJobs(ctx, pointers)
+}
+
+// attachJobs sets the Job of the bookmarks whose job still exists.
+func (b *BookmarkRepository) attachJobs(ctx context.Context, bookmarks []*models.Bookmark) error {
+	ids := []string{}
+	for _, bookmark := range bookmarks {
+		bookmark.Job = nil
+		if bookmark.JobID != nil {
+			ids = append(ids, bookmark.JobID.String())
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows, err := b.DB.QueryContext(ctx, `SELECT `+jobColumns+` FROM jobs j WHERE j.id = ANY($1::uuid[])`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	jobs := map[uuid.UUID]*models.Job{}
+	for rows.Next() {
+		job := &models.Job{}
+		if err := scanJob(rows, job); err != nil {
+			return err
+		}
+		jobs[job.ID] = job
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, bookmark := range bookmarks {
+		if bookmark.JobID != nil {
+			bookmark.Job = jobs[*bookmark.JobID]
+		}
+	}
+	return nil
+}
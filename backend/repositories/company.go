@@ -0,0 +1,415 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCompanyNotFound  = errors.New("company not found")
+	ErrDuplicateCompany = errors.New("company name already registered")
+	ErrMemberNotFound   = errors.New("company member not found")
+	ErrAlreadyMember    = errors.New("user already belongs to a company")
+	ErrLastCompanyOwner = errors.New("company must keep an owner")
+	ErrInviteNotFound   = errors.New("company invite not found")
+)
+
+type CompanyRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+const companyColumns = `c.id, c.name, c.slug, c.description, c.website, c.location, c.verified_at,
+	(SELECT count(*) FROM jobs j WHERE j.company_id = c.id), c.created_at, c.updated_at`
+
+func scanCompany(row scanner, company *models.Company, extra ...any) error {
+	var verifiedAt sql.NullTime
+	dest := []any{&company.ID, &company.Name, &company.Slug, &company.Description, &company.Website,
+		&company.Location, &verifiedAt, &company.Jobs, &company.CreatedAt, &company.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	company.Verified = verifiedAt.Valid
+	company.VerifiedAt = nil
+	if verifiedAt.Valid {
+		company.VerifiedAt = &verifiedAt.Time
+	}
+	return nil
+}
+
+func companyError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), `"companies_slug_key"`):
+		return ErrDuplicateCompany
+	case strings.Contains(err.Error(), `"company_members_user_id_key"`),
+		strings.Contains(err.Error(), `"company_members_pkey"`):
+		return ErrAlreadyMember
+	}
+	return err
+}
+
+// setRole moves the user from the role named from to the one named to. Users
+// in any other role, such as moderators, keep theirs.
+func setRole(tx *sql.Tx, ctx context.Context, userID uuid.UUID, from string, to string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE users SET role_id = (SELECT id FROM role WHERE name = $3)
+	WHERE id = $1 AND role_id = (SELECT id FROM role WHERE name = $2)`, userID, from, to)
+	return err
+}
+
+// Create registers the company with ownerID as its owner, who becomes an
+// employer.
+func (c *CompanyRepository) Create(ctx context.Context, company *models.Company, ownerID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return WithTx(c.DB, ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `INSERT INTO companies (name, slug, description, website, location)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`, company.Name, company.Slug,
+			company.Description, company.Website, company.Location).Scan(&company.ID, &company.CreatedAt, &company.UpdatedAt)
+		if err != nil {
+			c.logger.Errorw("Failed to insert the company", "error :", err.Error())
+			return companyError(err)
+		}
+		if err := c.addMember(tx, ctx, company.ID, ownerID, models.CompanyOwner); err != nil {
+			return err
+		}
+		company.MemberRole = models.CompanyOwner
+		return nil
+	})
+}
+
+// Update replaces the company's profile. A verified company that changes
+// its name has to be verified again; its slug is kept so links stay valid.
+func (c *CompanyRepository) Update(ctx context.Context, company *models.Company) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	err := scanCompany(c.DB.QueryRowContext(ctx, `UPDATE companies c SET name = $2, description = $3, website = $4,
+	location = $5, verified_at = CASE WHEN c.name = $2 THEN c.verified_at END,
+	verified_by = CASE WHEN c.name = $2 THEN c.verified_by END, updated_at = now()
+	WHERE c.id = $1 RETURNING `+companyColumns, company.ID, company.Name, company.Description, company.Website,
+		company.Location), company)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCompanyNotFound
+		}
+		c.logger.Errorw("Failed to update the company", "error :", err.Error())
+		return fmt.Errorf("CompanyRepository.Update failed: %w", err)
+	}
+	return nil
+}
+
+func (c *CompanyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	company := &models.Company{}
+	err := scanCompany(c.DB.QueryRowContext(ctx, `SELECT `+companyColumns+` FROM companies c WHERE c.id = $1`, id), company)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+func (c *CompanyRepository) GetBySlug(ctx context.Context, slug string) (*models.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	company := &models.Company{}
+	err := scanCompany(c.DB.QueryRowContext(ctx, `SELECT `+companyColumns+` FROM companies c WHERE c.slug = $1`, slug),
+		company)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+// ForUser returns the company the user belongs to, with their role in it.
+func (c *CompanyRepository) ForUser(ctx context.Context, userID uuid.UUID) (*models.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	company := &models.Company{}
+	err := scanCompany(c.DB.QueryRowContext(ctx, `SELECT `+companyColumns+`, m.role FROM companies c
+	JOIN company_members m ON m.company_id = c.id WHERE m.user_id = $1`, userID), company, &company.MemberRole)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+var companyPages = keyset{scope: "companies", columns: []string{"c.created_at", "c.id"}, desc: true}
+
+// List returns the companies, newest first, only the verified or unverified
+// ones when verified is set.
+func (c *CompanyRepository) List(ctx context.Context, verified *bool, pagination *models.PaginatedQuery) ([]models.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := companyPages.after(pagination, []any{verified}, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := companyPages.page(pagination, args)
+	rows, err := c.DB.QueryContext(ctx, `SELECT `+companyColumns+` FROM companies c
+	WHERE ($1::boolean IS NULL OR (c.verified_at IS NOT NULL) = $1) AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	companies := []models.Company{}
+	for rows.Next() {
+		var company models.Company
+		if err := scanCompany(rows, &company); err != nil {
+			return nil, err
+		}
+		companies = append(companies, company)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(companyPages, pagination, companies, func(company models.Company) []any {
+		return []any{company.CreatedAt, company.ID}
+	})
+}
+
+// SetVerified marks the company as verified by verifiedBy, or as not
+// verified when verifiedBy is nil.
+func (c *CompanyRepository) SetVerified(ctx context.Context, id uuid.UUID, verifiedBy *uuid.UUID) (*models.Company, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	company := &models.Company{}
+	err := scanCompany(c.DB.QueryRowContext(ctx, `UPDATE companies c
+	SET verified_at = CASE WHEN $2::uuid IS NULL THEN NULL ELSE COALESCE(c.verified_at, now()) END,
+	verified_by = $2, updated_at = now() WHERE c.id = $1 RETURNING `+companyColumns, id, verifiedBy), company)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, fmt.Errorf("CompanyRepository.SetVerified failed: %w", err)
+	}
+	return company, nil
+}
+
+var memberPages = keyset{scope: "company_members", columns: []string{"m.created_at", "m.user_id"}}
+
+// Members returns a page of the members of the company, in the order they
+// joined.
+func (c *CompanyRepository) Members(ctx context.Context, companyID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var userID uuid.UUID
+	after, args, err := memberPages.after(pagination, []any{companyID}, &createdAt, &userID)
+	if err != nil {
+		return nil, err
+	}
+	page, args := memberPages.page(pagination, args)
+	rows, err := c.DB.QueryContext(ctx, `SELECT m.company_id, m.user_id, u.username, u.first_name, u.last_name, u.email,
+	m.role, m.created_at FROM company_members m JOIN users u ON u.id = m.user_id
+	WHERE m.company_id = $1 AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.CompanyMember{}
+	for rows.Next() {
+		var member models.CompanyMember
+		err := rows.Scan(&member.CompanyID, &member.UserID, &member.Username, &member.FirstName, &member.LastName,
+			&member.Email, &member.Role, &member.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(memberPages, pagination, members, func(member models.CompanyMember) []any {
+		return []any{member.CreatedAt, member.UserID}
+	})
+}
+
+const inviteColumns = `i.id, i.company_id, i.email, i.role, i.invited_by, i.expires_at, i.created_at`
+
+func scanInvite(row scanner, invite *models.CompanyInvite) error {
+	return row.Scan(&invite.ID, &invite.CompanyID, &invite.Email, &invite.Role, &invite.InvitedBy, &invite.ExpiresAt,
+		&invite.CreatedAt)
+}
+
+// Invite saves the invite with the hash of its token, replacing any pending
+// invite of the same email to the company.
+func (c *CompanyRepository) Invite(ctx context.Context, invite *models.CompanyInvite, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	err := c.DB.QueryRowContext(ctx, `INSERT INTO company_invites (company_id, email, role, token_hash, invited_by, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT ON CONSTRAINT company_invites_company_email_key DO UPDATE SET role = EXCLUDED.role,
+	token_hash = EXCLUDED.token_hash, invited_by = EXCLUDED.invited_by, expires_at = EXCLUDED.expires_at,
+	created_at = now()
+	RETURNING id, created_at`, invite.CompanyID, invite.Email, invite.Role, hashToken(token), invite.InvitedBy,
+		invite.ExpiresAt).Scan(&invite.ID, &invite.CreatedAt)
+	if err != nil {
+		c.logger.Errorw("Failed to insert the company invite", "error :", err.Error())
+		return fmt.Errorf("CompanyRepository.Invite failed: %w", err)
+	}
+	return nil
+}
+
+var invitePages = keyset{scope: "company_invites", columns: []string{"i.created_at", "i.id"}, desc: true}
+
+// Invites returns a page of the pending invites of the company, newest
+// first.
+func (c *CompanyRepository) Invites(ctx context.Context, companyID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyInvite, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := invitePages.after(pagination, []any{companyID}, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := invitePages.page(pagination, args)
+	rows, err := c.DB.QueryContext(ctx, `SELECT `+inviteColumns+` FROM company_invites i
+	WHERE i.company_id = $1 AND i.expires_at > now() AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invites := []models.CompanyInvite{}
+	for rows.Next() {
+		var invite models.CompanyInvite
+		if err := scanInvite(rows, &invite); err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(invitePages, pagination, invites, func(invite models.CompanyInvite) []any {
+		return []any{invite.CreatedAt, invite.ID}
+	})
+}
+
+func (c *CompanyRepository) RevokeInvite(ctx context.Context, companyID uuid.UUID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := c.DB.ExecContext(ctx, `DELETE FROM company_invites WHERE id = $1 AND company_id = $2`, id, companyID)
+	if err != nil {
+		return fmt.Errorf("CompanyRepository.RevokeInvite failed: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrInviteNotFound
+	}
+	return nil
+}
+
+// AcceptInvite adds the user to the company of the unexpired invite holding
+// the token, making them an employer, and uses the invite up. The invite
+// must be for the user's email.
+func (c *CompanyRepository) AcceptInvite(ctx context.Context, token string, user *models.User) (*models.CompanyMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	member := &models.CompanyMember{
+		UserID:    user.ID,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+	}
+	err := WithTx(c.DB, ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `DELETE FROM company_invites WHERE token_hash = $1 AND lower(email) = lower($2)
+		AND expires_at > now() RETURNING company_id, role`, hashToken(token), user.Email).
+			Scan(&member.CompanyID, &member.Role)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrInviteNotFound
+			}
+			return fmt.Errorf("CompanyRepository.AcceptInvite failed: %w", err)
+		}
+		if err := c.addMember(tx, ctx, member.CompanyID, member.UserID, member.Role); err != nil {
+			return err
+		}
+		return tx.QueryRowContext(ctx, `SELECT created_at FROM company_members WHERE company_id = $1 AND user_id = $2`,
+			member.CompanyID, member.UserID).Scan(&member.CreatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (c *CompanyRepository) addMember(tx *sql.Tx, ctx context.Context, companyID uuid.UUID, userID uuid.UUID, role string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO company_members (company_id, user_id, role) VALUES ($1, $2, $3)`,
+		companyID, userID, role)
+	if err != nil {
+		c.logger.Errorw("Failed to insert the company member", "error :", err.Error())
+		return companyError(err)
+	}
+	if err := setRole(tx, ctx, userID, models.RoleNameUser, models.RoleNameEmployer); err != nil {
+		return fmt.Errorf("CompanyRepository.addMember failed: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember removes the user from the company, who is a user again
+// rather than an employer. The last owner cannot be removed.
+func (c *CompanyRepository) RemoveMember(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	return WithTx(c.DB, ctx, func(tx *sql.Tx) error {
+		// Locks the owners so two owners cannot remove each other at once
+		var owners int
+		err := tx.QueryRowContext(ctx, `SELECT count(*) FROM (SELECT 1 FROM company_members
+		WHERE company_id = $1 AND role = $2 FOR UPDATE) o`, companyID, models.CompanyOwner).Scan(&owners)
+		if err != nil {
+			return fmt.Errorf("CompanyRepository.RemoveMember failed: %w", err)
+		}
+		var role string
+		err = tx.QueryRowContext(ctx, `DELETE FROM company_members WHERE company_id = $1 AND user_id = $2 RETURNING role`,
+			companyID, userID).Scan(&role)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrMemberNotFound
+			}
+			return fmt.Errorf("CompanyRepository.RemoveMember failed: %w", err)
+		}
+		if role == models.CompanyOwner && owners <= 1 {
+			return ErrLastCompanyOwner
+		}
+		if err := setRole(tx, ctx, userID, models.RoleNameEmployer, models.RoleNameUser); err != nil {
+			return fmt.Errorf("CompanyRepository.RemoveMember failed: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"Inquiro/utils/cursor"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// keyset pages through a list ordered by columns, all ascending or all
+// descending, the last of which is unique. A page after the first starts
+// after the row its cursor was made from rather than at an offset, so deep
+// pages stay fast and rows added meanwhile are neither repeated nor skipped.
+type keyset struct {
+	scope   string
+	columns []string
+	desc    bool
+}
+
+// after returns the condition selecting the rows after the cursor of
+// pagination, reading the cursor into keys and adding them to args. Without
+// a cursor every row is selected.
+func (k keyset) after(pagination *models.PaginatedQuery, args []any, keys ...any) (string, []any, error) {
+	resumed, err := k.decode(pagination, keys...)
+	if err != nil || !resumed {
+		return "TRUE", args, err
+	}
+	condition, args := k.condition(args, keys...)
+	return condition, args, nil
+}
+
+// decode reads the cursor of pagination into keys and tells whether there
+// was one. Keys beyond the columns come first and are not compared, to carry
+// what the whole list is computed from.
+func (k keyset) decode(pagination *models.PaginatedQuery, keys ...any) (bool, error) {
+	if pagination.Cursor == "" {
+		return false, nil
+	}
+	if err := cursor.Default.Decode(pagination.Cursor, k.scope, keys...); err != nil {
+		return false, ErrInvalidCursor
+	}
+	return true, nil
+}
+
+// condition selects the rows after the one with the column values keys.
+func (k keyset) condition(args []any, keys ...any) (string, []any) {
+	placeholders := make([]string, 0, len(keys))
+	for _, key := range keys[len(keys)-len(k.columns):] {
+		args = append(args, key)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+	return "(" + strings.Join(k.columns, ", ") + ") " + op + " (" + strings.Join(placeholders, ", ") + ")", args
+}
+
+// page returns the ORDER BY and LIMIT clauses of the page. One row more than
+// the page holds is read to tell whether another page follows. The offset
+// only applies to lists read without a cursor.
+func (k keyset) page(pagination *models.PaginatedQuery, args []any) (string, []any) {
+	order := make([]string, 0, len(k.columns))
+	for _, column := range k.columns {
+		if k.desc {
+			column += " DESC"
+		}
+		order = append(order, column)
+	}
+	offset := pagination.Offset
+	if pagination.Cursor != "" {
+		offset = 0
+	}
+	args = append(args, pagination.Limit+1, offset)
+	return fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", strings.Join(order, ", "), len(args)-1, len(args)), args
+}
+
+// nextPage trims the extra row read by page and sets pagination.Next to the
+// cursor of the following page, made from the keys of the last row kept.
+func nextPage[T any](k keyset, pagination *models.PaginatedQuery, rows []T, keys func(row T) []any) ([]T, error) {
+	pagination.Next = ""
+	if len(rows) <= pagination.Limit {
+		return rows, nil
+	}
+	rows = rows[:pagination.Limit]
+	next, err := cursor.Default.Encode(k.scope, keys(rows[len(rows)-1])...)
+	if err != nil {
+		return nil, err
+	}
+	pagination.Next = next
+	return rows, nil
+}
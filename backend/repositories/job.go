@@ -0,0 +1,342 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+type JobRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+const jobColumns = `j.id, j.title, j.company, j.description, j.country, j.city, j.remote, j.min_experience,
+	j.max_experience, j.skills, j.url, j.created_by, j.source_id, j.canonical_id, j.company_id, j.posted_at, j.created_at,
+	j.updated_at`
+
+func scanJob(row scanner, job *models.Job, extra ...any) error {
+	var maxExperience sql.NullInt64
+	var createdBy uuid.NullUUID
+	var sourceID sql.NullInt64
+	var canonicalID, companyID uuid.NullUUID
+	dest := []any{&job.ID, &job.Title, &job.Company, &job.Description, &job.Country, &job.City, &job.Remote,
+		&job.MinExperience, &maxExperience, pq.Array(&job.Skills), &job.URL, &createdBy, &sourceID, &canonicalID,
+		&companyID, &job.PostedAt,
+		&job.CreatedAt, &job.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	job.MaxExperience = nil
+	if maxExperience.Valid {
+		v := int(maxExperience.Int64)
+		job.MaxExperience = &v
+	}
+	job.CreatedBy = nil
+	if createdBy.Valid {
+		job.CreatedBy = &createdBy.UUID
+	}
+	job.SourceID = nil
+	if sourceID.Valid {
+		job.SourceID = &sourceID.Int64
+	}
+	job.CanonicalID = nil
+	if canonicalID.Valid {
+		job.CanonicalID = &canonicalID.UUID
+	}
+	job.CompanyID = nil
+	if companyID.Valid {
+		job.CompanyID = &companyID.UUID
+	}
+	if job.Skills == nil {
+		job.Skills = []string{}
+	}
+	return nil
+}
+
+// fingerprint returns the values of the fingerprint and company_key
+// columns, NULL and empty when the job was not fingerprinted.
+func fingerprint(job *models.Job) (any, string) {
+	if job.Fingerprint.CompanyKey == "" {
+		return nil, ""
+	}
+	return int64(job.Fingerprint.SimHash), job.Fingerprint.CompanyKey
+}
+
+func skillKeys(skills []string) []string {
+	keys := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		if key := models.SkillKey(skill); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (j *JobRepository) Create(ctx context.Context, job *models.Job) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	hash, companyKey := fingerprint(job)
+	query := `INSERT INTO jobs (title, company, description, country, city, remote, min_experience, max_experience,
+	skills, skill_keys, url, created_by, fingerprint, company_key, company_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	RETURNING id, posted_at, created_at, updated_at`
+	err := j.DB.QueryRowContext(ctx, query, job.Title, job.Company, job.Description, job.Country, job.City, job.Remote,
+		job.MinExperience, job.MaxExperience, pq.Array(job.Skills), pq.Array(skillKeys(job.Skills)), job.URL,
+		job.CreatedBy, hash, companyKey, job.CompanyID).Scan(&job.ID, &job.PostedAt, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		j.logger.Errorw("Failed to insert the job", "error :", err.Error())
+		return fmt.Errorf("JobRepository.Create failed: %w", err)
+	}
+	return nil
+}
+
+// Update replaces the job's content. The poster, source and company of the
+// job are kept.
+func (j *JobRepository) Update(ctx context.Context, job *models.Job) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	hash, companyKey := fingerprint(job)
+	query := `UPDATE jobs SET title = $2, company = $3, description = $4, country = $5, city = $6, remote = $7,
+	min_experience = $8, max_experience = $9, skills = $10, skill_keys = $11, url = $12, fingerprint = $13,
+	company_key = $14, updated_at = now()
+	WHERE id = $1 RETURNING created_by, source_id, canonical_id, company_id, posted_at, created_at, updated_at`
+	var createdBy, canonicalID, companyID uuid.NullUUID
+	var sourceID sql.NullInt64
+	err := j.DB.QueryRowContext(ctx, query, job.ID, job.Title, job.Company, job.Description, job.Country, job.City,
+		job.Remote, job.MinExperience, job.MaxExperience, pq.Array(job.Skills), pq.Array(skillKeys(job.Skills)),
+		job.URL, hash, companyKey).Scan(&createdBy, &sourceID, &canonicalID, &companyID, &job.PostedAt, &job.CreatedAt,
+		&job.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrJobNotFound
+		}
+		j.logger.Errorw("Failed to update the job", "error :", err.Error())
+		return fmt.Errorf("JobRepository.Update failed: %w", err)
+	}
+	job.CreatedBy = nil
+	if createdBy.Valid {
+		job.CreatedBy = &createdBy.UUID
+	}
+	job.SourceID = nil
+	if sourceID.Valid {
+		job.SourceID = &sourceID.Int64
+	}
+	job.CanonicalID = nil
+	if canonicalID.Valid {
+		job.CanonicalID = &canonicalID.UUID
+	}
+	job.CompanyID = nil
+	if companyID.Valid {
+		job.CompanyID = &companyID.UUID
+	}
+	return nil
+}
+
+// Upsert stores a job read from job.SourceID under its id in that source.
+// A job that is already stored is only written when something changed; its
+// posting date and id are kept.
+func (j *JobRepository) Upsert(ctx context.Context, job *models.Job, externalID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `INSERT INTO jobs (title, company, description, country, city, remote, min_experience, max_experience,
+	skills, skill_keys, url, source_id, external_id, fingerprint, company_key, posted_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, COALESCE($16, now()))
+	ON CONFLICT (source_id, external_id) DO UPDATE SET title = EXCLUDED.title, company = EXCLUDED.company,
+	description = EXCLUDED.description, country = EXCLUDED.country, city = EXCLUDED.city, remote = EXCLUDED.remote,
+	min_experience = EXCLUDED.min_experience, max_experience = EXCLUDED.max_experience, skills = EXCLUDED.skills,
+	skill_keys = EXCLUDED.skill_keys, url = EXCLUDED.url, fingerprint = EXCLUDED.fingerprint,
+	company_key = EXCLUDED.company_key, updated_at = now()
+	WHERE jobs.fingerprint IS NULL OR (jobs.title, jobs.company, jobs.description, jobs.country, jobs.city, jobs.remote, jobs.min_experience,
+	jobs.max_experience, jobs.skills, jobs.url) IS DISTINCT FROM (EXCLUDED.title, EXCLUDED.company,
+	EXCLUDED.description, EXCLUDED.country, EXCLUDED.city, EXCLUDED.remote, EXCLUDED.min_experience,
+	EXCLUDED.max_experience, EXCLUDED.skills, EXCLUDED.url)
+	RETURNING id, xmax = 0, canonical_id, posted_at, created_at, updated_at`
+	hash, companyKey := fingerprint(job)
+	var postedAt *time.Time
+	if !job.PostedAt.IsZero() {
+		postedAt = &job.PostedAt
+	}
+	var created bool
+	var canonicalID uuid.NullUUID
+	err := j.DB.QueryRowContext(ctx, query, job.Title, job.Company, job.Description, job.Country, job.City, job.Remote,
+		job.MinExperience, job.MaxExperience, pq.Array(job.Skills), pq.Array(skillKeys(job.Skills)), job.URL,
+		job.SourceID, externalID, hash, companyKey, postedAt).
+		Scan(&job.ID, &created, &canonicalID, &job.PostedAt, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// The conflicting row matched the WHERE clause of neither branch
+			return models.UpsertUnchanged, nil
+		}
+		j.logger.Errorw("Failed to upsert the job", "error :", err.Error())
+		return "", fmt.Errorf("JobRepository.Upsert failed: %w", err)
+	}
+	job.CanonicalID = nil
+	if canonicalID.Valid {
+		job.CanonicalID = &canonicalID.UUID
+	}
+	if created {
+		return models.UpsertCreated, nil
+	}
+	return models.UpsertUpdated, nil
+}
+
+// duplicateCandidates caps the jobs of one company compared with a new
+// posting.
+const duplicateCandidates = 200
+
+// DuplicateCandidates returns the canonical jobs of the same company as job,
+// oldest first, with the fields ingest.IsDuplicate compares.
+func (j *JobRepository) DuplicateCandidates(ctx context.Context, job *models.Job) ([]models.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := j.DB.QueryContext(ctx, `SELECT id, title, city, fingerprint, company_key FROM jobs
+	WHERE company_key = $1 AND company_key <> '' AND canonical_id IS NULL AND id <> $2 AND fingerprint IS NOT NULL
+	ORDER BY created_at, id LIMIT $3`, job.Fingerprint.CompanyKey, job.ID, duplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []models.Job{}
+	for rows.Next() {
+		var candidate models.Job
+		var hash int64
+		if err := rows.Scan(&candidate.ID, &candidate.Title, &candidate.City, &hash, &candidate.Fingerprint.CompanyKey); err != nil {
+			return nil, err
+		}
+		candidate.Fingerprint.SimHash = uint64(hash)
+		candidates = append(candidates, candidate)
+	}
+	return candidates, rows.Err()
+}
+
+// SetCanonical clusters the job under canonicalID, or makes it canonical
+// when canonicalID is nil. Clusters stay one level deep: a job that others
+// are clustered under keeps its place, and a job is only clustered under a
+// canonical one.
+func (j *JobRepository) SetCanonical(ctx context.Context, id uuid.UUID, canonicalID *uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := j.DB.ExecContext(ctx, `UPDATE jobs SET canonical_id = $2 WHERE id = $1
+	AND canonical_id IS DISTINCT FROM $2
+	AND NOT EXISTS (SELECT 1 FROM jobs d WHERE d.canonical_id = $1)
+	AND ($2::uuid IS NULL OR EXISTS (SELECT 1 FROM jobs c WHERE c.id = $2 AND c.canonical_id IS NULL))`, id, canonicalID)
+	if err != nil {
+		return fmt.Errorf("JobRepository.SetCanonical failed: %w", err)
+	}
+	return nil
+}
+
+func (j *JobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := j.DB.ExecContext(ctx, `DELETE FROM jobs WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("JobRepository.Delete failed: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func (j *JobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	job := &models.Job{}
+	err := scanJob(j.DB.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs j WHERE j.id = $1`, id), job)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// jobConditions turns the filter into WHERE conditions on jobs j, appending
+// their arguments to args.
+func jobConditions(filter *models.JobFilter, args []any) ([]string, []any) {
+	conditions := []string{"TRUE"}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+	if filter.Position != "" {
+		add(`lower(j.title) LIKE '%' || lower(?) || '%'`, filter.Position)
+	}
+	if filter.Country != "" {
+		add(`j.country = ?`, filter.Country)
+	}
+	if filter.Remote != "" {
+		add(`j.remote = ?`, filter.Remote)
+	}
+	if years, err := strconv.Atoi(filter.Experience); err == nil {
+		add(`j.min_experience <= ? AND (j.max_experience IS NULL OR j.max_experience >= ?)`, years)
+	}
+	if keys := skillKeys(filter.RequiredSkills); len(keys) > 0 {
+		add(`j.skill_keys @> ?`, pq.Array(keys))
+	}
+	if filter.CompanyID != nil {
+		add(`j.company_id = ?`, *filter.CompanyID)
+	}
+	return conditions, args
+}
+
+var jobPages = keyset{scope: "jobs", columns: []string{"j.posted_at", "j.id"}, desc: true}
+
+// List returns the jobs matching filter, newest first. RequiredSkills must
+// already be catalog names; a job matches when it asks for all of them.
+func (j *JobRepository) List(ctx context.Context, filter *models.JobFilter) ([]models.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	conditions, args := jobConditions(filter, nil)
+	var postedAt time.Time
+	var id uuid.UUID
+	after, args, err := jobPages.after(filter.Paginatin, args, &postedAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := jobPages.page(filter.Paginatin, args)
+	query := `SELECT ` + jobColumns + ` FROM jobs j WHERE ` + strings.Join(append(conditions, after), " AND ") + page
+	rows, err := j.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		var job models.Job
+		if err := scanJob(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(jobPages, filter.Paginatin, jobs, func(job models.Job) []any {
+		return []any{job.PostedAt, job.ID}
+	})
+}
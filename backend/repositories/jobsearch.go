@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	// experienceBand matches models.ExperienceBands.
+	experienceBand = `CASE WHEN j.min_experience < 2 THEN '0-1' WHEN j.min_experience < 5 THEN '2-4'
+	WHEN j.min_experience < 8 THEN '5-7' ELSE '8+' END`
+	// headlineStart and headlineStop mark the matched words in headlines.
+	// They are private use characters, taken out of the description first,
+	// so the headline can be escaped before they become tags.
+	headlineStart = "\uE000"
+	headlineStop  = "\uE001"
+	jobHeadline   = `CASE WHEN $1 = '' THEN '' ELSE ts_headline('english',
+	translate(j.description, '` + headlineStart + headlineStop + `', ''), websearch_to_tsquery('english', $1),
+	'MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … ", StartSel="` + headlineStart + `", StopSel="` + headlineStop + `"') END`
+	// facetLimit caps the values returned for countries and skills.
+	facetLimit = 10
+)
+
+var headlineTags = strings.NewReplacer(headlineStart, "<b>", headlineStop, "</b>")
+
+// safeHeadline escapes the description fragments of a headline and wraps the
+// matched words in <b> tags.
+func safeHeadline(headline string) string {
+	return headlineTags.Replace(html.EscapeString(headline))
+}
+
+// jobScore mixes text relevance, normalised to [0, 1), with recency
+// halving about every three weeks before the time in asOf. $1 is the search
+// text. Pages of a search share asOf so scores do not drift between them.
+func jobScore(asOf string) string {
+	return `0.8 * CASE WHEN $1 = '' THEN 0 ELSE ts_rank_cd(j.search_vector, websearch_to_tsquery('english', $1), 32) END
+	+ 0.2 * exp(-extract(epoch FROM ` + asOf + `::timestamptz - j.posted_at) / 86400 / 30)`
+}
+
+// collapsedJobs selects one job per cluster of duplicates among those
+// matching where: the canonical job when it matches, else the best scoring
+// duplicate. The result is aliased j like the jobs table, with the score
+// and cluster_id, the id of the canonical job, added.
+func collapsedJobs(where string, asOf string) string {
+	return `(SELECT DISTINCT ON (COALESCE(j.canonical_id, j.id)) j.*, COALESCE(j.canonical_id, j.id) AS cluster_id,
+	` + jobScore(asOf) + ` AS score FROM jobs j WHERE ` + where + `
+	ORDER BY COALESCE(j.canonical_id, j.id), j.canonical_id IS NULL DESC, score DESC) j`
+}
+
+// searchConditions adds the search text and experience band to the listing
+// conditions. The text is always $1 so jobScore can refer to it.
+func searchConditions(query *models.JobSearchQuery) ([]string, []any) {
+	conditions, args := jobConditions(&query.Filter, []any{query.Query})
+	// $1 is referenced even without text, as Postgres rejects unused parameters
+	conditions = append(conditions, `($1 = '' OR j.search_vector @@ websearch_to_tsquery('english', $1))`)
+	if query.Band != "" {
+		args = append(args, query.Band)
+		conditions = append(conditions, fmt.Sprintf(`(%s) = $%d`, experienceBand, len(args)))
+	}
+	return conditions, args
+}
+
+var searchPages = keyset{scope: "search", columns: []string{"j.score", "j.posted_at", "j.id"}, desc: true}
+
+// Search returns a page of the jobs matching query, best first, with the
+// total and facet counts over all matches. Duplicates count once and are
+// listed with the job they duplicate.
+func (j *JobRepository) Search(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	pagination := query.Filter.Paginatin
+	asOf := time.Now()
+	var score float64
+	var postedAt time.Time
+	var id uuid.UUID
+	resumed, err := searchPages.decode(pagination, &asOf, &score, &postedAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	conditions, args := searchConditions(query)
+	where := strings.Join(conditions, " AND ")
+	args = append(args, asOf)
+	collapsed := collapsedJobs(where, fmt.Sprintf("$%d", len(args)))
+	result := &models.JobSearchResult{
+		Hits:   []models.JobHit{},
+		Limit:  pagination.Limit,
+		Offset: pagination.Offset,
+		Facets: models.JobFacets{
+			Country:    []models.FacetCount{},
+			Remote:     []models.FacetCount{},
+			Experience: []models.FacetCount{},
+			Skills:     []models.FacetCount{},
+		},
+	}
+
+	after, pageArgs := "TRUE", append([]any{}, args...)
+	if resumed {
+		after, pageArgs = searchPages.condition(pageArgs, score, postedAt, id)
+		result.Offset = 0
+	}
+	page, pageArgs := searchPages.page(pagination, pageArgs)
+	rows, err := j.DB.QueryContext(ctx, `SELECT `+jobColumns+`, j.score, `+jobHeadline+`, j.cluster_id
+	FROM `+collapsed+` WHERE `+after+page, pageArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	clusters := []string{}
+	for rows.Next() {
+		var hit models.JobHit
+		var cluster string
+		if err := scanJob(rows, &hit.Job, &hit.Score, &hit.Headline, &cluster); err != nil {
+			return nil, err
+		}
+		hit.Headline = safeHeadline(hit.Headline)
+		hit.AlsoPostedAt = []models.DuplicatePosting{}
+		result.Hits = append(result.Hits, hit)
+		clusters = append(clusters, cluster)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(result.Hits) > pagination.Limit {
+		clusters = clusters[:pagination.Limit]
+	}
+	result.Hits, err = nextPage(searchPages, pagination, result.Hits, func(hit models.JobHit) []any {
+		return []any{asOf, hit.Score, hit.Job.PostedAt, hit.Job.ID}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := j.alsoPostedAt(ctx, result.Hits, clusters); err != nil {
+		return nil, err
+	}
+
+	facetRows, err := j.DB.QueryContext(ctx, `WITH matched AS (
+		SELECT j.country, j.remote, j.skills, `+experienceBand+` AS band FROM `+collapsed+`
+	)
+	SELECT 'total', '', count(*) FROM matched
+	UNION ALL (SELECT 'country', country::text, count(*) FROM matched WHERE country <> ''
+		GROUP BY country ORDER BY count(*) DESC, country LIMIT `+fmt.Sprint(facetLimit)+`)
+	UNION ALL (SELECT 'remote', remote, count(*) FROM matched GROUP BY remote ORDER BY count(*) DESC, remote)
+	UNION ALL (SELECT 'experience', band, count(*) FROM matched GROUP BY band ORDER BY band)
+	UNION ALL (SELECT 'skill', skill, count(*) FROM matched, unnest(matched.skills) AS skill
+		GROUP BY skill ORDER BY count(*) DESC, skill LIMIT `+fmt.Sprint(facetLimit)+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer facetRows.Close()
+	for facetRows.Next() {
+		var facet string
+		var count models.FacetCount
+		if err := facetRows.Scan(&facet, &count.Value, &count.Count); err != nil {
+			return nil, err
+		}
+		switch facet {
+		case "total":
+			result.Total = count.Count
+		case "country":
+			result.Facets.Country = append(result.Facets.Country, count)
+		case "remote":
+			result.Facets.Remote = append(result.Facets.Remote, count)
+		case "experience":
+			result.Facets.Experience = append(result.Facets.Experience, count)
+		case "skill":
+			result.Facets.Skills = append(result.Facets.Skills, count)
+		}
+	}
+	return result, facetRows.Err()
+}
+
+// alsoPostedAt fills in the other postings in the cluster of each hit;
+// clusters holds the cluster id of each hit.
+func (j *JobRepository) alsoPostedAt(ctx context.Context, hits []models.JobHit, clusters []string) error {
+	if len(hits) == 0 {
+		return nil
+	}
+	rows, err := j.DB.QueryContext(ctx, `SELECT COALESCE(d.canonical_id, d.id), d.id, COALESCE(s.name, ''), d.company, d.url
+	FROM jobs d LEFT JOIN job_sources s ON s.id = d.source_id
+	WHERE d.canonical_id = ANY($1) OR d.id = ANY($1) ORDER BY d.created_at, d.id`, pq.Array(clusters))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	postings := map[string][]models.DuplicatePosting{}
+	for rows.Next() {
+		var cluster string
+		var posting models.DuplicatePosting
+		if err := rows.Scan(&cluster, &posting.JobID, &posting.Source, &posting.Company, &posting.URL); err != nil {
+			return err
+		}
+		postings[cluster] = append(postings[cluster], posting)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for i := range hits {
+		for _, posting := range postings[clusters[i]] {
+			if posting.JobID != hits[i].ID {
+				hits[i].AlsoPostedAt = append(hits[i].AlsoPostedAt, posting)
+			}
+		}
+	}
+	return nil
+}
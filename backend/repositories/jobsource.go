@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrJobSourceNotFound  = errors.New("job source not found")
+	ErrDuplicateJobSource = errors.New("job source name already used")
+)
+
+type JobSourceRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+const jobSourceColumns = `s.id, s.name, s.kind, s.url, s.items_path, s.fields, s.company, s.interval_minutes, s.enabled,
+	s.next_run_at, s.last_run_at, s.created_by, s.created_at, s.updated_at`
+
+func scanJobSource(row scanner, source *models.JobSource) error {
+	var fields []byte
+	var createdBy uuid.NullUUID
+	err := row.Scan(&source.ID, &source.Name, &source.Kind, &source.URL, &source.ItemsPath, &fields, &source.Company,
+		&source.IntervalMinutes, &source.Enabled, &source.NextRunAt, &source.LastRunAt, &createdBy, &source.CreatedAt,
+		&source.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	source.Fields = map[string]string{}
+	if err := json.Unmarshal(fields, &source.Fields); err != nil {
+		return err
+	}
+	source.CreatedBy = nil
+	if createdBy.Valid {
+		source.CreatedBy = &createdBy.UUID
+	}
+	return nil
+}
+
+func jobSourceError(err error) error {
+	if strings.Contains(err.Error(), `"job_sources_name_key"`) {
+		return ErrDuplicateJobSource
+	}
+	return err
+}
+
+func (j *JobSourceRepository) Create(ctx context.Context, source *models.JobSource) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	fields, err := json.Marshal(source.Fields)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO job_sources (name, kind, url, items_path, fields, company, interval_minutes, enabled, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, next_run_at, created_at, updated_at`
+	err = j.DB.QueryRowContext(ctx, query, source.Name, source.Kind, source.URL, source.ItemsPath, fields,
+		source.Company, source.IntervalMinutes, source.Enabled, source.CreatedBy).
+		Scan(&source.ID, &source.NextRunAt, &source.CreatedAt, &source.UpdatedAt)
+	if err != nil {
+		j.logger.Errorw("Failed to insert the job source", "error :", err.Error())
+		return jobSourceError(err)
+	}
+	return nil
+}
+
+func (j *JobSourceRepository) Update(ctx context.Context, source *models.JobSource) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	fields, err := json.Marshal(source.Fields)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE job_sources SET name = $2, kind = $3, url = $4, items_path = $5, fields = $6, company = $7,
+	interval_minutes = $8, enabled = $9, updated_at = now() WHERE id = $1
+	RETURNING next_run_at, last_run_at, created_by, created_at, updated_at`
+	var createdBy uuid.NullUUID
+	err = j.DB.QueryRowContext(ctx, query, source.ID, source.Name, source.Kind, source.URL, source.ItemsPath, fields,
+		source.Company, source.IntervalMinutes, source.Enabled).
+		Scan(&source.NextRunAt, &source.LastRunAt, &createdBy, &source.CreatedAt, &source.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrJobSourceNotFound
+		}
+		j.logger.Errorw("Failed to update the job source", "error :", err.Error())
+		return jobSourceError(err)
+	}
+	source.CreatedBy = nil
+	if createdBy.Valid {
+		source.CreatedBy = &createdBy.UUID
+	}
+	return nil
+}
+
+// Delete removes the source and its runs. Jobs it imported are kept and
+// become unmanaged.
+func (j *JobSourceRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := j.DB.ExecContext(ctx, `DELETE FROM job_sources WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("JobSourceRepository.Delete failed: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrJobSourceNotFound
+	}
+	return nil
+}
+
+func (j *JobSourceRepository) GetByID(ctx context.Context, id int64) (*models.JobSource, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	source := &models.JobSource{}
+	err := scanJobSource(j.DB.QueryRowContext(ctx, `SELECT `+jobSourceColumns+` FROM job_sources s WHERE s.id = $1`, id), source)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrJobSourceNotFound
+		}
+		return nil, err
+	}
+	return source, nil
+}
+
+var jobSourcePages = keyset{scope: "job_sources", columns: []string{"s.name", "s.id"}}
+
+// List returns a page of the sources by name.
+func (j *JobSourceRepository) List(ctx context.Context, pagination *models.PaginatedQuery) ([]models.JobSource, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var name string
+	var id int64
+	after, args, err := jobSourcePages.after(pagination, nil, &name, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := jobSourcePages.page(pagination, args)
+	rows, err := j.DB.QueryContext(ctx, `SELECT `+jobSourceColumns+` FROM job_sources s WHERE `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := collectJobSources(rows)
+	if err != nil {
+		return nil, err
+	}
+	return nextPage(jobSourcePages, pagination, sources, func(source models.JobSource) []any {
+		return []any{source.Name, source.ID}
+	})
+}
+
+// ClaimDue returns the enabled sources whose run is due and moves their next
+// run one interval ahead. Sources claimed by another instance are skipped,
+// so each run happens once however many backends are running.
+func (j *JobSourceRepository) ClaimDue(ctx context.Context) ([]models.JobSource, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `UPDATE job_sources s SET next_run_at = now() + s.interval_minutes * interval '1 minute'
+	WHERE s.id IN (SELECT id FROM job_sources WHERE enabled AND next_run_at <= now()
+	ORDER BY next_run_at FOR UPDATE SKIP LOCKED)
+	RETURNING ` + jobSourceColumns
+	rows, err := j.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return collectJobSources(rows)
+}
+
+func collectJobSources(rows *sql.Rows) ([]models.JobSource, error) {
+	defer rows.Close()
+	sources := []models.JobSource{}
+	for rows.Next() {
+		var source models.JobSource
+		if err := scanJobSource(rows, &source); err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, rows.Err()
+}
+
+const importRunColumns = `id, source_id, status, fetched, created, updated, unchanged, failed, errors, started_at, finished_at`
+
+func scanImportRun(row scanner, run *models.JobImportRun) error {
+	return row.Scan(&run.ID, &run.SourceID, &run.Status, &run.Fetched, &run.Created, &run.Updated, &run.Unchanged,
+		&run.Failed, pq.Array(&run.Errors), &run.StartedAt, &run.FinishedAt)
+}
+
+func (j *JobSourceRepository) StartRun(ctx context.Context, sourceID int64) (*models.JobImportRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	run := &models.JobImportRun{}
+	err := scanImportRun(j.DB.QueryRowContext(ctx, `INSERT INTO job_import_runs (source_id) VALUES ($1)
+	RETURNING `+importRunColumns, sourceID), run)
+	if err != nil {
+		if strings.Contains(err.Error(), `"job_import_runs_source_id_fkey"`) {
+			return nil, ErrJobSourceNotFound
+		}
+		return nil, fmt.Errorf("JobSourceRepository.StartRun failed: %w", err)
+	}
+	return run, nil
+}
+
+// FinishRun stores the outcome of the run and marks its source as read.
+func (j *JobSourceRepository) FinishRun(ctx context.Context, run *models.JobImportRun) error {
+	return WithTx(j.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		query := `UPDATE job_import_runs SET status = $2, fetched = $3, created = $4, updated = $5, unchanged = $6,
+		failed = $7, errors = $8, finished_at = now() WHERE id = $1 RETURNING finished_at`
+		err := tx.QueryRowContext(ctx, query, run.ID, run.Status, run.Fetched, run.Created, run.Updated, run.Unchanged,
+			run.Failed, pq.Array(run.Errors)).Scan(&run.FinishedAt)
+		if err != nil {
+			return fmt.Errorf("JobSourceRepository.FinishRun failed: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE job_sources SET last_run_at = $2 WHERE id = $1`, run.SourceID, run.FinishedAt)
+		return err
+	})
+}
+
+var importRunPages = keyset{scope: "import_runs", columns: []string{"started_at", "id"}, desc: true}
+
+func (j *JobSourceRepository) ListRuns(ctx context.Context, sourceID int64, pagination *models.PaginatedQuery) ([]models.JobImportRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var startedAt time.Time
+	var id int64
+	after, args, err := importRunPages.after(pagination, []any{sourceID}, &startedAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := importRunPages.page(pagination, args)
+	rows, err := j.DB.QueryContext(ctx, `SELECT `+importRunColumns+` FROM job_import_runs WHERE source_id = $1
+	AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []models.JobImportRun{}
+	for rows.Next() {
+		var run models.JobImportRun
+		if err := scanImportRun(rows, &run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(importRunPages, pagination, runs, func(run models.JobImportRun) []any {
+		return []any{run.StartedAt, run.ID}
+	})
+}
@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var ErrLinkNotFound = errors.New("resume link not found")
+
+// recentViews is how many views ResumeLinkRepository.Stats returns.
+const recentViews = 50
+
+type ResumeLinkRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+const linkColumns = `l.id, l.resume_id, l.user_id, l.redaction, l.password, l.expires_at, l.revoked_at,
+	l.view_count, l.last_viewed_at, l.created_at`
+
+func scanLink(row scanner, link *models.ResumeLink, extra ...any) error {
+	dest := []any{&link.ID, &link.ResumeID, &link.UserID, &link.Redaction, &link.Password.Hash, &link.ExpiresAt,
+		&link.RevokedAt, &link.ViewCount, &link.LastViewedAt, &link.CreatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	link.HasPassword = link.Password.Hash != nil
+	return nil
+}
+
+// Create stores the link under the hash of link.Token.
+func (l *ResumeLinkRepository) Create(ctx context.Context, link *models.ResumeLink) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `INSERT INTO resume_links (resume_id, user_id, token, redaction, password, expires_at)
+	SELECT id, user_id, $3, $4, $5, $6 FROM resumes WHERE id = $1 AND user_id = $2
+	RETURNING id, created_at`
+	err := l.DB.QueryRowContext(ctx, query, link.ResumeID, link.UserID, hashToken(link.Token), link.Redaction,
+		link.Password.Hash, link.ExpiresAt).Scan(&link.ID, &link.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrResumeNotFound
+		}
+		l.logger.Errorw("Failed to insert the resume link", "error :", err.Error())
+		return fmt.Errorf("ResumeLinkRepository.Create failed: %w", err)
+	}
+	link.HasPassword = link.Password.Hash != nil
+	return nil
+}
+
+var linkPages = keyset{scope: "resume_links", columns: []string{"l.created_at", "l.id"}, desc: true}
+
+// ListByResume returns a page of the links of the resume, newest first.
+func (l *ResumeLinkRepository) ListByResume(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeLink, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := linkPages.after(pagination, []any{resumeID, userID}, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := linkPages.page(pagination, args)
+	rows, err := l.DB.QueryContext(ctx, `SELECT `+linkColumns+` FROM resume_links l
+	WHERE l.resume_id = $1 AND l.user_id = $2 AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []models.ResumeLink{}
+	for rows.Next() {
+		var link models.ResumeLink
+		if err := scanLink(rows, &link); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(linkPages, pagination, links, func(link models.ResumeLink) []any {
+		return []any{link.CreatedAt, link.ID}
+	})
+}
+
+func (l *ResumeLinkRepository) Revoke(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := l.DB.ExecContext(ctx, `UPDATE resume_links SET revoked_at = now()
+	WHERE id = $1 AND resume_id = $2 AND user_id = $3 AND revoked_at IS NULL`, id, resumeID, userID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
+
+// GetByToken returns the resume behind an active link. Revoked and expired
+// links are reported as not found.
+func (l *ResumeLinkRepository) GetByToken(ctx context.Context, token string) (*models.LinkedResume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	linked := &models.LinkedResume{}
+	query := `SELECT ` + linkColumns + `, u.username, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), u.email,
+	r.version, r.file_name, r.job_titles, r.skills, r.experience, r.extracted_text
+	FROM resume_links l JOIN resumes r ON r.id = l.resume_id JOIN users u ON u.id = l.user_id
+	WHERE l.token = $1 AND l.revoked_at IS NULL AND (l.expires_at IS NULL OR l.expires_at > now())`
+	err := scanLink(l.DB.QueryRowContext(ctx, query, hashToken(token)), &linked.Link,
+		&linked.Owner.Username, &linked.Owner.FirstName, &linked.Owner.LastName, &linked.Owner.Email,
+		&linked.Version, &linked.FileName, pq.Array(&linked.Profile.JobTitles), pq.Array(&linked.Profile.Skills),
+		&linked.Profile.Experience, &linked.ExtractedText)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrLinkNotFound
+		}
+		return nil, err
+	}
+	linked.Owner.ID = linked.Link.UserID
+	return linked, nil
+}
+
+func (l *ResumeLinkRepository) RecordView(ctx context.Context, id uuid.UUID, referrer string) error {
+	return WithTx(l.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO resume_link_views (link_id, referrer) VALUES ($1, $2)`, id, referrer); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE resume_links SET view_count = view_count + 1, last_viewed_at = now() WHERE id = $1`, id)
+		return err
+	})
+}
+
+// Stats returns the view count of the link with its referrers and latest
+// views.
+func (l *ResumeLinkRepository) Stats(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) (*models.ResumeLinkStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	stats := &models.ResumeLinkStats{LinkID: id, Referrers: map[string]int{}, Recent: []models.ResumeLinkView{}}
+	err := l.DB.QueryRowContext(ctx, `SELECT view_count FROM resume_links WHERE id = $1 AND resume_id = $2 AND user_id = $3`,
+		id, resumeID, userID).Scan(&stats.ViewCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrLinkNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := l.DB.QueryContext(ctx, `SELECT referrer, count(*) FROM resume_link_views WHERE link_id = $1 GROUP BY referrer`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var referrer string
+		var count int
+		if err := rows.Scan(&referrer, &count); err != nil {
+			return nil, err
+		}
+		stats.Referrers[referrer] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	recent, err := l.DB.QueryContext(ctx, `SELECT referrer, viewed_at FROM resume_link_views WHERE link_id = $1
+	ORDER BY viewed_at DESC LIMIT $2`, id, recentViews)
+	if err != nil {
+		return nil, err
+	}
+	defer recent.Close()
+	for recent.Next() {
+		var view models.ResumeLinkView
+		if err := recent.Scan(&view.Referrer, &view.ViewedAt); err != nil {
+			return nil, err
+		}
+		stats.Recent = append(stats.Recent, view)
+	}
+	return stats, recent.Err()
+}
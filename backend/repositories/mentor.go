@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
@@ -22,8 +23,8 @@ type MentorRepository struct {
 
 func (u *MentorRepository) FindByEmail(ctx context.Context, email string) (*models.Mentor, error) {
 	mentor := &models.Mentor{}
-	query := `SELECT id, username, first_name, last_name, email, password , is_active, is_verified , experience_years, bio FROM mentor WHERE email = $1`
-	err := u.DB.QueryRowContext(ctx, query, email).Scan(&mentor.ID, &mentor.Username, &mentor.FirstName, &mentor.LastName, &mentor.Email, &mentor.Password.Hash, &mentor.IsActive, &mentor.IsVerified, &mentor.ExperienceYears)
+	query := `SELECT id, username, first_name, last_name, email, password , is_active, is_verified , COALESCE(experience_years, 0), COALESCE(bio, '') FROM mentor WHERE email = $1`
+	err := u.DB.QueryRowContext(ctx, query, email).Scan(&mentor.ID, &mentor.Username, &mentor.FirstName, &mentor.LastName, &mentor.Email, &mentor.Password.Hash, &mentor.IsActive, &mentor.IsVerified, &mentor.ExperienceYears, &mentor.Bio)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			u.logger.Warnw("user does not exist with this email", "error :", err.Error())
@@ -36,9 +37,9 @@ func (u *MentorRepository) FindByEmail(ctx context.Context, email string) (*mode
 }
 
 func (u *MentorRepository) FindByUsername(ctx context.Context, username string) (*models.Mentor, error) {
-	row := u.DB.QueryRowContext(ctx, "SELECT  id, username, first_name, last_name, email, password , is_active, is_verified , experience_years, bio FROM mentor WHERE username = $1", username)
+	row := u.DB.QueryRowContext(ctx, "SELECT  id, username, first_name, last_name, email, password , is_active, is_verified , COALESCE(experience_years, 0), COALESCE(bio, '') FROM mentor WHERE username = $1", username)
 	mentor := &models.Mentor{}
-	err := row.Scan(&mentor.ID, &mentor.Username, &mentor.FirstName, &mentor.LastName, &mentor.Email, &mentor.Password.Hash, &mentor.IsActive, &mentor.IsVerified, &mentor.ExperienceYears)
+	err := row.Scan(&mentor.ID, &mentor.Username, &mentor.FirstName, &mentor.LastName, &mentor.Email, &mentor.Password.Hash, &mentor.IsActive, &mentor.IsVerified, &mentor.ExperienceYears, &mentor.Bio)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			u.logger.Warnw("user does not exist with this username", "error :", err.Error())
@@ -49,6 +50,24 @@ func (u *MentorRepository) FindByUsername(ctx context.Context, username string)
 	return mentor, nil
 }
 
+// FindActiveByUsername finds a mentor that users can reach, one whose account
+// is activated and verified. Other mentors are reported as not found.
+func (u *MentorRepository) FindActiveByUsername(ctx context.Context, username string) (*models.Mentor, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+	row := u.DB.QueryRowContext(ctx, `SELECT id, username, first_name, last_name, email, is_active, is_verified FROM mentor
+		WHERE username = $1 AND is_active AND is_verified`, username)
+	mentor := &models.Mentor{}
+	err := row.Scan(&mentor.ID, &mentor.Username, &mentor.FirstName, &mentor.LastName, &mentor.Email, &mentor.IsActive, &mentor.IsVerified)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("MentorRepository.FindActiveByUsername failed: %w", err)
+	}
+	return mentor, nil
+}
+
 func (u *MentorRepository) create(tx *sql.Tx, ctx context.Context, mentor *models.Mentor) error {
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
 	defer cancel()
@@ -178,9 +197,14 @@ func (u *MentorRepository) deleteInvitation(tx *sql.Tx, ctx context.Context, use
 }
 
 func (u *MentorRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Mentor, error) {
-	row := u.DB.QueryRowContext(ctx, "SELECT id, username, first_name, last_name, is_active , is_verified, email, role_id FROM user WHERE id = $1", id)
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	row := u.DB.QueryRowContext(ctx, `SELECT id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), is_active, is_verified, email,
+	COALESCE(experience_years, 0), COALESCE(bio, ''), created_at, updated_at FROM mentor WHERE id = $1`, id)
 	user := &models.Mentor{}
-	err := row.Scan(&user.ID, &user.Username, &user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified, &user.Password, &user.Email, &user.RoleID)
+	err := row.Scan(&user.ID, &user.Username, &user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified, &user.Email,
+		&user.ExperienceYears, &user.Bio, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrUserNotFound
@@ -189,3 +213,77 @@ func (u *MentorRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.M
 	}
 	return user, nil
 }
+
+// SetSkills replaces the catalog skills the mentor can help with.
+func (u *MentorRepository) SetSkills(ctx context.Context, mentorID uuid.UUID, skillIDs []int64) error {
+	return WithTx(u.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM mentor_skills WHERE mentor_id = $1`, mentorID); err != nil {
+			return fmt.Errorf("MentorRepository.SetSkills failed: %w", err)
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO mentor_skills (mentor_id, skill_id)
+		SELECT $1, unnest($2::bigint[]) ON CONFLICT DO NOTHING`, mentorID, pq.Array(skillIDs))
+		if err != nil {
+			return fmt.Errorf("MentorRepository.SetSkills failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// Skills returns the names of the skills the mentor can help with.
+func (u *MentorRepository) Skills(ctx context.Context, mentorID uuid.UUID) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := u.DB.QueryContext(ctx, `SELECT s.name FROM mentor_skills ms JOIN skills s ON s.id = ms.skill_id
+	WHERE ms.mentor_id = $1 ORDER BY s.name`, mentorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		skills = append(skills, name)
+	}
+	return skills, rows.Err()
+}
+
+// BySkills returns up to perSkill active, verified mentors for each of the skills,
+// the most experienced first.
+func (u *MentorRepository) BySkills(ctx context.Context, skillIDs []int64, perSkill int) (map[int64][]models.MentorProfile, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := u.DB.QueryContext(ctx, `SELECT skill_id, id, username, first_name, last_name, experience_years, bio
+	FROM (SELECT ms.skill_id, m.id, m.username, COALESCE(m.first_name, '') AS first_name,
+		COALESCE(m.last_name, '') AS last_name, COALESCE(m.experience_years, 0) AS experience_years,
+		COALESCE(m.bio, '') AS bio,
+		row_number() OVER (PARTITION BY ms.skill_id ORDER BY m.experience_years DESC NULLS LAST, m.username) AS n
+		FROM mentor_skills ms JOIN mentor m ON m.id = ms.mentor_id
+		WHERE ms.skill_id = ANY($1) AND m.is_active AND m.is_verified) ranked
+	WHERE n <= $2 ORDER BY skill_id, n`, pq.Array(skillIDs), perSkill)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mentors := map[int64][]models.MentorProfile{}
+	for rows.Next() {
+		var skillID int64
+		var mentor models.MentorProfile
+		err := rows.Scan(&skillID, &mentor.ID, &mentor.Username, &mentor.FirstName, &mentor.LastName,
+			&mentor.ExperienceYears, &mentor.Bio)
+		if err != nil {
+			return nil, err
+		}
+		mentors[skillID] = append(mentors[skillID], mentor)
+	}
+	return mentors, rows.Err()
+}
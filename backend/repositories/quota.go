@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrQuotaExceeded = errors.New("parse quota exceeded")
+
+type QuotaRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+// quotaPeriods returns the start of the current UTC day and month.
+func quotaPeriods(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return day, month
+}
+
+// quotaQuery reads the limits of the user's role and the parses made since
+// the start of the day and month.
+const quotaQuery = `SELECT r.name, pq.daily_limit, pq.monthly_limit,
+	(SELECT count(*) FROM parse_usage WHERE user_id = u.id AND created_at >= $2),
+	(SELECT count(*) FROM parse_usage WHERE user_id = u.id AND created_at >= $3)
+	FROM users u JOIN role r ON r.id = u.role_id
+	LEFT JOIN parse_quotas pq ON pq.role_id = u.role_id
+	WHERE u.id = $1`
+
+func newParseQuota() (*models.ParseQuota, time.Time, time.Time) {
+	day, month := quotaPeriods(time.Now())
+	quota := &models.ParseQuota{}
+	quota.Daily.ResetsAt = day.AddDate(0, 0, 1)
+	quota.Monthly.ResetsAt = month.AddDate(0, 1, 0)
+	return quota, day, month
+}
+
+func scanQuota(row scanner, quota *models.ParseQuota) error {
+	var daily, monthly sql.NullInt64
+	if err := row.Scan(&quota.Role, &daily, &monthly, &quota.Daily.Used, &quota.Monthly.Used); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if daily.Valid {
+		limit := int(daily.Int64)
+		quota.Daily.Limit = &limit
+	}
+	if monthly.Valid {
+		limit := int(monthly.Int64)
+		quota.Monthly.Limit = &limit
+	}
+	return nil
+}
+
+// Usage returns the parse quota of the user.
+func (q *QuotaRepository) Usage(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	quota, day, month := newParseQuota()
+	if err := scanQuota(q.DB.QueryRowContext(ctx, quotaQuery, userID, day, month), quota); err != nil {
+		return nil, err
+	}
+	quota.SetRemaining()
+	return quota, nil
+}
+
+// Reserve records a parse for the user when the quota allows it and returns
+// the id of the usage row, so it can be released if the parse fails. When the
+// quota is used up it returns ErrQuotaExceeded along with the quota.
+func (q *QuotaRepository) Reserve(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	quota, day, month := newParseQuota()
+	var id int64
+	err := WithTx(q.DB, ctx, func(tx *sql.Tx) error {
+		// Locking the user row makes concurrent reservations of the same
+		// user count one after the other
+		row := tx.QueryRowContext(ctx, quotaQuery+` FOR UPDATE OF u`, userID, day, month)
+		if err := scanQuota(row, quota); err != nil {
+			return err
+		}
+		if quota.Daily.Exceeded() || quota.Monthly.Exceeded() {
+			return ErrQuotaExceeded
+		}
+		if err := tx.QueryRowContext(ctx, `INSERT INTO parse_usage (user_id) VALUES ($1) RETURNING id`, userID).Scan(&id); err != nil {
+			return err
+		}
+		quota.Daily.Used++
+		quota.Monthly.Used++
+		return nil
+	})
+	quota.SetRemaining()
+	if err != nil {
+		if errors.Is(err, ErrQuotaExceeded) || errors.Is(err, ErrUserNotFound) {
+			return quota, 0, err
+		}
+		q.logger.Errorw("Failed to reserve a parse", "error :", err.Error())
+		return nil, 0, fmt.Errorf("QuotaRepository.Reserve failed: %w", err)
+	}
+	return quota, id, nil
+}
+
+// Release gives back a parse recorded by Reserve.
+func (q *QuotaRepository) Release(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := q.DB.ExecContext(ctx, `DELETE FROM parse_usage WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,362 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRecommendationsNotComputed = errors.New("recommendations not computed")
+	ErrFeedbackNotFound           = errors.New("recommendation feedback not found")
+)
+
+type RecommendationRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+func (r *RecommendationRepository) State(ctx context.Context, userID uuid.UUID) (*models.RecommendationState, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	state := &models.RecommendationState{}
+	err := r.DB.QueryRowContext(ctx, `SELECT user_id, resume_id, resume_updated_at, refreshed_at
+	FROM recommendation_states WHERE user_id = $1`, userID).
+		Scan(&state.UserID, &state.ResumeID, &state.ResumeUpdatedAt, &state.RefreshedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRecommendationsNotComputed
+		}
+		return nil, err
+	}
+	return state, nil
+}
+
+// Touch records that the user asked for recommendations, which keeps them
+// being refreshed in the background.
+func (r *RecommendationRepository) Touch(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := r.DB.ExecContext(ctx, `UPDATE recommendation_states SET requested_at = now() WHERE user_id = $1`, userID)
+	return err
+}
+
+// Candidates returns up to limit canonical jobs sharing a skill with skills,
+// which must be catalog names, and asking for experience within slack years
+// of experience. Jobs sharing the most skills come first.
+func (r *RecommendationRepository) Candidates(ctx context.Context, skills []string, experience int, slack int, limit int) ([]models.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `SELECT ` + jobColumns + ` FROM jobs j
+	WHERE j.canonical_id IS NULL AND j.skill_keys && $1
+	AND j.min_experience <= $2 + $3 AND (j.max_experience IS NULL OR j.max_experience >= $2 - $3)
+	ORDER BY cardinality(ARRAY(SELECT unnest(j.skill_keys) INTERSECT SELECT unnest($1::text[]))) DESC,
+	j.posted_at DESC, j.id LIMIT $4`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(skillKeys(skills)), experience, slack, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		var job models.Job
+		if err := scanJob(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// Scores returns the cached recommendations of the user by job, without the
+// jobs themselves.
+func (r *RecommendationRepository) Scores(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]models.JobRecommendation, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT job_id, score, relevancy, matched_skills, missing_skills, job_updated_at
+	FROM job_recommendations WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := map[uuid.UUID]models.JobRecommendation{}
+	for rows.Next() {
+		var recommendation models.JobRecommendation
+		if err := scanRecommendation(rows, &recommendation, false); err != nil {
+			return nil, err
+		}
+		scores[recommendation.Job.ID] = recommendation
+	}
+	return scores, rows.Err()
+}
+
+// scanRecommendation reads the score columns, after the job columns and
+// followed by the user's feedback when withJob is set, after the job id
+// otherwise.
+func scanRecommendation(row scanner, recommendation *models.JobRecommendation, withJob bool) error {
+	var relevancy sql.NullFloat64
+	dest := []any{&recommendation.Score, &relevancy, pq.Array(&recommendation.MatchedSkills),
+		pq.Array(&recommendation.MissingSkills), &recommendation.JobUpdatedAt}
+	var err error
+	if withJob {
+		err = scanJob(row, &recommendation.Job, append(dest, &recommendation.Feedback)...)
+	} else {
+		err = row.Scan(append([]any{&recommendation.Job.ID}, dest...)...)
+	}
+	if err != nil {
+		return err
+	}
+	recommendation.Relevancy = nil
+	if relevancy.Valid {
+		recommendation.Relevancy = &relevancy.Float64
+	}
+	if recommendation.MatchedSkills == nil {
+		recommendation.MatchedSkills = []string{}
+	}
+	if recommendation.MissingSkills == nil {
+		recommendation.MissingSkills = []string{}
+	}
+	return nil
+}
+
+// Replace swaps the cached recommendations of state.UserID for
+// recommendations and saves state.
+func (r *RecommendationRepository) Replace(ctx context.Context, state *models.RecommendationState, recommendations []models.JobRecommendation) error {
+	return WithTx(r.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM job_recommendations WHERE user_id = $1`, state.UserID); err != nil {
+			return fmt.Errorf("RecommendationRepository.Replace failed: %w", err)
+		}
+		insert, err := tx.PrepareContext(ctx, `INSERT INTO job_recommendations
+		(user_id, job_id, matched_skills, missing_skills, relevancy, score, job_updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`)
+		if err != nil {
+			return err
+		}
+		defer insert.Close()
+		for _, recommendation := range recommendations {
+			_, err := insert.ExecContext(ctx, state.UserID, recommendation.Job.ID, pq.Array(recommendation.MatchedSkills),
+				pq.Array(recommendation.MissingSkills), recommendation.Relevancy, recommendation.Score,
+				recommendation.JobUpdatedAt)
+			if err != nil {
+				return fmt.Errorf("RecommendationRepository.Replace failed: %w", err)
+			}
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO recommendation_states (user_id, resume_id, resume_updated_at, refreshed_at)
+		VALUES ($1, $2, $3, $4) ON CONFLICT (user_id) DO UPDATE
+		SET resume_id = EXCLUDED.resume_id, resume_updated_at = EXCLUDED.resume_updated_at,
+		refreshed_at = EXCLUDED.refreshed_at`, state.UserID, state.ResumeID, state.ResumeUpdatedAt, state.RefreshedAt)
+		return err
+	})
+}
+
+// feedbackAdjustment is added to the score of a recommended job j for the
+// feedback of the user. Each piece of feedback pulls up (thumbs up) or down
+// the jobs of the same company, with similar titles, and those sharing the
+// trait its reason names. The total is capped so feedback reorders the
+// recommendations without burying them.
+const feedbackAdjustment = `(SELECT GREATEST(-0.5, LEAST(0.2, COALESCE(sum(
+	CASE f.kind WHEN 'up' THEN 1 ELSE -1 END * (
+		CASE WHEN f.company_key <> '' AND f.company_key = j.company_key THEN 0.15 ELSE 0 END
+		+ CASE WHEN similarity(lower(j.title), lower(f.title)) >= 0.5
+			THEN 0.15 * similarity(lower(j.title), lower(f.title)) ELSE 0 END
+		+ CASE WHEN f.reason = 'location' AND j.country = f.country AND j.remote = f.remote THEN 0.1 ELSE 0 END
+		+ CASE WHEN f.reason = 'seniority' AND abs(j.min_experience - f.min_experience) <= 1 THEN 0.1 ELSE 0 END
+		+ CASE WHEN f.reason = 'skills' AND j.skill_keys && f.missing_skill_keys THEN 0.1 ELSE 0 END
+	)), 0)))
+	FROM recommendation_feedback f WHERE f.user_id = r.user_id)`
+
+var recommendationPages = keyset{
+	scope:   "recommendations",
+	columns: []string{"r.score + " + feedbackAdjustment, "j.posted_at", "j.id"},
+	desc:    true,
+}
+
+// List returns the cached recommendations of the user that match filter,
+// best first after adjusting their scores for the user's feedback. Jobs the
+// user applied to or is not interested in and duplicates are left out.
+func (r *RecommendationRepository) List(ctx context.Context, userID uuid.UUID, filter *models.JobFilter) ([]models.JobRecommendation, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	conditions, args := jobConditions(filter, []any{userID})
+	var score float64
+	var postedAt time.Time
+	var id uuid.UUID
+	after, args, err := recommendationPages.after(filter.Paginatin, args, &score, &postedAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append(conditions, "r.user_id = $1", "j.canonical_id IS NULL",
+		`NOT EXISTS (SELECT 1 FROM applications a WHERE a.user_id = r.user_id AND a.job_id = r.job_id
+		AND a.status <> 'withdrawn')`,
+		`COALESCE(own.kind, '') <> 'not_interested'`, after)
+	page, args := recommendationPages.page(filter.Paginatin, args)
+	query := `SELECT ` + jobColumns + `, r.score + ` + feedbackAdjustment + ` AS adjusted, r.relevancy,
+	r.matched_skills, r.missing_skills, r.job_updated_at, COALESCE(own.kind, '')
+	FROM job_recommendations r JOIN jobs j ON j.id = r.job_id
+	LEFT JOIN recommendation_feedback own ON own.user_id = r.user_id AND own.job_id = r.job_id
+	WHERE ` + strings.Join(conditions, " AND ") + page
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recommendations := []models.JobRecommendation{}
+	for rows.Next() {
+		var recommendation models.JobRecommendation
+		if err := scanRecommendation(rows, &recommendation, true); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(recommendationPages, filter.Paginatin, recommendations, func(recommendation models.JobRecommendation) []any {
+		return []any{recommendation.Score, recommendation.Job.PostedAt, recommendation.Job.ID}
+	})
+}
+
+// Outdated returns up to limit users who asked for recommendations in the
+// last activeDays days and whose primary resume or the jobs changed since
+// their recommendations were computed, least recently refreshed first.
+func (r *RecommendationRepository) Outdated(ctx context.Context, activeDays int, limit int) ([]uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT s.user_id FROM recommendation_states s
+	WHERE s.requested_at > now() - make_interval(days => $1)
+	AND (s.refreshed_at < (SELECT max(updated_at) FROM jobs)
+		OR EXISTS (SELECT 1 FROM resumes r WHERE r.user_id = s.user_id AND r.is_primary
+			AND (r.id <> s.resume_id OR r.updated_at > s.resume_updated_at)))
+	ORDER BY s.refreshed_at LIMIT $2`, activeDays, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		users = append(users, id)
+	}
+	return users, rows.Err()
+}
+
+// SaveFeedback stores the user's feedback on the job, replacing earlier
+// feedback on it.
+func (r *RecommendationRepository) SaveFeedback(ctx context.Context, feedback *models.RecommendationFeedback) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `INSERT INTO recommendation_feedback (user_id, job_id, kind, reason, score, company_key, title, country,
+	remote, min_experience, missing_skill_keys)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (user_id, job_id) DO UPDATE SET kind = EXCLUDED.kind, reason = EXCLUDED.reason,
+	score = EXCLUDED.score, company_key = EXCLUDED.company_key, title = EXCLUDED.title, country = EXCLUDED.country,
+	remote = EXCLUDED.remote, min_experience = EXCLUDED.min_experience,
+	missing_skill_keys = EXCLUDED.missing_skill_keys, updated_at = now()
+	RETURNING id, created_at, updated_at`
+	err := r.DB.QueryRowContext(ctx, query, feedback.UserID, feedback.JobID, feedback.Kind, feedback.Reason,
+		feedback.Score, feedback.CompanyKey, feedback.Title, feedback.Country, feedback.Remote, feedback.MinExperience,
+		pq.Array(skillKeys(feedback.MissingSkills))).
+		Scan(&feedback.ID, &feedback.CreatedAt, &feedback.UpdatedAt)
+	if err != nil {
+		r.logger.Errorw("Failed to save the recommendation feedback", "error :", err.Error())
+		return fmt.Errorf("RecommendationRepository.SaveFeedback failed: %w", err)
+	}
+	return nil
+}
+
+func (r *RecommendationRepository) DeleteFeedback(ctx context.Context, userID uuid.UUID, jobID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM recommendation_feedback WHERE user_id = $1 AND job_id = $2`,
+		userID, jobID)
+	if err != nil {
+		return fmt.Errorf("RecommendationRepository.DeleteFeedback failed: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrFeedbackNotFound
+	}
+	return nil
+}
+
+// FeedbackStats sums up the feedback given since since, with the scores of
+// the recommended jobs split into that many equal bands between 0 and 1.
+func (r *RecommendationRepository) FeedbackStats(ctx context.Context, since time.Time, bands int) (*models.RecommendationFeedbackStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT kind, reason,
+	CASE WHEN score IS NULL THEN 0 ELSE LEAST(GREATEST(width_bucket(score, 0, 1, $2), 1), $2) END AS band,
+	count(*), count(score), COALESCE(sum(score), 0)
+	FROM recommendation_feedback WHERE created_at >= $1 GROUP BY kind, reason, band`, since, bands)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := &models.RecommendationFeedbackStats{
+		Since:        since,
+		ByKind:       map[string]int{},
+		ByReason:     map[string]map[string]int{},
+		AverageScore: map[string]float64{},
+		Bands:        make([]models.FeedbackBand, bands),
+	}
+	for i := range stats.Bands {
+		stats.Bands[i] = models.FeedbackBand{
+			Min:    float64(i) / float64(bands),
+			Max:    float64(i+1) / float64(bands),
+			Counts: map[string]int{},
+		}
+	}
+	scored := map[string]int{}
+	for rows.Next() {
+		var kind, reason string
+		var band, count, withScore int
+		var sum float64
+		if err := rows.Scan(&kind, &reason, &band, &count, &withScore, &sum); err != nil {
+			return nil, err
+		}
+		stats.Total += count
+		stats.ByKind[kind] += count
+		if reason != "" {
+			if stats.ByReason[reason] == nil {
+				stats.ByReason[reason] = map[string]int{}
+			}
+			stats.ByReason[reason][kind] += count
+		}
+		if band > 0 {
+			stats.Bands[band-1].Counts[kind] += withScore
+			stats.AverageScore[kind] += sum
+			scored[kind] += withScore
+		}
+	}
+	for kind, count := range scored {
+		stats.AverageScore[kind] /= float64(count)
+	}
+	return stats, rows.Err()
+}
@@ -4,6 +4,7 @@ import (
 	"Inquiro/models"
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -26,6 +27,7 @@ type Storage struct {
 	Mentor interface {
 		FindByEmail(ctx context.Context, email string) (*models.Mentor, error)
 		FindByUsername(ctx context.Context, username string) (*models.Mentor, error)
+		FindActiveByUsername(ctx context.Context, username string) (*models.Mentor, error)
 		CreateAndInvite(ctx context.Context, token string, user *models.Mentor) error
 		create(tx *sql.Tx, ctx context.Context, mentor *models.Mentor) error
 		createInvitation(tx *sql.Tx, ctx context.Context, userId uuid.UUID, token string) error
@@ -34,10 +36,141 @@ type Storage struct {
 		update(tx *sql.Tx, ctx context.Context, mentor *models.Mentor) error
 		deleteInvitation(tx *sql.Tx, ctx context.Context, userId uuid.UUID) error
 		GetByID(ctx context.Context, id uuid.UUID) (*models.Mentor, error)
+		SetSkills(ctx context.Context, mentorID uuid.UUID, skillIDs []int64) error
+		Skills(ctx context.Context, mentorID uuid.UUID) ([]string, error)
+		BySkills(ctx context.Context, skillIDs []int64, perSkill int) (map[int64][]models.MentorProfile, error)
 	}
 	Role interface {
 		GetRoleByID(ctx context.Context, id int) (models.Role, error)
 	}
+	Resume interface {
+		Create(ctx context.Context, resume *models.Resume, title string) error
+		GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Resume, error)
+		GetVersion(ctx context.Context, documentID uuid.UUID, version int, userID uuid.UUID) (*models.Resume, error)
+		GetPrimary(ctx context.Context, userID uuid.UUID) (*models.Resume, error)
+		ListByUser(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Resume, error)
+		ListDocuments(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeDocument, error)
+		DocumentExists(ctx context.Context, documentID uuid.UUID, userID uuid.UUID) error
+		UpdateProfile(ctx context.Context, resume *models.Resume) error
+		SetPrimary(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	}
+	Skill interface {
+		Create(ctx context.Context, skill *models.Skill) error
+		Update(ctx context.Context, skill *models.Skill) error
+		Upsert(ctx context.Context, skill *models.Skill) (bool, []string, error)
+		Delete(ctx context.Context, id int64) error
+		GetByID(ctx context.Context, id int64) (*models.Skill, error)
+		List(ctx context.Context, category string, pagination *models.PaginatedQuery) ([]models.Skill, error)
+		Resolve(ctx context.Context, keys []string) (map[string]models.Skill, error)
+		RecordUnknown(ctx context.Context, names map[string]string) error
+		ListUnknown(ctx context.Context, pagination *models.PaginatedQuery) ([]models.UnknownSkill, error)
+		Suggest(ctx context.Context, query string, limit int) ([]models.SkillSuggestion, error)
+		RecordUsage(ctx context.Context, ids []int64) error
+	}
+	ResumeShare interface {
+		Create(ctx context.Context, share *models.ResumeShare) error
+		ListByResume(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeShare, error)
+		Revoke(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error
+		ListByMentor(ctx context.Context, mentorID uuid.UUID, pagination *models.PaginatedQuery) ([]models.SharedResume, error)
+		GetForMentor(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (*models.SharedResume, error)
+		GetFileForMentor(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (*models.SharedResume, error)
+	}
+	ResumeLink interface {
+		Create(ctx context.Context, link *models.ResumeLink) error
+		ListByResume(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeLink, error)
+		Revoke(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error
+		GetByToken(ctx context.Context, token string) (*models.LinkedResume, error)
+		RecordView(ctx context.Context, id uuid.UUID, referrer string) error
+		Stats(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) (*models.ResumeLinkStats, error)
+	}
+	Job interface {
+		Create(ctx context.Context, job *models.Job) error
+		Update(ctx context.Context, job *models.Job) error
+		Upsert(ctx context.Context, job *models.Job, externalID string) (string, error)
+		DuplicateCandidates(ctx context.Context, job *models.Job) ([]models.Job, error)
+		SetCanonical(ctx context.Context, id uuid.UUID, canonicalID *uuid.UUID) error
+		Delete(ctx context.Context, id uuid.UUID) error
+		GetByID(ctx context.Context, id uuid.UUID) (*models.Job, error)
+		List(ctx context.Context, filter *models.JobFilter) ([]models.Job, error)
+		Search(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error)
+	}
+	JobSource interface {
+		Create(ctx context.Context, source *models.JobSource) error
+		Update(ctx context.Context, source *models.JobSource) error
+		Delete(ctx context.Context, id int64) error
+		GetByID(ctx context.Context, id int64) (*models.JobSource, error)
+		List(ctx context.Context, pagination *models.PaginatedQuery) ([]models.JobSource, error)
+		ClaimDue(ctx context.Context) ([]models.JobSource, error)
+		StartRun(ctx context.Context, sourceID int64) (*models.JobImportRun, error)
+		FinishRun(ctx context.Context, run *models.JobImportRun) error
+		ListRuns(ctx context.Context, sourceID int64, pagination *models.PaginatedQuery) ([]models.JobImportRun, error)
+	}
+	Alert interface {
+		Create(ctx context.Context, alert *models.JobAlert) error
+		Update(ctx context.Context, alert *models.JobAlert) error
+		Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+		ListByUser(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobAlert, error)
+		AddUnsubscribeToken(ctx context.Context, alertID uuid.UUID, token string) error
+		Unsubscribe(ctx context.Context, token string) (*models.JobAlert, error)
+		ClaimDue(ctx context.Context) ([]models.JobAlert, error)
+		NewMatches(ctx context.Context, alert *models.JobAlert, query *models.JobSearchQuery, limit int) ([]models.Job, int, error)
+		RecordSent(ctx context.Context, alertID uuid.UUID, jobIDs []uuid.UUID, ranAt time.Time) error
+	}
+	Application interface {
+		Create(ctx context.Context, application *models.Application) error
+		GetByID(ctx context.Context, id uuid.UUID) (*models.Application, error)
+		ListByUser(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Application, error)
+		ListByJob(ctx context.Context, jobID uuid.UUID, status string, pagination *models.PaginatedQuery) ([]models.Application, error)
+		SetStatus(ctx context.Context, application *models.Application, event models.ApplicationEvent) error
+	}
+	Bookmark interface {
+		CreateCollection(ctx context.Context, collection *models.JobCollection) error
+		UpdateCollection(ctx context.Context, collection *models.JobCollection) error
+		DeleteCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+		GetCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.JobCollection, error)
+		ListCollections(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobCollection, error)
+		Add(ctx context.Context, bookmark *models.Bookmark) error
+		Update(ctx context.Context, bookmark *models.Bookmark) error
+		Remove(ctx context.Context, id uuid.UUID, collectionID uuid.UUID, userID uuid.UUID) error
+		List(ctx context.Context, collectionID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Bookmark, error)
+		ClaimDueReminders(ctx context.Context, limit int) ([]models.Bookmark, error)
+	}
+	Company interface {
+		Create(ctx context.Context, company *models.Company, ownerID uuid.UUID) error
+		Update(ctx context.Context, company *models.Company) error
+		GetByID(ctx context.Context, id uuid.UUID) (*models.Company, error)
+		GetBySlug(ctx context.Context, slug string) (*models.Company, error)
+		ForUser(ctx context.Context, userID uuid.UUID) (*models.Company, error)
+		List(ctx context.Context, verified *bool, pagination *models.PaginatedQuery) ([]models.Company, error)
+		SetVerified(ctx context.Context, id uuid.UUID, verifiedBy *uuid.UUID) (*models.Company, error)
+		Members(ctx context.Context, companyID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyMember, error)
+		Invite(ctx context.Context, invite *models.CompanyInvite, token string) error
+		Invites(ctx context.Context, companyID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyInvite, error)
+		RevokeInvite(ctx context.Context, companyID uuid.UUID, id uuid.UUID) error
+		AcceptInvite(ctx context.Context, token string, user *models.User) (*models.CompanyMember, error)
+		RemoveMember(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) error
+	}
+	Recommendation interface {
+		State(ctx context.Context, userID uuid.UUID) (*models.RecommendationState, error)
+		Touch(ctx context.Context, userID uuid.UUID) error
+		Candidates(ctx context.Context, skills []string, experience int, slack int, limit int) ([]models.Job, error)
+		Scores(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]models.JobRecommendation, error)
+		Replace(ctx context.Context, state *models.RecommendationState, recommendations []models.JobRecommendation) error
+		List(ctx context.Context, userID uuid.UUID, filter *models.JobFilter) ([]models.JobRecommendation, error)
+		Outdated(ctx context.Context, activeDays int, limit int) ([]uuid.UUID, error)
+		SaveFeedback(ctx context.Context, feedback *models.RecommendationFeedback) error
+		DeleteFeedback(ctx context.Context, userID uuid.UUID, jobID uuid.UUID) error
+		FeedbackStats(ctx context.Context, since time.Time, bands int) (*models.RecommendationFeedbackStats, error)
+	}
+	Quota interface {
+		Usage(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, error)
+		Reserve(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, int64, error)
+		Release(ctx context.Context, id int64) error
+	}
+	Title interface {
+		Record(ctx context.Context, titles []string) error
+		Suggest(ctx context.Context, query string, limit int) ([]models.TitleSuggestion, error)
+	}
 }
 
 func NewStorage(db *sql.DB, logger *zap.SugaredLogger) Storage {
@@ -48,6 +181,32 @@ func NewStorage(db *sql.DB, logger *zap.SugaredLogger) Storage {
 			logger: logger},
 		Role: &RoleRepository{DB: db,
 			logger: logger},
+		Resume: &ResumeRepository{DB: db,
+			logger: logger},
+		Skill: &SkillRepository{DB: db,
+			logger: logger},
+		Title: &TitleRepository{DB: db,
+			logger: logger},
+		ResumeShare: &ResumeShareRepository{DB: db,
+			logger: logger},
+		ResumeLink: &ResumeLinkRepository{DB: db,
+			logger: logger},
+		Job: &JobRepository{DB: db,
+			logger: logger},
+		JobSource: &JobSourceRepository{DB: db,
+			logger: logger},
+		Alert: &AlertRepository{DB: db,
+			logger: logger},
+		Application: &ApplicationRepository{DB: db,
+			logger: logger},
+		Bookmark: &BookmarkRepository{DB: db,
+			logger: logger},
+		Company: &CompanyRepository{DB: db,
+			logger: logger},
+		Recommendation: &RecommendationRepository{DB: db,
+			logger: logger},
+		Quota: &QuotaRepository{DB: db,
+			logger: logger},
 	}
 }
 
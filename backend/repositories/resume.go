@@ -0,0 +1,332 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrResumeNotFound         = errors.New("resume not found")
+	ErrResumeDocumentNotFound = errors.New("resume document not found")
+	ErrResumeConflict         = errors.New("resumes changed meanwhile, try again")
+)
+
+const resumeColumns = `id, user_id, document_id, version, is_primary, file_name, content_type, degraded,
+	parsed_job_titles, parsed_skills, parsed_experience, job_titles, skills, experience, skill_ids,
+	edited_at, created_at, updated_at`
+
+type ResumeRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanResume(row scanner, resume *models.Resume, extra ...any) error {
+	dest := []any{
+		&resume.ID, &resume.UserID, &resume.DocumentID, &resume.Version, &resume.IsPrimary,
+		&resume.FileName, &resume.ContentType, &resume.Degraded,
+		pq.Array(&resume.Parsed.JobTitles), pq.Array(&resume.Parsed.Skills), &resume.Parsed.Experience,
+		pq.Array(&resume.Profile.JobTitles), pq.Array(&resume.Profile.Skills), &resume.Profile.Experience, pq.Array(&resume.SkillIDs),
+		&resume.EditedAt, &resume.CreatedAt, &resume.UpdatedAt,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// lockResumes serialises the changes to the user's resumes that pick the
+// primary one, so two of them cannot both make a resume primary.
+func lockResumes(tx *sql.Tx, ctx context.Context, userID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `SELECT 1 FROM users WHERE id = $1 FOR NO KEY UPDATE`, userID)
+	return err
+}
+
+// resumeError turns the unique violations a concurrent change to the user's
+// resumes can still cause into ErrResumeConflict.
+func resumeError(err error) error {
+	if strings.Contains(err.Error(), `"resumes_primary_idx"`) || strings.Contains(err.Error(), `"resumes_document_version_idx"`) {
+		return ErrResumeConflict
+	}
+	return err
+}
+
+// Create stores the resume as the next version of resume.DocumentID. When no
+// document is given a new one is created with the given title. The first
+// resume of a user becomes the primary one.
+func (r *ResumeRepository) Create(ctx context.Context, resume *models.Resume, title string) error {
+	return WithTx(r.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		if err := lockResumes(tx, ctx, resume.UserID); err != nil {
+			return fmt.Errorf("ResumeRepository.Create failed: %w", err)
+		}
+		if resume.DocumentID == uuid.Nil {
+			err := tx.QueryRowContext(ctx, `INSERT INTO resume_documents (user_id, title) VALUES ($1, $2) RETURNING id`,
+				resume.UserID, title).Scan(&resume.DocumentID)
+			if err != nil {
+				r.logger.Errorw("Failed to insert the resume document", "error :", err.Error())
+				return fmt.Errorf("ResumeRepository.Create failed: %w", err)
+			}
+		} else {
+			// Locking the document serialises concurrent uploads of new versions
+			var id uuid.UUID
+			err := tx.QueryRowContext(ctx, `SELECT id FROM resume_documents WHERE id = $1 AND user_id = $2 FOR UPDATE`,
+				resume.DocumentID, resume.UserID).Scan(&id)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return ErrResumeDocumentNotFound
+				}
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `UPDATE resume_documents SET updated_at = now() WHERE id = $1`, id); err != nil {
+				return err
+			}
+		}
+
+		query := `INSERT INTO resumes (user_id, document_id, version, is_primary, file_name, content_type, file_content, extracted_text, degraded,
+		parsed_job_titles, parsed_skills, parsed_experience, job_titles, skills, experience, skill_ids)
+		VALUES ($1, $2,
+			(SELECT COALESCE(MAX(version), 0) + 1 FROM resumes WHERE document_id = $2),
+			NOT EXISTS (SELECT 1 FROM resumes WHERE user_id = $1 AND is_primary),
+			$3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, version, is_primary, created_at, updated_at`
+		err := tx.QueryRowContext(ctx, query,
+			resume.UserID, resume.DocumentID,
+			resume.FileName, resume.ContentType, resume.FileContent, resume.ExtractedText, resume.Degraded,
+			pq.Array(resume.Parsed.JobTitles), pq.Array(resume.Parsed.Skills), resume.Parsed.Experience,
+			pq.Array(resume.Profile.JobTitles), pq.Array(resume.Profile.Skills), resume.Profile.Experience, pq.Array(resume.SkillIDs),
+		).Scan(&resume.ID, &resume.Version, &resume.IsPrimary, &resume.CreatedAt, &resume.UpdatedAt)
+		if err != nil {
+			r.logger.Errorw("Failed to insert the resume", "error :", err.Error())
+			return fmt.Errorf("ResumeRepository.Create failed: %w", resumeError(err))
+		}
+		return nil
+	})
+}
+
+// DocumentExists returns ErrResumeDocumentNotFound unless the document
+// belongs to userID.
+func (r *ResumeRepository) DocumentExists(ctx context.Context, documentID uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM resume_documents WHERE id = $1 AND user_id = $2)`,
+		documentID, userID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrResumeDocumentNotFound
+	}
+	return nil
+}
+
+// GetByID returns the resume only when it belongs to userID, so callers
+// cannot tell apart a missing resume from somebody else's.
+func (r *ResumeRepository) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Resume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `SELECT ` + resumeColumns + `, file_content, extracted_text FROM resumes WHERE id = $1 AND user_id = $2`
+	resume := &models.Resume{}
+	err := scanResume(r.DB.QueryRowContext(ctx, query, id, userID), resume, &resume.FileContent, &resume.ExtractedText)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			r.logger.Warnw("resume does not exist", "error :", err.Error())
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+	return resume, nil
+}
+
+func (r *ResumeRepository) GetVersion(ctx context.Context, documentID uuid.UUID, version int, userID uuid.UUID) (*models.Resume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `SELECT ` + resumeColumns + ` FROM resumes WHERE document_id = $1 AND version = $2 AND user_id = $3`
+	resume := &models.Resume{}
+	err := scanResume(r.DB.QueryRowContext(ctx, query, documentID, version, userID), resume)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+	return resume, nil
+}
+
+// GetPrimary returns the resume the user marked as primary.
+func (r *ResumeRepository) GetPrimary(ctx context.Context, userID uuid.UUID) (*models.Resume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `SELECT ` + resumeColumns + ` FROM resumes WHERE user_id = $1 AND is_primary`
+	resume := &models.Resume{}
+	err := scanResume(r.DB.QueryRowContext(ctx, query, userID), resume)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+	return resume, nil
+}
+
+var (
+	resumePages   = keyset{scope: "resumes", columns: []string{"created_at", "id"}, desc: true}
+	documentPages = keyset{scope: "resume_documents", columns: []string{"updated_at", "id"}, desc: true}
+)
+
+// ListByUser returns a page of the resumes of the user, newest first.
+func (r *ResumeRepository) ListByUser(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Resume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := resumePages.after(pagination, []any{userID}, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := resumePages.page(pagination, args)
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+resumeColumns+` FROM resumes WHERE user_id = $1 AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resumes := []models.Resume{}
+	for rows.Next() {
+		var resume models.Resume
+		if err := scanResume(rows, &resume); err != nil {
+			return nil, err
+		}
+		resumes = append(resumes, resume)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(resumePages, pagination, resumes, func(resume models.Resume) []any {
+		return []any{resume.CreatedAt, resume.ID}
+	})
+}
+
+// ListDocuments returns a page of the documents of the user, most recently
+// updated first, with their versions in ascending order.
+func (r *ResumeRepository) ListDocuments(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeDocument, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var updatedAt time.Time
+	var id uuid.UUID
+	after, args, err := documentPages.after(pagination, []any{userID}, &updatedAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := documentPages.page(pagination, args)
+	rows, err := r.DB.QueryContext(ctx, `SELECT id, user_id, title, created_at, updated_at FROM resume_documents
+	WHERE user_id = $1 AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []models.ResumeDocument{}
+	for rows.Next() {
+		document := models.ResumeDocument{Versions: []models.Resume{}}
+		if err := rows.Scan(&document.ID, &document.UserID, &document.Title, &document.CreatedAt, &document.UpdatedAt); err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	documents, err = nextPage(documentPages, pagination, documents, func(document models.ResumeDocument) []any {
+		return []any{document.UpdatedAt, document.ID}
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(documents))
+	index := map[uuid.UUID]int{}
+	for i, document := range documents {
+		ids = append(ids, document.ID)
+		index[document.ID] = i
+	}
+
+	versions, err := r.DB.QueryContext(ctx, `SELECT `+resumeColumns+` FROM resumes
+	WHERE user_id = $1 AND document_id = ANY($2::uuid[]) ORDER BY document_id, version`, userID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer versions.Close()
+	for versions.Next() {
+		var resume models.Resume
+		if err := scanResume(versions, &resume); err != nil {
+			return nil, err
+		}
+		if i, ok := index[resume.DocumentID]; ok {
+			documents[i].Versions = append(documents[i].Versions, resume)
+		}
+	}
+	return documents, versions.Err()
+}
+
+// UpdateProfile saves the user edited profile. The parsed columns are left
+// untouched so the original machine output stays available.
+func (r *ResumeRepository) UpdateProfile(ctx context.Context, resume *models.Resume) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	query := `UPDATE resumes SET job_titles = $1, skills = $2, experience = $3, skill_ids = $4, edited_at = now(), updated_at = now()
+	WHERE id = $5 AND user_id = $6 RETURNING edited_at, updated_at`
+	err := r.DB.QueryRowContext(ctx, query,
+		pq.Array(resume.Profile.JobTitles), pq.Array(resume.Profile.Skills), resume.Profile.Experience, pq.Array(resume.SkillIDs),
+		resume.ID, resume.UserID,
+	).Scan(&resume.EditedAt, &resume.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrResumeNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// SetPrimary marks the resume as the primary one of its owner and clears the
+// flag on every other resume of that user.
+func (r *ResumeRepository) SetPrimary(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return WithTx(r.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		if err := lockResumes(tx, ctx, userID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE resumes SET is_primary = FALSE WHERE user_id = $1 AND is_primary AND id <> $2`, userID, id); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `UPDATE resumes SET is_primary = TRUE WHERE id = $1 AND user_id = $2`, id, userID)
+		if err != nil {
+			return resumeError(err)
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return ErrResumeNotFound
+		}
+		return nil
+	})
+}
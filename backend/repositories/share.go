@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var ErrShareNotFound = errors.New("resume share not found")
+
+type ResumeShareRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+// shareColumns never include resumes.file_content or resumes.extracted_text,
+// mentors must only be able to read the redacted copies.
+const shareColumns = `s.id, s.resume_id, s.user_id, s.mentor_id, m.username, s.reveal, s.redactions,
+	s.redacted_file IS NOT NULL, s.created_at, s.updated_at, s.revoked_at`
+
+func scanShare(row scanner, share *models.ResumeShare, extra ...any) error {
+	var redactions []byte
+	dest := []any{&share.ID, &share.ResumeID, &share.UserID, &share.MentorID, &share.MentorUsername,
+		pq.Array(&share.Reveal), &redactions, &share.HasFile, &share.CreatedAt, &share.UpdatedAt, &share.RevokedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	return json.Unmarshal(redactions, &share.Redactions)
+}
+
+// Create shares the resume with the mentor. Sharing the same resume again
+// replaces the redacted copy of the active share.
+func (s *ResumeShareRepository) Create(ctx context.Context, share *models.ResumeShare) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	redactions, err := json.Marshal(share.Redactions)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO resume_shares (resume_id, user_id, mentor_id, reveal, redactions, redacted_text, redacted_file)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (resume_id, mentor_id) WHERE revoked_at IS NULL DO UPDATE SET
+		reveal = EXCLUDED.reveal, redactions = EXCLUDED.redactions, redacted_text = EXCLUDED.redacted_text,
+		redacted_file = EXCLUDED.redacted_file, updated_at = now()
+	RETURNING id, created_at, updated_at`
+	err = s.DB.QueryRowContext(ctx, query, share.ResumeID, share.UserID, share.MentorID, pq.Array(share.Reveal),
+		redactions, share.RedactedText, share.RedactedFile).Scan(&share.ID, &share.CreatedAt, &share.UpdatedAt)
+	if err != nil {
+		s.logger.Errorw("Failed to insert the resume share", "error :", err.Error())
+		return fmt.Errorf("ResumeShareRepository.Create failed: %w", err)
+	}
+	share.HasFile = share.RedactedFile != nil
+	return nil
+}
+
+var (
+	sharePages       = keyset{scope: "resume_shares", columns: []string{"s.created_at", "s.id"}, desc: true}
+	mentorSharePages = keyset{scope: "mentor_shares", columns: []string{"s.updated_at", "s.id"}, desc: true}
+)
+
+// ListByResume returns a page of the active shares of the resume, newest
+// first.
+func (s *ResumeShareRepository) ListByResume(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeShare, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var createdAt time.Time
+	var id uuid.UUID
+	after, args, err := sharePages.after(pagination, []any{resumeID, userID}, &createdAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := sharePages.page(pagination, args)
+	rows, err := s.DB.QueryContext(ctx, `SELECT `+shareColumns+` FROM resume_shares s JOIN mentor m ON m.id = s.mentor_id
+	WHERE s.resume_id = $1 AND s.user_id = $2 AND s.revoked_at IS NULL AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := []models.ResumeShare{}
+	for rows.Next() {
+		var share models.ResumeShare
+		if err := scanShare(rows, &share); err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(sharePages, pagination, shares, func(share models.ResumeShare) []any {
+		return []any{share.CreatedAt, share.ID}
+	})
+}
+
+func (s *ResumeShareRepository) Revoke(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, `UPDATE resume_shares SET revoked_at = now(), updated_at = now()
+	WHERE id = $1 AND resume_id = $2 AND user_id = $3 AND revoked_at IS NULL`, id, resumeID, userID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// ListByMentor returns a page of the active shares of the mentor without
+// their text, most recently updated first.
+func (s *ResumeShareRepository) ListByMentor(ctx context.Context, mentorID uuid.UUID, pagination *models.PaginatedQuery) ([]models.SharedResume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var updatedAt time.Time
+	var id uuid.UUID
+	after, args, err := mentorSharePages.after(pagination, []any{mentorID}, &updatedAt, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := mentorSharePages.page(pagination, args)
+	rows, err := s.DB.QueryContext(ctx, `SELECT `+shareColumns+`, r.file_name, r.job_titles, r.skills, r.experience
+	FROM resume_shares s JOIN mentor m ON m.id = s.mentor_id JOIN resumes r ON r.id = s.resume_id
+	WHERE s.mentor_id = $1 AND s.revoked_at IS NULL AND `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shared := []models.SharedResume{}
+	for rows.Next() {
+		var resume models.SharedResume
+		if err := scanShare(rows, &resume.Share, &resume.FileName,
+			pq.Array(&resume.Profile.JobTitles), pq.Array(&resume.Profile.Skills), &resume.Profile.Experience); err != nil {
+			return nil, err
+		}
+		shared = append(shared, resume)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(mentorSharePages, pagination, shared, func(resume models.SharedResume) []any {
+		return []any{resume.Share.UpdatedAt, resume.Share.ID}
+	})
+}
+
+func (s *ResumeShareRepository) GetForMentor(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (*models.SharedResume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	resume := &models.SharedResume{}
+	err := scanShare(s.DB.QueryRowContext(ctx, `SELECT `+shareColumns+`, r.file_name, r.job_titles, r.skills, r.experience, s.redacted_text
+	FROM resume_shares s JOIN mentor m ON m.id = s.mentor_id JOIN resumes r ON r.id = s.resume_id
+	WHERE s.id = $1 AND s.mentor_id = $2 AND s.revoked_at IS NULL`, id, mentorID), &resume.Share, &resume.FileName,
+		pq.Array(&resume.Profile.JobTitles), pq.Array(&resume.Profile.Skills), &resume.Profile.Experience, &resume.Text)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrShareNotFound
+		}
+		return nil, err
+	}
+	return resume, nil
+}
+
+// GetFileForMentor returns the share with its redacted rendition, which is
+// nil when no text could be extracted from the original file.
+func (s *ResumeShareRepository) GetFileForMentor(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (*models.SharedResume, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	resume := &models.SharedResume{}
+	err := scanShare(s.DB.QueryRowContext(ctx, `SELECT `+shareColumns+`, r.file_name, s.redacted_file
+	FROM resume_shares s JOIN mentor m ON m.id = s.mentor_id JOIN resumes r ON r.id = s.resume_id
+	WHERE s.id = $1 AND s.mentor_id = $2 AND s.revoked_at IS NULL`, id, mentorID), &resume.Share, &resume.FileName, &resume.Share.RedactedFile)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrShareNotFound
+		}
+		return nil, err
+	}
+	return resume, nil
+}
@@ -0,0 +1,347 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSkillNotFound       = errors.New("skill not found")
+	ErrDuplicateSkill      = errors.New("skill already exists")
+	ErrDuplicateSkillAlias = errors.New("alias already used by another skill")
+)
+
+type SkillRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+const skillSelect = `SELECT s.id, s.name, s.category, s.popularity, s.created_at, s.updated_at,
+	COALESCE(array_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}')
+	FROM skills s LEFT JOIN skill_aliases a ON a.skill_id = s.id`
+
+func scanSkill(row scanner, skill *models.Skill) error {
+	return row.Scan(&skill.ID, &skill.Name, &skill.Category, &skill.Popularity, &skill.CreatedAt, &skill.UpdatedAt, pq.Array(&skill.Aliases))
+}
+
+func skillError(err error) error {
+	errString := err.Error()
+	switch {
+	case strings.Contains(errString, `"skills_name_key"`):
+		return ErrDuplicateSkill
+	case strings.Contains(errString, `"skill_aliases_pkey"`):
+		return ErrDuplicateSkillAlias
+	}
+	return err
+}
+
+// aliasKeys returns the keys stored for a skill: its own name and every alias.
+func aliasKeys(skill *models.Skill) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, name := range append([]string{skill.Name}, skill.Aliases...) {
+		key := models.SkillKey(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *SkillRepository) replaceAliases(tx *sql.Tx, ctx context.Context, skill *models.Skill) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM skill_aliases WHERE skill_id = $1`, skill.ID); err != nil {
+		return err
+	}
+	keys := aliasKeys(skill)
+	if _, err := tx.ExecContext(ctx, `INSERT INTO skill_aliases (alias, skill_id) SELECT unnest($1::varchar[]), $2`, pq.Array(keys), skill.ID); err != nil {
+		return skillError(err)
+	}
+	// Spellings that are now known no longer need curation
+	if _, err := tx.ExecContext(ctx, `DELETE FROM unknown_skills WHERE alias = ANY($1)`, pq.Array(keys)); err != nil {
+		return err
+	}
+	skill.Aliases = keys
+	return nil
+}
+
+func (s *SkillRepository) Create(ctx context.Context, skill *models.Skill) error {
+	return WithTx(s.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		err := tx.QueryRowContext(ctx, `INSERT INTO skills (name, category) VALUES ($1, $2) RETURNING id, created_at, updated_at`,
+			skill.Name, skill.Category).Scan(&skill.ID, &skill.CreatedAt, &skill.UpdatedAt)
+		if err != nil {
+			s.logger.Warnw("Failed to insert the skill", "error :", err.Error())
+			return skillError(err)
+		}
+		return s.replaceAliases(tx, ctx, skill)
+	})
+}
+
+func (s *SkillRepository) Update(ctx context.Context, skill *models.Skill) error {
+	return WithTx(s.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		err := tx.QueryRowContext(ctx, `UPDATE skills SET name = $1, category = $2, updated_at = now() WHERE id = $3 RETURNING popularity, created_at, updated_at`,
+			skill.Name, skill.Category, skill.ID).Scan(&skill.Popularity, &skill.CreatedAt, &skill.UpdatedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrSkillNotFound
+			}
+			return skillError(err)
+		}
+		return s.replaceAliases(tx, ctx, skill)
+	})
+}
+
+// Upsert creates the skill or updates the category of the skill with the
+// same name. Aliases are added, aliases of other skills are left alone and
+// reported as skipped. It returns whether the skill was created.
+func (s *SkillRepository) Upsert(ctx context.Context, skill *models.Skill) (bool, []string, error) {
+	created := false
+	var skipped []string
+	err := WithTx(s.DB, ctx, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+		defer cancel()
+
+		err := tx.QueryRowContext(ctx, `INSERT INTO skills (name, category) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET category = EXCLUDED.category, updated_at = now()
+		RETURNING id, popularity, created_at, updated_at, (xmax = 0)`,
+			skill.Name, skill.Category).Scan(&skill.ID, &skill.Popularity, &skill.CreatedAt, &skill.UpdatedAt, &created)
+		if err != nil {
+			return err
+		}
+		for _, key := range aliasKeys(skill) {
+			var owner int64
+			err := tx.QueryRowContext(ctx, `INSERT INTO skill_aliases (alias, skill_id) VALUES ($1, $2)
+			ON CONFLICT (alias) DO UPDATE SET alias = EXCLUDED.alias RETURNING skill_id`, key, skill.ID).Scan(&owner)
+			if err != nil {
+				return err
+			}
+			if owner != skill.ID {
+				skipped = append(skipped, key)
+			}
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM unknown_skills WHERE alias IN (SELECT alias FROM skill_aliases WHERE skill_id = $1)`, skill.ID)
+		return err
+	})
+	return created, skipped, err
+}
+
+func (s *SkillRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM skills WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrSkillNotFound
+	}
+	return nil
+}
+
+func (s *SkillRepository) GetByID(ctx context.Context, id int64) (*models.Skill, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	skill := &models.Skill{}
+	err := scanSkill(s.DB.QueryRowContext(ctx, skillSelect+` WHERE s.id = $1 GROUP BY s.id`, id), skill)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSkillNotFound
+		}
+		return nil, err
+	}
+	return skill, nil
+}
+
+var skillPages = keyset{scope: "skills", columns: []string{"s.name", "s.id"}}
+
+func (s *SkillRepository) List(ctx context.Context, category string, pagination *models.PaginatedQuery) ([]models.Skill, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var name string
+	var id int64
+	after, args, err := skillPages.after(pagination, []any{category}, &name, &id)
+	if err != nil {
+		return nil, err
+	}
+	page, args := skillPages.page(pagination, args)
+	rows, err := s.DB.QueryContext(ctx, skillSelect+` WHERE ($1 = '' OR s.category = $1) AND `+after+` GROUP BY s.id`+page,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []models.Skill{}
+	for rows.Next() {
+		var skill models.Skill
+		if err := scanSkill(rows, &skill); err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(skillPages, pagination, skills, func(skill models.Skill) []any {
+		return []any{skill.Name, skill.ID}
+	})
+}
+
+// Resolve maps alias keys to the skills they belong to. Keys that are not
+// in the catalog are missing from the result.
+func (s *SkillRepository) Resolve(ctx context.Context, keys []string) (map[string]models.Skill, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, `SELECT a.alias, s.id, s.name, s.category FROM skill_aliases a
+	JOIN skills s ON s.id = a.skill_id WHERE a.alias = ANY($1)`, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resolved := make(map[string]models.Skill, len(keys))
+	for rows.Next() {
+		var key string
+		var skill models.Skill
+		if err := rows.Scan(&key, &skill.ID, &skill.Name, &skill.Category); err != nil {
+			return nil, err
+		}
+		resolved[key] = skill
+	}
+	return resolved, rows.Err()
+}
+
+// RecordUnknown counts spellings that are not in the catalog so they can be
+// curated later. names maps alias keys to the spelling that was seen.
+func (s *SkillRepository) RecordUnknown(ctx context.Context, names map[string]string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	keys := make([]string, 0, len(names))
+	spellings := make([]string, 0, len(names))
+	for key, name := range names {
+		keys = append(keys, key)
+		spellings = append(spellings, name)
+	}
+	_, err := s.DB.ExecContext(ctx, `INSERT INTO unknown_skills (alias, name)
+	SELECT * FROM unnest($1::varchar[], $2::varchar[])
+	ON CONFLICT (alias) DO UPDATE SET occurrences = unknown_skills.occurrences + 1, last_seen_at = now()`,
+		pq.Array(keys), pq.Array(spellings))
+	if err != nil {
+		return fmt.Errorf("SkillRepository.RecordUnknown failed: %w", err)
+	}
+	return nil
+}
+
+var unknownSkillPages = keyset{scope: "unknown_skills", columns: []string{"occurrences", "last_seen_at", "alias"}, desc: true}
+
+func (s *SkillRepository) ListUnknown(ctx context.Context, pagination *models.PaginatedQuery) ([]models.UnknownSkill, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	var occurrences int
+	var lastSeenAt time.Time
+	var alias string
+	after, args, err := unknownSkillPages.after(pagination, nil, &occurrences, &lastSeenAt, &alias)
+	if err != nil {
+		return nil, err
+	}
+	page, args := unknownSkillPages.page(pagination, args)
+	rows, err := s.DB.QueryContext(ctx, `SELECT alias, name, occurrences, first_seen_at, last_seen_at FROM unknown_skills
+	WHERE `+after+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unknown := []models.UnknownSkill{}
+	for rows.Next() {
+		var skill models.UnknownSkill
+		if err := rows.Scan(&skill.Alias, &skill.Name, &skill.Occurrences, &skill.FirstSeenAt, &skill.LastSeenAt); err != nil {
+			return nil, err
+		}
+		unknown = append(unknown, skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nextPage(unknownSkillPages, pagination, unknown, func(skill models.UnknownSkill) []any {
+		return []any{skill.Occurrences, skill.LastSeenAt, skill.Alias}
+	})
+}
+
+// Suggest returns the skills whose name or aliases look like query. Prefix
+// matches rank above fuzzy ones so typeahead works from the first letters,
+// and popularity breaks ties between similar scores.
+func (s *SkillRepository) Suggest(ctx context.Context, query string, limit int) ([]models.SkillSuggestion, error) {
+	key := models.SkillKey(query)
+	if key == "" {
+		return []models.SkillSuggestion{}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, `SELECT id, name, category, popularity, alias, score, alias = $1 FROM (
+		SELECT DISTINCT ON (s.id) s.id, s.name, s.category, s.popularity, m.alias, m.score
+		FROM (
+			SELECT skill_id, alias, CASE
+				WHEN alias = $1 THEN 1
+				WHEN left(alias, length($1)) = $1 THEN 0.6 + 0.4 * similarity(alias, $1)
+				ELSE similarity(alias, $1)
+			END AS score
+			FROM skill_aliases
+			WHERE alias % $1 OR left(alias, length($1)) = $1
+		) m JOIN skills s ON s.id = m.skill_id
+		ORDER BY s.id, m.score DESC
+	) best
+	ORDER BY score + `+popularityWeight+` DESC, name LIMIT $2`, key, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []models.SkillSuggestion{}
+	for rows.Next() {
+		var suggestion models.SkillSuggestion
+		if err := rows.Scan(&suggestion.ID, &suggestion.Name, &suggestion.Category, &suggestion.Popularity,
+			&suggestion.Alias, &suggestion.Score, &suggestion.Exact); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, rows.Err()
+}
+
+// RecordUsage bumps the popularity of the given skills once each.
+func (s *SkillRepository) RecordUsage(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, `UPDATE skills SET popularity = popularity + 1 WHERE id = ANY($1)`, pq.Array(ids))
+	return err
+}
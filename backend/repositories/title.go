@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"Inquiro/models"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// popularityWeight is added to the similarity score of a suggestion. The
+// logarithm keeps very common entries from burying close matches.
+const popularityWeight = `0.05 * ln(1 + popularity)`
+
+type TitleRepository struct {
+	DB     *sql.DB
+	logger *zap.SugaredLogger
+}
+
+// Record adds the titles to the catalog and bumps their popularity once
+// each, however many times they are repeated in titles.
+func (t *TitleRepository) Record(ctx context.Context, titles []string) error {
+	seen := map[string]bool{}
+	names := make([]string, 0, len(titles))
+	for _, title := range titles {
+		title = strings.TrimSpace(title)
+		if title == "" || seen[strings.ToLower(title)] {
+			continue
+		}
+		seen[strings.ToLower(title)] = true
+		names = append(names, title)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	_, err := t.DB.ExecContext(ctx, `INSERT INTO job_titles (name, popularity) SELECT unnest($1::varchar[]), 1
+	ON CONFLICT (name) DO UPDATE SET popularity = job_titles.popularity + 1, updated_at = now()`, pq.Array(names))
+	return err
+}
+
+func (t *TitleRepository) Suggest(ctx context.Context, query string, limit int) ([]models.TitleSuggestion, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []models.TitleSuggestion{}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeOut)
+	defer cancel()
+
+	rows, err := t.DB.QueryContext(ctx, `SELECT id, name, popularity, score FROM (
+		SELECT id, name, popularity, CASE
+			WHEN lower(name::text) = $1 THEN 1
+			WHEN left(lower(name::text), length($1)) = $1 THEN 0.6 + 0.4 * similarity(lower(name::text), $1)
+			ELSE word_similarity($1, lower(name::text))
+		END AS score
+		FROM job_titles
+		WHERE $1 <% lower(name::text) OR left(lower(name::text), length($1)) = $1
+	) m
+	ORDER BY score + `+popularityWeight+` DESC, name LIMIT $2`, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []models.TitleSuggestion{}
+	for rows.Next() {
+		var suggestion models.TitleSuggestion
+		if err := rows.Scan(&suggestion.ID, &suggestion.Name, &suggestion.Popularity, &suggestion.Score); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, rows.Err()
+}
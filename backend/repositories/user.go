@@ -194,9 +194,9 @@ func (u *UserRepository) deleteInvitation(tx *sql.Tx, ctx context.Context, userI
 }
 
 func (u *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	row := u.DB.QueryRowContext(ctx, "SELECT id, username, first_name, last_name, is_active , is_verified, email, role_id FROM user WHERE id = $1", id)
+	row := u.DB.QueryRowContext(ctx, "SELECT id, username, first_name, last_name, is_active , is_verified, email, role_id FROM users WHERE id = $1", id)
 	user := &models.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified, &user.Password, &user.Email, &user.RoleID)
+	err := row.Scan(&user.ID, &user.Username, &user.FirstName, &user.LastName, &user.IsActive, &user.IsVerified, &user.Email, &user.RoleID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrUserNotFound
@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"Inquiro/controller"
+	"Inquiro/middlewares"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ApplicationRoutes struct {
+	controller controller.Controller
+	middleware middlewares.Middleware
+}
+
+func NewApplicationRoutes(controller controller.Controller, middleware middlewares.Middleware) ApplicationRoutes {
+	return ApplicationRoutes{
+		controller: controller,
+		middleware: middleware,
+	}
+}
+
+func (ar ApplicationRoutes) RegisterApplicationRoutes(chi_router *chi.Mux) {
+	chi_router.Route("/applications", func(r chi.Router) {
+		r.Use(ar.middleware.Auth.LoadUser())
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			ar.controller.Application.ListApplications(w, r)
+		})
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			ar.controller.Application.Apply(w, r)
+		})
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			ar.controller.Application.GetApplication(w, r)
+		})
+		r.Post("/{id}/withdraw", func(w http.ResponseWriter, r *http.Request) {
+			ar.controller.Application.WithdrawApplication(w, r)
+		})
+		// Moderators and the employers of the job's company
+		r.Put("/{id}/status", func(w http.ResponseWriter, r *http.Request) {
+			ar.controller.Application.SetApplicationStatus(w, r)
+		})
+	})
+}
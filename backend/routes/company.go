@@ -0,0 +1,76 @@
+package routes
+
+import (
+	"Inquiro/controller"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CompanyRoutes struct {
+	controller controller.Controller
+	middleware middlewares.Middleware
+}
+
+func NewCompanyRoutes(controller controller.Controller, middleware middlewares.Middleware) CompanyRoutes {
+	return CompanyRoutes{
+		controller: controller,
+		middleware: middleware,
+	}
+}
+
+func (cr CompanyRoutes) RegisterCompanyRoutes(chi_router *chi.Mux) {
+	chi_router.Route("/companies", func(r chi.Router) {
+		r.Get("/{slug}", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.GetCompany(w, r)
+		})
+		r.Get("/{slug}/jobs", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.ListCompanyJobs(w, r)
+		})
+	})
+	chi_router.Route("/employer/company", func(r chi.Router) {
+		r.Use(cr.middleware.Auth.LoadUser())
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.GetMyCompany(w, r)
+		})
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.CreateCompany(w, r)
+		})
+		r.Put("/", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.UpdateCompany(w, r)
+		})
+		r.Get("/members", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.ListCompanyMembers(w, r)
+		})
+		r.Delete("/members/{user}", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.RemoveCompanyMember(w, r)
+		})
+		r.Get("/invites", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.ListCompanyInvites(w, r)
+		})
+		r.Post("/invites", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.InviteCompanyMember(w, r)
+		})
+		r.Delete("/invites/{id}", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.RevokeCompanyInvite(w, r)
+		})
+	})
+	chi_router.Route("/employer/invites", func(r chi.Router) {
+		r.Use(cr.middleware.Auth.LoadUser())
+		r.Post("/{token}", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.AcceptCompanyInvite(w, r)
+		})
+	})
+	chi_router.Route("/admin/companies", func(r chi.Router) {
+		r.Use(cr.middleware.Auth.LoadUser())
+		r.Use(cr.middleware.Auth.RequireRole(models.RoleLevelAdmin))
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.ListCompanies(w, r)
+		})
+		r.Put("/{id}/verification", func(w http.ResponseWriter, r *http.Request) {
+			cr.controller.Company.VerifyCompany(w, r)
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"Inquiro/controller"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type JobRoutes struct {
+	controller controller.Controller
+	middleware middlewares.Middleware
+}
+
+func NewJobRoutes(controller controller.Controller, middleware middlewares.Middleware) JobRoutes {
+	return JobRoutes{
+		controller: controller,
+		middleware: middleware,
+	}
+}
+
+func (jr JobRoutes) RegisterJobRoutes(chi_router *chi.Mux) {
+	chi_router.Route("/jobs", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Job.ListJobs(w, r)
+		})
+		r.Get("/search", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Job.SearchJobs(w, r)
+		})
+		r.With(jr.middleware.Auth.LoadUser()).Get("/recommended", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Recommendation.RecommendedJobs(w, r)
+		})
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Job.GetJob(w, r)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(jr.middleware.Auth.LoadUser())
+			r.Put("/{id}/feedback", func(w http.ResponseWriter, r *http.Request) {
+				jr.controller.Recommendation.JobFeedback(w, r)
+			})
+			r.Delete("/{id}/feedback", func(w http.ResponseWriter, r *http.Request) {
+				jr.controller.Recommendation.DeleteJobFeedback(w, r)
+			})
+			r.Get("/{id}/gap", func(w http.ResponseWriter, r *http.Request) {
+				jr.controller.Job.SkillGap(w, r)
+			})
+		})
+		// Moderators manage every job, employers those of their company
+		r.Group(func(r chi.Router) {
+			r.Use(jr.middleware.Auth.LoadUser())
+			r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+				jr.controller.Job.CreateJob(w, r)
+			})
+			r.Put("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				jr.controller.Job.UpdateJob(w, r)
+			})
+			r.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				jr.controller.Job.DeleteJob(w, r)
+			})
+			r.Get("/{id}/applications", func(w http.ResponseWriter, r *http.Request) {
+				jr.controller.Application.ListJobApplications(w, r)
+			})
+		})
+	})
+	chi_router.With(jr.middleware.Auth.LoadUser(), jr.middleware.Auth.RequireRole(models.RoleLevelAdmin)).
+		Get("/admin/recommendations/feedback", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Recommendation.RecommendationFeedbackStats(w, r)
+		})
+	chi_router.Route("/admin/job-sources", func(r chi.Router) {
+		r.Use(jr.middleware.Auth.LoadUser())
+		r.Use(jr.middleware.Auth.RequireRole(models.RoleLevelAdmin))
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Ingest.ListJobSources(w, r)
+		})
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Ingest.CreateJobSource(w, r)
+		})
+		r.Put("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Ingest.UpdateJobSource(w, r)
+		})
+		r.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Ingest.DeleteJobSource(w, r)
+		})
+		r.Post("/{id}/run", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Ingest.RunJobSource(w, r)
+		})
+		r.Get("/{id}/runs", func(w http.ResponseWriter, r *http.Request) {
+			jr.controller.Ingest.ListJobImportRuns(w, r)
+		})
+	})
+}
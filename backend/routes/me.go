@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"Inquiro/controller"
+	"Inquiro/middlewares"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MeRoutes are about the logged in user.
+type MeRoutes struct {
+	controller controller.Controller
+	middleware middlewares.Middleware
+}
+
+func NewMeRoutes(controller controller.Controller, middleware middlewares.Middleware) MeRoutes {
+	return MeRoutes{
+		controller: controller,
+		middleware: middleware,
+	}
+}
+
+func (mr MeRoutes) RegisterMeRoutes(chi_router *chi.Mux) {
+	chi_router.Route("/me", func(r chi.Router) {
+		r.Use(mr.middleware.Auth.LoadUser())
+		r.Get("/quota", func(w http.ResponseWriter, r *http.Request) {
+			mr.controller.Quota.GetParseQuota(w, r)
+		})
+		r.Get("/alerts", func(w http.ResponseWriter, r *http.Request) {
+			mr.controller.Alert.ListJobAlerts(w, r)
+		})
+		r.Post("/alerts", func(w http.ResponseWriter, r *http.Request) {
+			mr.controller.Alert.CreateJobAlert(w, r)
+		})
+		r.Put("/alerts/{id}", func(w http.ResponseWriter, r *http.Request) {
+			mr.controller.Alert.UpdateJobAlert(w, r)
+		})
+		r.Delete("/alerts/{id}", func(w http.ResponseWriter, r *http.Request) {
+			mr.controller.Alert.DeleteJobAlert(w, r)
+		})
+		r.Route("/collections", func(r chi.Router) {
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.ListCollections(w, r)
+			})
+			r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.CreateCollection(w, r)
+			})
+			r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.GetCollection(w, r)
+			})
+			r.Put("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.UpdateCollection(w, r)
+			})
+			r.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.DeleteCollection(w, r)
+			})
+			r.Get("/{id}/jobs", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.ListBookmarks(w, r)
+			})
+			r.Post("/{id}/jobs", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.AddBookmark(w, r)
+			})
+			r.Put("/{id}/jobs/{bookmark}", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.UpdateBookmark(w, r)
+			})
+			r.Delete("/{id}/jobs/{bookmark}", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Bookmark.RemoveBookmark(w, r)
+			})
+		})
+	})
+}
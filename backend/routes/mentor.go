@@ -2,6 +2,7 @@ package routes
 
 import (
 	"Inquiro/controller"
+	"Inquiro/middlewares"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -9,11 +10,13 @@ import (
 
 type MentorRoutes struct {
 	controller controller.Controller
+	middleware middlewares.Middleware
 }
 
-func NewMentorRoutes(controller controller.Controller) MentorRoutes {
+func NewMentorRoutes(controller controller.Controller, middleware middlewares.Middleware) MentorRoutes {
 	return MentorRoutes{
 		controller: controller,
+		middleware: middleware,
 	}
 }
 
@@ -28,5 +31,27 @@ func (mr MentorRoutes) RegisterMentorRoutes(chi_router *chi.Mux) {
 		r.Post("/login", func(w http.ResponseWriter, r *http.Request) {
 			mr.controller.Mentor.MentorLogin(w, r)
 		})
+		r.Route("/skills", func(r chi.Router) {
+			r.Use(mr.middleware.Auth.LoadMentor())
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Mentor.GetMentorSkills(w, r)
+			})
+			r.Put("/", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Mentor.UpdateMentorSkills(w, r)
+			})
+		})
+		// Mentors only ever get the redacted copy of a shared resume
+		r.Route("/shares", func(r chi.Router) {
+			r.Use(mr.middleware.Auth.LoadMentor())
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Share.ListSharedResumes(w, r)
+			})
+			r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Share.GetSharedResume(w, r)
+			})
+			r.Get("/{id}/file", func(w http.ResponseWriter, r *http.Request) {
+				mr.controller.Share.DownloadSharedResume(w, r)
+			})
+		})
 	})
 }
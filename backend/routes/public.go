@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"Inquiro/controller"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// PublicRoutes are reachable without a session.
+type PublicRoutes struct {
+	controller controller.Controller
+}
+
+func NewPublicRoutes(controller controller.Controller) PublicRoutes {
+	return PublicRoutes{
+		controller: controller,
+	}
+}
+
+func (pr PublicRoutes) RegisterPublicRoutes(chi_router *chi.Mux) {
+	chi_router.Route("/public", func(r chi.Router) {
+		r.Get("/resumes/{token}", func(w http.ResponseWriter, r *http.Request) {
+			pr.controller.Link.ViewPublicResume(w, r)
+		})
+		r.Get("/resumes/{token}/pdf", func(w http.ResponseWriter, r *http.Request) {
+			pr.controller.Link.DownloadPublicResume(w, r)
+		})
+		r.Get("/alerts/unsubscribe/{token}", func(w http.ResponseWriter, r *http.Request) {
+			pr.controller.Alert.ConfirmUnsubscribe(w, r)
+		})
+		r.Post("/alerts/unsubscribe/{token}", func(w http.ResponseWriter, r *http.Request) {
+			pr.controller.Alert.UnsubscribeJobAlert(w, r)
+		})
+	})
+}
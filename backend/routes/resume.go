@@ -2,6 +2,7 @@ package routes
 
 import (
 	"Inquiro/controller"
+	"Inquiro/middlewares"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -9,18 +10,81 @@ import (
 
 type ResumeRoutes struct {
 	controller controller.Controller
+	middleware middlewares.Middleware
 }
 
-func NewResumeRoutes(controller controller.Controller) ResumeRoutes {
+func NewResumeRoutes(controller controller.Controller, middleware middlewares.Middleware) ResumeRoutes {
 	return ResumeRoutes{
 		controller: controller,
+		middleware: middleware,
 	}
 }
 
 func (rr ResumeRoutes) RegisterResumeRoutes(chi_router *chi.Mux) {
 	chi_router.Route("/resume", func(r chi.Router) {
+		r.Use(rr.middleware.Auth.LoadUser())
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.ListResumes(w, r)
+		})
 		r.Post("/upload", func(w http.ResponseWriter, r *http.Request) {
 			rr.controller.Resume.ProcessResume(w, r)
 		})
+		r.Post("/jobs", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.StartParseJob(w, r)
+		})
+		r.Get("/jobs/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.ParseJobEvents(w, r)
+		})
+		r.Post("/relevancy/batch", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.BatchRelevancy(w, r)
+		})
+		r.Post("/import", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Export.ImportResume(w, r)
+		})
+		r.Get("/export/themes", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Export.ListExportThemes(w, r)
+		})
+		r.Get("/documents", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.ListResumeDocuments(w, r)
+		})
+		r.Get("/documents/{id}/diff", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.DiffResumeVersions(w, r)
+		})
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.GetResume(w, r)
+		})
+		r.Patch("/{id}/profile", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.UpdateResumeProfile(w, r)
+		})
+		r.Put("/{id}/primary", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.SetPrimaryResume(w, r)
+		})
+		r.Get("/{id}/export", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Export.ExportResume(w, r)
+		})
+		r.Get("/{id}/ats-report", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Resume.ATSReport(w, r)
+		})
+		r.Post("/{id}/shares", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Share.ShareResume(w, r)
+		})
+		r.Get("/{id}/shares", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Share.ListResumeShares(w, r)
+		})
+		r.Delete("/{id}/shares/{shareId}", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Share.RevokeResumeShare(w, r)
+		})
+		r.Post("/{id}/links", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Link.CreateResumeLink(w, r)
+		})
+		r.Get("/{id}/links", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Link.ListResumeLinks(w, r)
+		})
+		r.Delete("/{id}/links/{linkId}", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Link.RevokeResumeLink(w, r)
+		})
+		r.Get("/{id}/links/{linkId}/views", func(w http.ResponseWriter, r *http.Request) {
+			rr.controller.Link.ResumeLinkStats(w, r)
+		})
 	})
 }
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"Inquiro/controller"
+	"Inquiro/middlewares"
+	"Inquiro/models"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SkillRoutes struct {
+	controller controller.Controller
+	middleware middlewares.Middleware
+}
+
+func NewSkillRoutes(controller controller.Controller, middleware middlewares.Middleware) SkillRoutes {
+	return SkillRoutes{
+		controller: controller,
+		middleware: middleware,
+	}
+}
+
+func (sr SkillRoutes) RegisterSkillRoutes(chi_router *chi.Mux) {
+	chi_router.Route("/skills", func(r chi.Router) {
+		r.Get("/suggest", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.SuggestSkills(w, r)
+		})
+		r.With(sr.middleware.Auth.LoadUser()).Post("/normalize", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.NormalizeSkills(w, r)
+		})
+	})
+	chi_router.Route("/titles", func(r chi.Router) {
+		r.Get("/suggest", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Title.SuggestTitles(w, r)
+		})
+	})
+	chi_router.Route("/admin/skills", func(r chi.Router) {
+		r.Use(sr.middleware.Auth.LoadUser())
+		r.Use(sr.middleware.Auth.RequireRole(models.RoleLevelAdmin))
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.ListSkills(w, r)
+		})
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.CreateSkill(w, r)
+		})
+		r.Post("/import", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.ImportSkills(w, r)
+		})
+		r.Get("/unknown", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.ListUnknownSkills(w, r)
+		})
+		r.Put("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.UpdateSkill(w, r)
+		})
+		r.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			sr.controller.Skill.DeleteSkill(w, r)
+		})
+	})
+}
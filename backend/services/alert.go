@@ -0,0 +1,217 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/utils/mailer"
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AlertDigestSize is how many jobs a digest lists; the rest are linked to.
+var AlertDigestSize = 20
+
+// alertFrequencies completes "You receive this email ..." in digests.
+var alertFrequencies = map[string]string{
+	models.AlertInstant: "whenever new jobs match",
+	models.AlertDaily:   "once a day",
+	models.AlertWeekly:  "once a week",
+}
+
+type AlertServices struct {
+	repo        repositories.Storage
+	logger      *zap.SugaredLogger
+	mailer      mailer.Client
+	frontendURL string
+	apiURL      string
+}
+
+type digestJob struct {
+	Title    string
+	Company  string
+	Location string
+	URL      string
+}
+
+type digest struct {
+	Username       string
+	AlertName      string
+	Frequency      string
+	Jobs           []digestJob
+	Total          int
+	More           int
+	SearchURL      string
+	UnsubscribeURL string
+}
+
+func alertFromPayload(payload models.JobAlertPayload) *models.JobAlert {
+	filter := payload.Filter
+	filter.Position = strings.TrimSpace(filter.Position)
+	filter.Country = strings.TrimSpace(filter.Country)
+	filter.Remote = strings.ToLower(strings.TrimSpace(filter.Remote))
+	filter.Experience = strings.TrimSpace(filter.Experience)
+	if len(filter.RequiredSkills) == 0 {
+		for _, skill := range strings.Split(filter.Skills, ",") {
+			if skill = strings.TrimSpace(skill); skill != "" {
+				filter.RequiredSkills = append(filter.RequiredSkills, skill)
+			}
+		}
+	}
+	filter.Skills = strings.Join(filter.RequiredSkills, ",")
+	filter.Paginatin = nil
+	return &models.JobAlert{
+		Name:           strings.TrimSpace(payload.Name),
+		Query:          strings.TrimSpace(payload.Query),
+		ExperienceBand: payload.ExperienceBand,
+		Filter:         filter,
+		Frequency:      payload.Frequency,
+		Active:         payload.Active == nil || *payload.Active,
+	}
+}
+
+// CreateAlert saves the search. Its first digest holds the jobs posted from
+// now on.
+func (a AlertServices) CreateAlert(ctx context.Context, userID uuid.UUID, payload models.JobAlertPayload) (*models.JobAlert, error) {
+	alert := alertFromPayload(payload)
+	alert.UserID = userID
+	if err := a.repo.Alert.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (a AlertServices) UpdateAlert(ctx context.Context, id uuid.UUID, userID uuid.UUID, payload models.JobAlertPayload) (*models.JobAlert, error) {
+	alert := alertFromPayload(payload)
+	alert.ID = id
+	alert.UserID = userID
+	if err := a.repo.Alert.Update(ctx, alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (a AlertServices) DeleteAlert(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return a.repo.Alert.Delete(ctx, id, userID)
+}
+
+func (a AlertServices) ListAlerts(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobAlert, error) {
+	return a.repo.Alert.ListByUser(ctx, userID, pagination)
+}
+
+func (a AlertServices) Unsubscribe(ctx context.Context, token string) (*models.JobAlert, error) {
+	return a.repo.Alert.Unsubscribe(ctx, token)
+}
+
+// Schedule mails the digests that are due every tick until ctx is done.
+func (a AlertServices) Schedule(ctx context.Context, tick time.Duration) {
+	schedule(ctx, tick, a.runDue)
+}
+
+func (a AlertServices) runDue(ctx context.Context) {
+	alerts, err := a.repo.Alert.ClaimDue(ctx)
+	if err != nil {
+		a.logger.Errorw("Could not claim the due job alerts", "error : ", err.Error())
+		return
+	}
+	for i := range alerts {
+		if err := a.sendDigest(ctx, &alerts[i]); err != nil {
+			a.logger.Errorw("Could not send the job alert", "alert : ", alerts[i].ID, "error : ", err.Error())
+		}
+	}
+}
+
+// sendDigest mails the jobs posted since the alert's last digest. Nothing is
+// mailed when there are none. Jobs are only recorded as sent once the mail
+// is out, so a failed digest is retried with the next one. Each digest gets
+// its own unsubscribe token, which mail clients can also use in one click.
+func (a AlertServices) sendDigest(ctx context.Context, alert *models.JobAlert) error {
+	// last_run_at keeps whole seconds; truncating keeps jobs created in the
+	// rest of this second for the next digest
+	ranAt := time.Now().Truncate(time.Second)
+	query := alert.SearchQuery()
+	if err := resolveRequiredSkills(ctx, a.repo, &query.Filter); err != nil {
+		return err
+	}
+	jobs, total, err := a.repo.Alert.NewMatches(ctx, alert, query, AlertDigestSize)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return a.repo.Alert.RecordSent(ctx, alert.ID, nil, ranAt)
+	}
+	user, err := a.repo.Users.GetByID(ctx, alert.UserID)
+	if err != nil {
+		return err
+	}
+
+	token, err := newLinkToken()
+	if err != nil {
+		return err
+	}
+	if err := a.repo.Alert.AddUnsubscribeToken(ctx, alert.ID, token); err != nil {
+		return err
+	}
+	unsubscribeURL := strings.TrimSuffix(a.apiURL, "/") + "/api/public/alerts/unsubscribe/" + token
+
+	base := strings.TrimSuffix(a.frontendURL, "/")
+	data := digest{
+		Username:       user.Username,
+		AlertName:      alert.Name,
+		Frequency:      alertFrequencies[alert.Frequency],
+		Total:          total,
+		More:           total - len(jobs),
+		SearchURL:      base + "/jobs/search?" + alertSearchParams(alert).Encode(),
+		UnsubscribeURL: unsubscribeURL,
+	}
+	ids := make([]uuid.UUID, 0, len(jobs))
+	for _, job := range jobs {
+		places := []string{}
+		for _, place := range []string{job.City, job.Country} {
+			if place != "" {
+				places = append(places, place)
+			}
+		}
+		if job.Remote != models.RemoteOnsite {
+			places = append(places, job.Remote)
+		}
+		data.Jobs = append(data.Jobs, digestJob{
+			Title:    job.Title,
+			Company:  job.Company,
+			Location: strings.Join(places, ", "),
+			URL:      base + "/jobs/" + job.ID.String(),
+		})
+		ids = append(ids, job.ID)
+	}
+	headers := map[string]string{
+		"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+	if err := a.mailer.SendWithHeaders(mailer.JobAlertTemplate, user.Username, []string{user.Email}, data, headers); err != nil {
+		return err
+	}
+	return a.repo.Alert.RecordSent(ctx, alert.ID, ids, ranAt)
+}
+
+// alertSearchParams are the query parameters of the job search the alert
+// runs, for linking to the full results.
+func alertSearchParams(alert *models.JobAlert) url.Values {
+	params := url.Values{}
+	set := func(key string, value string) {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	set("q", alert.Query)
+	set("experience_band", alert.ExperienceBand)
+	set("position", alert.Filter.Position)
+	set("country", alert.Filter.Country)
+	set("remote", alert.Filter.Remote)
+	set("experience", alert.Filter.Experience)
+	set("skills", alert.Filter.Skills)
+	return params
+}
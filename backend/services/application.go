@@ -0,0 +1,200 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/utils/mailer"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidTransition = errors.New("application cannot move to this status")
+
+// applicationMessages tell the candidate what a status set by the hiring
+// side means.
+var applicationMessages = map[string]string{
+	models.ApplicationScreening: "Your application is being screened.",
+	models.ApplicationInterview: "You have been invited to interview.",
+	models.ApplicationOffer:     "You have received an offer.",
+	models.ApplicationRejected:  "Your application was not successful this time.",
+}
+
+type ApplicationServices struct {
+	repo        repositories.Storage
+	logger      *zap.SugaredLogger
+	mailer      mailer.Client
+	frontendURL string
+}
+
+type applicationNotice struct {
+	Username string
+	Heading  string
+	Message  string
+	Note     string
+	URL      string
+	LinkText string
+}
+
+// Apply sends one of the user's resume versions to the job.
+func (a ApplicationServices) Apply(ctx context.Context, userID uuid.UUID, payload models.ApplicationPayload) (*models.Application, error) {
+	job, err := a.repo.Job.GetByID(ctx, payload.JobID)
+	if err != nil {
+		return nil, err
+	}
+	resume, err := a.repo.Resume.GetByID(ctx, payload.ResumeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	application := &models.Application{
+		UserID:        userID,
+		JobID:         job.ID,
+		JobTitle:      job.Title,
+		Company:       job.Company,
+		ResumeID:      resume.ID,
+		ResumeVersion: resume.Version,
+		CoverNote:     strings.TrimSpace(payload.CoverNote),
+	}
+	if err := a.repo.Application.Create(ctx, application); err != nil {
+		return nil, err
+	}
+	go a.notify(context.WithoutCancel(ctx), job, application, "")
+	return application, nil
+}
+
+// GetApplication returns the application to its candidate, to moderators
+// and to the employers of the job's company.
+func (a ApplicationServices) GetApplication(ctx context.Context, id uuid.UUID, user *models.User) (*models.Application, error) {
+	application, err := a.repo.Application.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if application.UserID == user.ID {
+		return application, nil
+	}
+	err = a.authorize(ctx, user, application)
+	switch {
+	case errors.Is(err, ErrNotJobManager), errors.Is(err, ErrCompanyUnverified), errors.Is(err, ErrNotCompanyJob):
+		return nil, repositories.ErrApplicationNotFound
+	case err != nil:
+		return nil, err
+	}
+	return application, nil
+}
+
+// authorize checks the user manages the job the application is for.
+func (a ApplicationServices) authorize(ctx context.Context, user *models.User, application *models.Application) error {
+	job, err := a.repo.Job.GetByID(ctx, application.JobID)
+	if err != nil {
+		return err
+	}
+	_, err = authorizeJob(ctx, a.repo, user, job)
+	return err
+}
+
+func (a ApplicationServices) ListApplications(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Application, error) {
+	return a.repo.Application.ListByUser(ctx, userID, pagination)
+}
+
+// ListJobApplications returns a page of the applications to a job the user
+// manages.
+func (a ApplicationServices) ListJobApplications(ctx context.Context, jobID uuid.UUID, user *models.User, status string, pagination *models.PaginatedQuery) ([]models.Application, error) {
+	job, err := a.repo.Job.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeJob(ctx, a.repo, user, job); err != nil {
+		return nil, err
+	}
+	return a.repo.Application.ListByJob(ctx, jobID, status, pagination)
+}
+
+// Withdraw closes the candidate's own application.
+func (a ApplicationServices) Withdraw(ctx context.Context, id uuid.UUID, userID uuid.UUID, note string) (*models.Application, error) {
+	application, err := a.repo.Application.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if application.UserID != userID {
+		return nil, repositories.ErrApplicationNotFound
+	}
+	return a.transition(ctx, application, models.ApplicationWithdrawn, userID, note)
+}
+
+// SetStatus moves the application along the pipeline for the hiring side:
+// moderators and the employers of the job's company.
+func (a ApplicationServices) SetStatus(ctx context.Context, id uuid.UUID, user *models.User, payload models.ApplicationStatusPayload) (*models.Application, error) {
+	application, err := a.repo.Application.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authorize(ctx, user, application); err != nil {
+		return nil, err
+	}
+	return a.transition(ctx, application, payload.Status, user.ID, payload.Note)
+}
+
+func (a ApplicationServices) transition(ctx context.Context, application *models.Application, status string, changedBy uuid.UUID, note string) (*models.Application, error) {
+	if !models.CanTransition(application.Status, status) {
+		return nil, ErrInvalidTransition
+	}
+	note = strings.TrimSpace(note)
+	err := a.repo.Application.SetStatus(ctx, application, models.ApplicationEvent{
+		FromStatus: application.Status,
+		ToStatus:   status,
+		ChangedBy:  &changedBy,
+		Note:       note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	job, err := a.repo.Job.GetByID(ctx, application.JobID)
+	if err != nil {
+		a.logger.Errorw("Could not read the job of the application", "application : ", application.ID, "error : ", err.Error())
+		return application, nil
+	}
+	go a.notify(context.WithoutCancel(ctx), job, application, note)
+	return application, nil
+}
+
+// notify mails the other side about the application's current status:
+// whoever posted the job when the candidate applied or withdrew, the
+// candidate otherwise. Failures are only logged, the change is already made.
+func (a ApplicationServices) notify(ctx context.Context, job *models.Job, application *models.Application, note string) {
+	base := strings.TrimSuffix(a.frontendURL, "/")
+	notice := applicationNotice{Note: note}
+	recipient := application.UserID
+	switch application.Status {
+	case models.ApplicationApplied, models.ApplicationWithdrawn:
+		if job.CreatedBy == nil {
+			return
+		}
+		recipient = *job.CreatedBy
+		notice.Heading = "New application"
+		notice.Message = "A candidate applied to " + job.Title + " at " + job.Company + "."
+		if application.Status == models.ApplicationWithdrawn {
+			notice.Heading = "Application withdrawn"
+			notice.Message = "A candidate withdrew their application to " + job.Title + " at " + job.Company + "."
+		}
+		notice.URL = base + "/jobs/" + job.ID.String() + "/applications"
+		notice.LinkText = "View applications"
+	default:
+		notice.Heading = job.Title + " at " + job.Company
+		notice.Message = applicationMessages[application.Status]
+		notice.URL = base + "/applications/" + application.ID.String()
+		notice.LinkText = "View your application"
+	}
+
+	user, err := a.repo.Users.GetByID(ctx, recipient)
+	if err != nil {
+		a.logger.Errorw("Could not read the user to notify", "application : ", application.ID, "error : ", err.Error())
+		return
+	}
+	notice.Username = user.Username
+	if err := a.mailer.Send(mailer.ApplicationStatusTemplate, user.Username, []string{user.Email}, notice); err != nil {
+		a.logger.Errorw("Could not send the application notification", "application : ", application.ID, "error : ", err.Error())
+	}
+}
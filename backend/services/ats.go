@@ -0,0 +1,44 @@
+package services
+
+import (
+	"Inquiro/ats"
+	"Inquiro/models"
+	"Inquiro/parser"
+	"Inquiro/repositories"
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ATSServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+	// keywords finds the skills named in a job description
+	keywords *parser.LocalParser
+}
+
+// Report analyses the text of the resume. The target keywords are the given
+// ones plus the skills found in the job description.
+func (a ATSServices) Report(ctx context.Context, id uuid.UUID, userID uuid.UUID, target models.ATSTarget) (*ats.Report, error) {
+	resume, err := a.repo.Resume.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	text := resume.ExtractedText
+	if text == "" {
+		// Image only PDFs end up here and are reported as unreadable
+		text, _ = parser.ExtractText(resume.FileContent)
+	}
+	keywords := append([]string{}, target.Keywords...)
+	if target.JobDescription != "" {
+		parsed, err := a.keywords.Parse(ctx, "job.txt", []byte(target.JobDescription))
+		if err != nil {
+			a.logger.Infow("Could not read keywords from the job description", "error : ", err.Error())
+		} else {
+			keywords = append(keywords, parsed.Skills...)
+		}
+	}
+	report := ats.Analyze(text, keywords)
+	return &report, nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/utils/mailer"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// BookmarkExpiryDays is how long after it was posted a bookmarked job is
+	// shown as expired.
+	BookmarkExpiryDays = 60
+	// BookmarkReminderBatch is how many reminders are mailed per tick.
+	BookmarkReminderBatch = 100
+)
+
+var ErrReminderInPast = errors.New("reminder must be in the future")
+
+type BookmarkServices struct {
+	repo        repositories.Storage
+	logger      *zap.SugaredLogger
+	mailer      mailer.Client
+	frontendURL string
+}
+
+func (b BookmarkServices) CreateCollection(ctx context.Context, userID uuid.UUID, payload models.JobCollectionPayload) (*models.JobCollection, error) {
+	collection := &models.JobCollection{
+		UserID:      userID,
+		Name:        strings.TrimSpace(payload.Name),
+		Description: strings.TrimSpace(payload.Description),
+	}
+	if err := b.repo.Bookmark.CreateCollection(ctx, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (b BookmarkServices) UpdateCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID, payload models.JobCollectionPayload) (*models.JobCollection, error) {
+	collection := &models.JobCollection{
+		ID:          id,
+		UserID:      userID,
+		Name:        strings.TrimSpace(payload.Name),
+		Description: strings.TrimSpace(payload.Description),
+	}
+	if err := b.repo.Bookmark.UpdateCollection(ctx, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (b BookmarkServices) DeleteCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return b.repo.Bookmark.DeleteCollection(ctx, id, userID)
+}
+
+func (b BookmarkServices) GetCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.JobCollection, error) {
+	return b.repo.Bookmark.GetCollection(ctx, id, userID)
+}
+
+func (b BookmarkServices) ListCollections(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobCollection, error) {
+	return b.repo.Bookmark.ListCollections(ctx, userID, pagination)
+}
+
+// ListBookmarks returns a page of the collection's bookmarks. Bookmarks of
+// expired and removed jobs are kept and marked as such.
+func (b BookmarkServices) ListBookmarks(ctx context.Context, collectionID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Bookmark, error) {
+	if _, err := b.repo.Bookmark.GetCollection(ctx, collectionID, userID); err != nil {
+		return nil, err
+	}
+	bookmarks, err := b.repo.Bookmark.List(ctx, collectionID, pagination)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bookmarks {
+		setBookmarkStatus(&bookmarks[i])
+	}
+	return bookmarks, nil
+}
+
+func (b BookmarkServices) AddBookmark(ctx context.Context, collectionID uuid.UUID, userID uuid.UUID, payload models.BookmarkPayload) (*models.Bookmark, error) {
+	if payload.RemindAt != nil && !payload.RemindAt.After(time.Now()) {
+		return nil, ErrReminderInPast
+	}
+	job, err := b.repo.Job.GetByID(ctx, payload.JobID)
+	if err != nil {
+		return nil, err
+	}
+	bookmark := &models.Bookmark{
+		CollectionID: collectionID,
+		UserID:       userID,
+		Job:          job,
+		Note:         strings.TrimSpace(payload.Note),
+		RemindAt:     payload.RemindAt,
+	}
+	if err := b.repo.Bookmark.Add(ctx, bookmark); err != nil {
+		return nil, err
+	}
+	setBookmarkStatus(bookmark)
+	return bookmark, nil
+}
+
+func (b BookmarkServices) UpdateBookmark(ctx context.Context, id uuid.UUID, collectionID uuid.UUID, userID uuid.UUID, payload models.BookmarkUpdatePayload) (*models.Bookmark, error) {
+	if payload.RemindAt != nil && !payload.RemindAt.After(time.Now()) {
+		return nil, ErrReminderInPast
+	}
+	bookmark := &models.Bookmark{
+		ID:           id,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Note:         strings.TrimSpace(payload.Note),
+		RemindAt:     payload.RemindAt,
+	}
+	if err := b.repo.Bookmark.Update(ctx, bookmark); err != nil {
+		return nil, err
+	}
+	setBookmarkStatus(bookmark)
+	return bookmark, nil
+}
+
+func (b BookmarkServices) RemoveBookmark(ctx context.Context, id uuid.UUID, collectionID uuid.UUID, userID uuid.UUID) error {
+	return b.repo.Bookmark.Remove(ctx, id, collectionID, userID)
+}
+
+// setBookmarkStatus tells whether the bookmarked job is still open.
+func setBookmarkStatus(bookmark *models.Bookmark) {
+	switch {
+	case bookmark.Job == nil:
+		bookmark.Status = models.BookmarkRemoved
+	case bookmark.Job.PostedAt.Before(time.Now().AddDate(0, 0, -BookmarkExpiryDays)):
+		bookmark.Status = models.BookmarkExpired
+	default:
+		bookmark.Status = models.BookmarkActive
+	}
+}
+
+type bookmarkReminder struct {
+	Username string
+	Heading  string
+	Message  string
+	Note     string
+	URL      string
+	LinkText string
+}
+
+// Schedule mails the bookmark reminders that are due every tick until ctx
+// is done.
+func (b BookmarkServices) Schedule(ctx context.Context, tick time.Duration) {
+	schedule(ctx, tick, b.runDue)
+}
+
+func (b BookmarkServices) runDue(ctx context.Context) {
+	bookmarks, err := b.repo.Bookmark.ClaimDueReminders(ctx, BookmarkReminderBatch)
+	if err != nil {
+		b.logger.Errorw("Could not claim the due bookmark reminders", "error : ", err.Error())
+		return
+	}
+	for i := range bookmarks {
+		if err := b.remind(ctx, &bookmarks[i]); err != nil {
+			b.logger.Errorw("Could not send the bookmark reminder", "bookmark : ", bookmarks[i].ID, "error : ", err.Error())
+		}
+	}
+}
+
+// remind mails the reminder of the bookmark. Reminders are claimed before
+// they are mailed, so a failed one is not retried.
+func (b BookmarkServices) remind(ctx context.Context, bookmark *models.Bookmark) error {
+	user, err := b.repo.Users.GetByID(ctx, bookmark.UserID)
+	if err != nil {
+		return err
+	}
+	setBookmarkStatus(bookmark)
+	base := strings.TrimSuffix(b.frontendURL, "/")
+	reminder := bookmarkReminder{
+		Username: user.Username,
+		Heading:  "Reminder: " + bookmark.JobTitle + " at " + bookmark.Company,
+		Message:  "You asked to be reminded of this job.",
+		Note:     bookmark.Note,
+		URL:      base + "/collections/" + bookmark.CollectionID.String(),
+		LinkText: "View your collection",
+	}
+	if bookmark.Status != models.BookmarkActive {
+		reminder.Message = "You asked to be reminded of this job, which is no longer open."
+	}
+	if bookmark.Job != nil {
+		reminder.URL = base + "/jobs/" + bookmark.Job.ID.String()
+		reminder.LinkText = "View the job"
+	}
+	return b.mailer.Send(mailer.BookmarkReminderTemplate, user.Username, []string{user.Email}, reminder)
+}
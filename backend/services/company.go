@@ -0,0 +1,268 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/utils/mailer"
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidCompanyName = errors.New("company name must contain letters or digits")
+	ErrNotCompanyOwner    = errors.New("only company owners can do this")
+	ErrNotJobManager      = errors.New("only moderators and employers can manage jobs")
+	ErrCompanyUnverified  = errors.New("company is not verified yet")
+	ErrNotCompanyJob      = errors.New("job does not belong to your company")
+)
+
+// CompanyInviteExpiry is how long an invite to join a company can be
+// accepted.
+var CompanyInviteExpiry = 7 * 24 * time.Hour
+
+type CompanyServices struct {
+	repo        repositories.Storage
+	logger      *zap.SugaredLogger
+	mailer      mailer.Client
+	frontendURL string
+}
+
+type companyInvite struct {
+	Heading  string
+	Message  string
+	Expires  string
+	URL      string
+	LinkText string
+}
+
+// companySlug makes the company's profile path from its name, "Acme & Co."
+// becoming "acme-co".
+func companySlug(name string) string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return slug.String()
+}
+
+// CreateCompany registers a company owned by the user, who becomes an
+// employer. It posts jobs once an admin has verified it.
+func (c CompanyServices) CreateCompany(ctx context.Context, userID uuid.UUID, payload models.CompanyPayload) (*models.Company, error) {
+	company := &models.Company{
+		Name:        strings.TrimSpace(payload.Name),
+		Description: strings.TrimSpace(payload.Description),
+		Website:     strings.TrimSpace(payload.Website),
+		Location:    strings.TrimSpace(payload.Location),
+	}
+	company.Slug = companySlug(company.Name)
+	if company.Slug == "" {
+		return nil, ErrInvalidCompanyName
+	}
+	if err := c.repo.Company.Create(ctx, company, userID); err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+// GetCompany returns the public profile of a verified company.
+func (c CompanyServices) GetCompany(ctx context.Context, slug string) (*models.Company, error) {
+	company, err := c.repo.Company.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !company.Verified {
+		return nil, repositories.ErrCompanyNotFound
+	}
+	return company, nil
+}
+
+// ListCompanyJobs returns a page of the jobs of a verified company, newest
+// first.
+func (c CompanyServices) ListCompanyJobs(ctx context.Context, slug string, filter *models.JobFilter) ([]models.Job, error) {
+	company, err := c.GetCompany(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveRequiredSkills(ctx, c.repo, filter); err != nil {
+		return nil, err
+	}
+	filter.CompanyID = &company.ID
+	return c.repo.Job.List(ctx, filter)
+}
+
+// MyCompany returns the company the user belongs to, verified or not.
+func (c CompanyServices) MyCompany(ctx context.Context, userID uuid.UUID) (*models.Company, error) {
+	return c.repo.Company.ForUser(ctx, userID)
+}
+
+// ownedCompany returns the user's company when they own it.
+func (c CompanyServices) ownedCompany(ctx context.Context, userID uuid.UUID) (*models.Company, error) {
+	company, err := c.repo.Company.ForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if company.MemberRole != models.CompanyOwner {
+		return nil, ErrNotCompanyOwner
+	}
+	return company, nil
+}
+
+func (c CompanyServices) UpdateCompany(ctx context.Context, userID uuid.UUID, payload models.CompanyPayload) (*models.Company, error) {
+	company, err := c.ownedCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	company.Name = strings.TrimSpace(payload.Name)
+	company.Description = strings.TrimSpace(payload.Description)
+	company.Website = strings.TrimSpace(payload.Website)
+	company.Location = strings.TrimSpace(payload.Location)
+	if err := c.repo.Company.Update(ctx, company); err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+func (c CompanyServices) ListMembers(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyMember, error) {
+	company, err := c.repo.Company.ForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.repo.Company.Members(ctx, company.ID, pagination)
+}
+
+// InviteMember mails an invite to join the owner's company to the payload's
+// email. Nobody is added until the invite is accepted.
+func (c CompanyServices) InviteMember(ctx context.Context, userID uuid.UUID, payload models.CompanyInvitePayload) (*models.CompanyInvite, error) {
+	company, err := c.ownedCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, err
+	}
+	invite := &models.CompanyInvite{
+		CompanyID: company.ID,
+		Email:     strings.ToLower(strings.TrimSpace(payload.Email)),
+		Role:      payload.Role,
+		InvitedBy: &userID,
+		ExpiresAt: time.Now().Add(CompanyInviteExpiry),
+	}
+	if err := c.repo.Company.Invite(ctx, invite, token); err != nil {
+		return nil, err
+	}
+	data := companyInvite{
+		Heading:  "Join " + company.Name,
+		Message:  "You are invited to join " + company.Name + " as " + payload.Role + " to manage its job postings.",
+		Expires:  invite.ExpiresAt.Format("January 2, 2006"),
+		URL:      strings.TrimSuffix(c.frontendURL, "/") + "/employer/invites/" + token,
+		LinkText: "Accept the invitation",
+	}
+	if err := c.mailer.Send(mailer.CompanyInviteTemplate, invite.Email, []string{invite.Email}, data); err != nil {
+		// Nobody received the token, so the invite could never be accepted
+		if err := c.repo.Company.RevokeInvite(ctx, company.ID, invite.ID); err != nil {
+			c.logger.Errorw("Could not delete the unsent company invite", "invite : ", invite.ID, "error : ", err.Error())
+		}
+		return nil, err
+	}
+	return invite, nil
+}
+
+// ListInvites returns a page of the pending invites of the owner's company.
+func (c CompanyServices) ListInvites(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyInvite, error) {
+	company, err := c.ownedCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.repo.Company.Invites(ctx, company.ID, pagination)
+}
+
+func (c CompanyServices) RevokeInvite(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	company, err := c.ownedCompany(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return c.repo.Company.RevokeInvite(ctx, company.ID, id)
+}
+
+// AcceptInvite makes the user a member of the company they were invited to.
+func (c CompanyServices) AcceptInvite(ctx context.Context, user *models.User, token string) (*models.CompanyMember, error) {
+	return c.repo.Company.AcceptInvite(ctx, token, user)
+}
+
+// RemoveMember removes a member from the company. Owners remove anyone,
+// other members only themselves.
+func (c CompanyServices) RemoveMember(ctx context.Context, userID uuid.UUID, memberID uuid.UUID) error {
+	company, err := c.repo.Company.ForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && company.MemberRole != models.CompanyOwner {
+		return ErrNotCompanyOwner
+	}
+	return c.repo.Company.RemoveMember(ctx, company.ID, memberID)
+}
+
+// ListCompanies returns a page of the companies for admins, only the
+// verified or unverified ones when verified is set.
+func (c CompanyServices) ListCompanies(ctx context.Context, verified *bool, pagination *models.PaginatedQuery) ([]models.Company, error) {
+	return c.repo.Company.List(ctx, verified, pagination)
+}
+
+// VerifyCompany marks the company as verified by the admin, or withdraws
+// its verification, which stops its members from managing its jobs.
+func (c CompanyServices) VerifyCompany(ctx context.Context, id uuid.UUID, adminID uuid.UUID, verified bool) (*models.Company, error) {
+	var verifiedBy *uuid.UUID
+	if verified {
+		verifiedBy = &adminID
+	}
+	return c.repo.Company.SetVerified(ctx, id, verifiedBy)
+}
+
+// jobCompany returns the company whose jobs the user manages: nil for
+// moderators, who manage every job, and the user's verified company for
+// employers.
+func jobCompany(ctx context.Context, repo repositories.Storage, user *models.User) (*models.Company, error) {
+	if user.Role.Level >= models.RoleLevelModerator {
+		return nil, nil
+	}
+	company, err := repo.Company.ForUser(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCompanyNotFound) {
+			return nil, ErrNotJobManager
+		}
+		return nil, err
+	}
+	if !company.Verified {
+		return nil, ErrCompanyUnverified
+	}
+	return company, nil
+}
+
+// authorizeJob checks the user manages the job, returning their company as
+// jobCompany does.
+func authorizeJob(ctx context.Context, repo repositories.Storage, user *models.User, job *models.Job) (*models.Company, error) {
+	company, err := jobCompany(ctx, repo, user)
+	if err != nil {
+		return nil, err
+	}
+	if company != nil && (job.CompanyID == nil || *job.CompanyID != company.ID) {
+		return nil, ErrNotCompanyJob
+	}
+	return company, nil
+}
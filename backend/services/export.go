@@ -0,0 +1,114 @@
+package services
+
+import (
+	"Inquiro/export"
+	"Inquiro/models"
+	"Inquiro/parser"
+	"Inquiro/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrUnknownExportFormat = errors.New("unknown export format, use json, html or pdf")
+
+type ExportServices struct {
+	repo    repositories.Storage
+	logger  *zap.SugaredLogger
+	resumes ResumeServices
+}
+
+// Export renders the edited profile of the resume as a JSON Resume document,
+// themed HTML or PDF.
+func (e ExportServices) Export(ctx context.Context, user *models.User, resumeID uuid.UUID, format string, theme string) (*models.ExportFile, error) {
+	selected, err := export.LookupTheme(theme)
+	if err != nil {
+		return nil, err
+	}
+	resume, err := e.repo.Resume.GetByID(ctx, resumeID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := e.skillCategories(ctx, resume.Profile.Skills)
+	if err != nil {
+		return nil, err
+	}
+	doc := export.FromResume(user, resume, categories)
+	base := strings.TrimSuffix(resume.FileName, path.Ext(resume.FileName))
+
+	switch strings.ToLower(format) {
+	case "", "json":
+		content, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &models.ExportFile{FileName: base + ".json", ContentType: "application/json", Content: content}, nil
+	case "html":
+		content, err := export.RenderHTML(doc, selected)
+		if err != nil {
+			e.logger.Errorw("Could not render resume html", "theme", selected.Name, "error : ", err.Error())
+			return nil, err
+		}
+		return &models.ExportFile{FileName: base + ".html", ContentType: "text/html; charset=utf-8", Content: content}, nil
+	case "pdf":
+		return &models.ExportFile{FileName: base + ".pdf", ContentType: "application/pdf", Content: export.RenderPDF(doc, selected)}, nil
+	}
+	return nil, ErrUnknownExportFormat
+}
+
+func (e ExportServices) skillCategories(ctx context.Context, skills []string) (map[string]string, error) {
+	keys := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		keys = append(keys, models.SkillKey(skill))
+	}
+	resolved, err := e.repo.Skill.Resolve(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[string]string, len(skills))
+	for _, skill := range skills {
+		if known, ok := resolved[models.SkillKey(skill)]; ok {
+			categories[skill] = known.Category
+		}
+	}
+	return categories, nil
+}
+
+// Import stores a JSON Resume document as a new resume version. The profile
+// is read from the document itself, so the job service is not involved.
+func (e ExportServices) Import(ctx context.Context, user *models.User, documentID uuid.UUID, title string, data []byte) (*models.Resume, error) {
+	doc, err := export.ParseJSONResume(data)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := doc.Profile(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = doc.Basics.Label
+	}
+	return e.resumes.StoreResume(ctx, models.ResumeUpload{
+		UserID:      user.ID,
+		DocumentID:  documentID,
+		Title:       title,
+		FileName:    "resume.json",
+		ContentType: "application/json",
+		Content:     data,
+		Text:        doc.Text(),
+	}, &parser.ParsedResume{
+		JobTitles:  profile.JobTitles,
+		Skills:     profile.Skills,
+		Experience: profile.Experience,
+	})
+}
+
+func (e ExportServices) Themes() []export.Theme {
+	return export.Themes()
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"Inquiro/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// GapMentorsPerSkill is how many mentors are suggested for a skill gap.
+var GapMentorsPerSkill = 3
+
+// SkillGap compares the skills and experience the job asks for with those of
+// the resume, the user's primary resume when resumeID is uuid.Nil. A skill
+// missing from the resume is adjacent when the resume lists another skill of
+// its catalog category. Mentors are suggested for every skill not present.
+func (j JobServices) SkillGap(ctx context.Context, jobID uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) (*models.SkillGap, error) {
+	job, err := j.repo.Job.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	var resume *models.Resume
+	if resumeID == uuid.Nil {
+		resume, err = j.repo.Resume.GetPrimary(ctx, userID)
+	} else {
+		resume, err = j.repo.Resume.GetByID(ctx, resumeID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, skill := range append(append([]string{}, job.Skills...), resume.Profile.Skills...) {
+		keys = append(keys, models.SkillKey(skill))
+	}
+	catalog, err := j.repo.Skill.Resolve(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	onResume := map[string]bool{}
+	byCategory := map[string][]string{}
+	for _, skill := range resume.Profile.Skills {
+		key := models.SkillKey(skill)
+		onResume[key] = true
+		if known, ok := catalog[key]; ok && known.Category != defaultSkillCategory {
+			byCategory[known.Category] = append(byCategory[known.Category], skill)
+		}
+	}
+
+	gap := &models.SkillGap{
+		JobID:    job.ID,
+		ResumeID: resume.ID,
+		Present:  []string{},
+		Adjacent: []models.AdjacentSkill{},
+		Missing:  []string{},
+		Experience: models.ExperienceGap{
+			Required:  job.MinExperience,
+			Maximum:   job.MaxExperience,
+			Resume:    int(resume.Profile.Experience),
+			Shortfall: max(0, job.MinExperience-int(resume.Profile.Experience)),
+		},
+		Mentors: []models.SkillMentors{},
+	}
+	lacking := []models.Skill{}
+	for _, skill := range job.Skills {
+		key := models.SkillKey(skill)
+		if onResume[key] {
+			gap.Present = append(gap.Present, skill)
+			continue
+		}
+		known, ok := catalog[key]
+		if ok {
+			lacking = append(lacking, known)
+		}
+		if related := byCategory[known.Category]; ok && len(related) > 0 {
+			gap.Adjacent = append(gap.Adjacent, models.AdjacentSkill{Skill: skill, Category: known.Category, Related: related})
+		} else {
+			gap.Missing = append(gap.Missing, skill)
+		}
+	}
+
+	if len(lacking) == 0 {
+		return gap, nil
+	}
+	ids := make([]int64, 0, len(lacking))
+	for _, skill := range lacking {
+		ids = append(ids, skill.ID)
+	}
+	mentors, err := j.repo.Mentor.BySkills(ctx, ids, GapMentorsPerSkill)
+	if err != nil {
+		return nil, err
+	}
+	for _, skill := range lacking {
+		if len(mentors[skill.ID]) > 0 {
+			gap.Mentors = append(gap.Mentors, models.SkillMentors{Skill: skill.Name, Mentors: mentors[skill.ID]})
+		}
+	}
+	return gap, nil
+}
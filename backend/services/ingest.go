@@ -0,0 +1,224 @@
+package services
+
+import (
+	"Inquiro/ingest"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// IngestRunTimeout bounds reading a feed and storing its jobs.
+	IngestRunTimeout = 10 * time.Minute
+	// IngestFetchTimeout bounds a single request for a feed.
+	IngestFetchTimeout = time.Minute
+	// DefaultIngestInterval is how often a source is read when the payload
+	// does not say.
+	DefaultIngestInterval = 60
+)
+
+// maxRunErrors is how many posting errors a run records.
+const maxRunErrors = 20
+
+type IngestServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+	client *http.Client
+}
+
+// sourceFromPayload builds the source and checks that an adapter accepts it.
+func (i IngestServices) sourceFromPayload(payload models.JobSourcePayload) (*models.JobSource, error) {
+	source := &models.JobSource{
+		Name:            strings.TrimSpace(payload.Name),
+		Kind:            payload.Kind,
+		URL:             strings.TrimSpace(payload.URL),
+		ItemsPath:       strings.TrimSpace(payload.ItemsPath),
+		Fields:          payload.Fields,
+		Company:         strings.TrimSpace(payload.Company),
+		IntervalMinutes: payload.IntervalMinutes,
+		Enabled:         payload.Enabled == nil || *payload.Enabled,
+	}
+	if source.Fields == nil {
+		source.Fields = map[string]string{}
+	}
+	if source.IntervalMinutes == 0 {
+		source.IntervalMinutes = DefaultIngestInterval
+	}
+	if _, err := ingest.New(source, i.client); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func (i IngestServices) CreateSource(ctx context.Context, userID uuid.UUID, payload models.JobSourcePayload) (*models.JobSource, error) {
+	source, err := i.sourceFromPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	source.CreatedBy = &userID
+	if err := i.repo.JobSource.Create(ctx, source); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func (i IngestServices) UpdateSource(ctx context.Context, id int64, payload models.JobSourcePayload) (*models.JobSource, error) {
+	source, err := i.sourceFromPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	source.ID = id
+	if err := i.repo.JobSource.Update(ctx, source); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func (i IngestServices) DeleteSource(ctx context.Context, id int64) error {
+	return i.repo.JobSource.Delete(ctx, id)
+}
+
+func (i IngestServices) ListSources(ctx context.Context, pagination *models.PaginatedQuery) ([]models.JobSource, error) {
+	return i.repo.JobSource.List(ctx, pagination)
+}
+
+func (i IngestServices) ListRuns(ctx context.Context, sourceID int64, pagination *models.PaginatedQuery) ([]models.JobImportRun, error) {
+	if _, err := i.repo.JobSource.GetByID(ctx, sourceID); err != nil {
+		return nil, err
+	}
+	return i.repo.JobSource.ListRuns(ctx, sourceID, pagination)
+}
+
+// RunSource starts reading the source now, outside its schedule. The run is
+// returned while still running; its outcome is found in the source's runs.
+func (i IngestServices) RunSource(ctx context.Context, id int64) (*models.JobImportRun, error) {
+	source, err := i.repo.JobSource.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	run, err := i.repo.JobSource.StartRun(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	started := *run
+	go i.importSource(context.WithoutCancel(ctx), source, run)
+	return &started, nil
+}
+
+// Schedule reads the sources that are due every tick until ctx is done.
+func (i IngestServices) Schedule(ctx context.Context, tick time.Duration) {
+	schedule(ctx, tick, i.runDue)
+}
+
+func (i IngestServices) runDue(ctx context.Context) {
+	sources, err := i.repo.JobSource.ClaimDue(ctx)
+	if err != nil {
+		i.logger.Errorw("Could not claim the due job sources", "error : ", err.Error())
+		return
+	}
+	for _, source := range sources {
+		run, err := i.repo.JobSource.StartRun(ctx, source.ID)
+		if err != nil {
+			i.logger.Errorw("Could not start the job import", "source : ", source.Name, "error : ", err.Error())
+			continue
+		}
+		i.importSource(ctx, &source, run)
+	}
+}
+
+// importSource reads the source and stores its postings, then records the
+// outcome on run. A posting that cannot be stored fails alone.
+func (i IngestServices) importSource(ctx context.Context, source *models.JobSource, run *models.JobImportRun) {
+	ctx, cancel := context.WithTimeout(ctx, IngestRunTimeout)
+	defer cancel()
+
+	run.Status = models.ImportSucceeded
+	run.Errors = []string{}
+	postings, err := i.fetch(ctx, source)
+	if err != nil {
+		run.Status = models.ImportFailed
+		run.Errors = append(run.Errors, err.Error())
+	}
+	run.Fetched = len(postings)
+	now := time.Now()
+	for n, posting := range postings {
+		outcome, externalID, err := i.store(ctx, source, posting, now)
+		if err != nil {
+			run.Failed++
+			if len(run.Errors) < maxRunErrors {
+				run.Errors = append(run.Errors, fmt.Sprintf("posting %d (%s): %s", n+1, externalID, err.Error()))
+			}
+			continue
+		}
+		switch outcome {
+		case models.UpsertCreated:
+			run.Created++
+		case models.UpsertUpdated:
+			run.Updated++
+		default:
+			run.Unchanged++
+		}
+	}
+	if run.Failed > 0 {
+		run.Status = models.ImportPartial
+		if run.Failed == run.Fetched {
+			run.Status = models.ImportFailed
+		}
+	}
+	// The outcome is recorded even when the run timed out
+	if err := i.repo.JobSource.FinishRun(context.WithoutCancel(ctx), run); err != nil {
+		i.logger.Errorw("Could not record the job import", "source : ", source.Name, "error : ", err.Error())
+		return
+	}
+	i.logger.Infow("Imported jobs", "source : ", source.Name, "status : ", run.Status, "fetched : ", run.Fetched,
+		"created : ", run.Created, "updated : ", run.Updated, "failed : ", run.Failed)
+}
+
+func (i IngestServices) fetch(ctx context.Context, source *models.JobSource) ([]ingest.Posting, error) {
+	adapter, err := ingest.New(source, i.client)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.Fetch(ctx)
+}
+
+func (i IngestServices) store(ctx context.Context, source *models.JobSource, posting ingest.Posting, now time.Time) (string, string, error) {
+	job, externalID, err := ingest.Normalize(posting, source.Company, now)
+	if err != nil {
+		return "", externalID, err
+	}
+	skills, skillIDs, err := ingestSkills(ctx, i.repo, i.logger, job.Skills)
+	if err != nil {
+		return "", externalID, err
+	}
+	job.Skills = skills
+	job.SourceID = &source.ID
+	job.Fingerprint = ingest.Fingerprint(job)
+	outcome, err := i.repo.Job.Upsert(ctx, job, externalID)
+	if err != nil || outcome == models.UpsertUnchanged {
+		return outcome, externalID, err
+	}
+	recordUsage(ctx, i.repo, i.logger, skillIDs, []string{job.Title})
+	if err := i.cluster(ctx, job); err != nil {
+		// The job is stored, it only shows up unclustered until its next change
+		i.logger.Warnw("Could not cluster the imported job", "job : ", job.ID, "error : ", err.Error())
+	}
+	return outcome, externalID, nil
+}
+
+// cluster puts the job under the earlier posting it duplicates, or takes it
+// out of its cluster when its text no longer matches.
+func (i IngestServices) cluster(ctx context.Context, job *models.Job) error {
+	candidates, err := i.repo.Job.DuplicateCandidates(ctx, job)
+	if err != nil {
+		return err
+	}
+	return i.repo.Job.SetCanonical(ctx, job.ID, ingest.FindCanonical(job, candidates))
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"Inquiro/ingest"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type JobServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+}
+
+// jobFromPayload builds the job with its skills rewritten to catalog names,
+// returning the IDs of the known ones.
+func (j JobServices) jobFromPayload(ctx context.Context, payload models.JobPayload) (*models.Job, []int64, error) {
+	skills, skillIDs, err := ingestSkills(ctx, j.repo, j.logger, payload.Skills)
+	if err != nil {
+		return nil, nil, err
+	}
+	remote := payload.Remote
+	if remote == "" {
+		remote = models.RemoteOnsite
+	}
+	job := &models.Job{
+		Title:         strings.TrimSpace(payload.Title),
+		Company:       strings.TrimSpace(payload.Company),
+		Description:   strings.TrimSpace(payload.Description),
+		Country:       strings.TrimSpace(payload.Country),
+		City:          strings.TrimSpace(payload.City),
+		Remote:        remote,
+		MinExperience: payload.MinExperience,
+		MaxExperience: payload.MaxExperience,
+		Skills:        skills,
+		URL:           strings.TrimSpace(payload.URL),
+	}
+	// Fingerprinted so imported postings of the same role cluster under it
+	job.Fingerprint = ingest.Fingerprint(job)
+	return job, skillIDs, nil
+}
+
+// CreateJob posts a job as the user. Jobs posted by employers belong to
+// their company and carry its name.
+func (j JobServices) CreateJob(ctx context.Context, user *models.User, payload models.JobPayload) (*models.Job, error) {
+	company, err := jobCompany(ctx, j.repo, user)
+	if err != nil {
+		return nil, err
+	}
+	if company != nil {
+		payload.Company = company.Name
+	}
+	job, skillIDs, err := j.jobFromPayload(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	job.CreatedBy = &user.ID
+	if company != nil {
+		job.CompanyID = &company.ID
+	}
+	if err := j.repo.Job.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	recordUsage(ctx, j.repo, j.logger, skillIDs, []string{job.Title})
+	return job, nil
+}
+
+// UpdateJob replaces a job the user manages.
+func (j JobServices) UpdateJob(ctx context.Context, id uuid.UUID, user *models.User, payload models.JobPayload) (*models.Job, error) {
+	current, err := j.repo.Job.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	company, err := authorizeJob(ctx, j.repo, user, current)
+	if err != nil {
+		return nil, err
+	}
+	if company != nil {
+		payload.Company = company.Name
+	}
+	job, skillIDs, err := j.jobFromPayload(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	job.ID = id
+	if err := j.repo.Job.Update(ctx, job); err != nil {
+		return nil, err
+	}
+	recordUsage(ctx, j.repo, j.logger, skillIDs, []string{job.Title})
+	return job, nil
+}
+
+// DeleteJob removes a job the user manages.
+func (j JobServices) DeleteJob(ctx context.Context, id uuid.UUID, user *models.User) error {
+	job, err := j.repo.Job.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeJob(ctx, j.repo, user, job); err != nil {
+		return err
+	}
+	return j.repo.Job.Delete(ctx, id)
+}
+
+func (j JobServices) GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	return j.repo.Job.GetByID(ctx, id)
+}
+
+// resolveRequiredSkills rewrites the required skills to catalog names so
+// "golang" finds jobs asking for "Go". Unknown skills are matched as written
+// and are not recorded, since they are search terms rather than resume
+// content.
+func resolveRequiredSkills(ctx context.Context, repo repositories.Storage, filter *models.JobFilter) error {
+	if len(filter.RequiredSkills) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(filter.RequiredSkills))
+	for _, skill := range filter.RequiredSkills {
+		keys = append(keys, models.SkillKey(skill))
+	}
+	resolved, err := repo.Skill.Resolve(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		if skill, ok := resolved[key]; ok {
+			filter.RequiredSkills[i] = skill.Name
+		}
+	}
+	return nil
+}
+
+func (j JobServices) ListJobs(ctx context.Context, filter *models.JobFilter) ([]models.Job, error) {
+	if err := resolveRequiredSkills(ctx, j.repo, filter); err != nil {
+		return nil, err
+	}
+	return j.repo.Job.List(ctx, filter)
+}
+
+func (j JobServices) SearchJobs(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error) {
+	if err := resolveRequiredSkills(ctx, j.repo, &query.Filter); err != nil {
+		return nil, err
+	}
+	return j.repo.Job.Search(ctx, query)
+}
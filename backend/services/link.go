@@ -0,0 +1,180 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/utils/limiter"
+	"Inquiro/utils/pdf"
+	"Inquiro/utils/redact"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrLinkPasswordRequired = errors.New("this link is protected by a password")
+	ErrLinkPasswordInvalid  = errors.New("invalid link password")
+	ErrLinkPasswordLocked   = errors.New("too many password attempts, try again later")
+	// LinkPasswordFreeAttempts password attempts on a link go through right
+	// away, after that they are spaced out from LinkPasswordBackoff doubling
+	// up to LinkPasswordMaxBackoff.
+	LinkPasswordFreeAttempts = 5
+	LinkPasswordBackoff      = time.Second
+	LinkPasswordMaxBackoff   = 5 * time.Minute
+)
+
+type LinkServices struct {
+	repo     repositories.Storage
+	logger   *zap.SugaredLogger
+	attempts *limiter.Backoff
+}
+
+func newLinkToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreateLink creates a public link to the resume. The returned link holds
+// the token, which cannot be recovered later.
+func (l LinkServices) CreateLink(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, payload models.ResumeLinkPayload) (*models.ResumeLink, error) {
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, err
+	}
+	link := &models.ResumeLink{
+		ResumeID:  resumeID,
+		UserID:    userID,
+		Token:     token,
+		Redaction: payload.Redaction,
+	}
+	if link.Redaction == "" {
+		link.Redaction = models.RedactionContact
+	}
+	if payload.ExpiresInHours > 0 {
+		expires := time.Now().UTC().Add(time.Duration(payload.ExpiresInHours) * time.Hour).Truncate(time.Second)
+		link.ExpiresAt = &expires
+	}
+	if payload.Password != "" {
+		if err := link.Password.Set(payload.Password); err != nil {
+			return nil, err
+		}
+	}
+	if err := l.repo.ResumeLink.Create(ctx, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (l LinkServices) ListLinks(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeLink, error) {
+	return l.repo.ResumeLink.ListByResume(ctx, resumeID, userID, pagination)
+}
+
+func (l LinkServices) RevokeLink(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error {
+	return l.repo.ResumeLink.Revoke(ctx, id, resumeID, userID)
+}
+
+func (l LinkServices) LinkStats(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) (*models.ResumeLinkStats, error) {
+	return l.repo.ResumeLink.Stats(ctx, id, resumeID, userID)
+}
+
+// ViewLink returns the resume behind the token at the redaction level of the
+// link and records the view.
+func (l LinkServices) ViewLink(ctx context.Context, token string, password string, referrer string) (*models.PublicResume, error) {
+	linked, err := l.open(ctx, token, password, referrer)
+	if err != nil {
+		return nil, err
+	}
+	public := &models.PublicResume{
+		Name:      strings.TrimSpace(linked.Owner.FirstName + " " + linked.Owner.LastName),
+		Version:   linked.Version,
+		Redaction: linked.Link.Redaction,
+		Profile:   linked.Profile,
+		ExpiresAt: linked.Link.ExpiresAt,
+	}
+	if public.Name == "" {
+		public.Name = linked.Owner.Username
+	}
+	switch linked.Link.Redaction {
+	case models.RedactionNone:
+		public.Text = linked.ExtractedText
+	case models.RedactionContact:
+		public.Text, _ = redact.Default(candidateTerms(&linked.Owner)).Redact(linked.ExtractedText, nil)
+	}
+	return public, nil
+}
+
+// LinkPDF renders the text visible through the link as a PDF.
+func (l LinkServices) LinkPDF(ctx context.Context, token string, password string, referrer string) (*models.ExportFile, error) {
+	public, err := l.ViewLink(ctx, token, password, referrer)
+	if err != nil {
+		return nil, err
+	}
+	text := public.Text
+	if text == "" {
+		// The profile level has no text, the PDF lists the profile instead
+		text = strings.Join([]string{
+			public.Name,
+			strings.Join(public.Profile.JobTitles, ", "),
+			"Skills: " + strings.Join(public.Profile.Skills, ", "),
+		}, "\n")
+	}
+	return &models.ExportFile{
+		FileName:    "resume.pdf",
+		ContentType: "application/pdf",
+		Content:     pdf.Render(public.Name, text),
+	}, nil
+}
+
+func (l LinkServices) open(ctx context.Context, token string, password string, referrer string) (*models.LinkedResume, error) {
+	linked, err := l.repo.ResumeLink.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if linked.Link.HasPassword {
+		if password == "" {
+			return nil, ErrLinkPasswordRequired
+		}
+		// Counted per link, so guesses spread over many clients are slowed too
+		key := linked.Link.ID.String()
+		if !l.attempts.Attempt(key) {
+			return nil, ErrLinkPasswordLocked
+		}
+		if err := linked.Link.Password.Compare(password); err != nil {
+			return nil, ErrLinkPasswordInvalid
+		}
+		l.attempts.Reset(key)
+	}
+	if err := l.repo.ResumeLink.RecordView(ctx, linked.Link.ID, coarseReferrer(referrer)); err != nil {
+		// Analytics must not break the link
+		l.logger.Warnw("Could not record the link view", "error : ", err.Error())
+	}
+	return linked, nil
+}
+
+// coarseReferrer keeps only the host of the referring page, so views can be
+// grouped by site without storing full URLs.
+func coarseReferrer(referrer string) string {
+	referrer = strings.TrimSpace(referrer)
+	if referrer == "" {
+		return "direct"
+	}
+	u, err := url.Parse(referrer)
+	if err != nil || u.Hostname() == "" {
+		return "other"
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if len(host) > 255 {
+		return "other"
+	}
+	return host
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -51,3 +52,20 @@ func (m MentorServices) ActivateMentor(ctx context.Context, token string) error
 func (m MentorServices) RegisterMentor(ctx context.Context, mentor *models.Mentor, token string) error {
 	return m.repo.Mentor.CreateAndInvite(ctx, token, mentor)
 }
+
+// SetSkills replaces the skills the mentor can help with by their catalog
+// entries and returns the names kept.
+func (m MentorServices) SetSkills(ctx context.Context, mentorID uuid.UUID, names []string) ([]string, error) {
+	_, ids, err := canonicalSkills(ctx, m.repo, names)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.repo.Mentor.SetSkills(ctx, mentorID, ids); err != nil {
+		return nil, err
+	}
+	return m.repo.Mentor.Skills(ctx, mentorID)
+}
+
+func (m MentorServices) Skills(ctx context.Context, mentorID uuid.UUID) ([]string, error) {
+	return m.repo.Mentor.Skills(ctx, mentorID)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/parser"
+	"Inquiro/repositories"
+	"Inquiro/utils/limiter"
+	"Inquiro/utils/pdf"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrParseJobNotFound = errors.New("parse job not found")
+	// ParseJobTimeout bounds parsing and storing a resume in the background.
+	ParseJobTimeout = 2 * time.Minute
+	// ParseJobRetention is how long a finished job keeps its events for
+	// clients that reconnect late.
+	ParseJobRetention = 10 * time.Minute
+)
+
+type parseJob struct {
+	userID   uuid.UUID
+	events   []models.ParseEvent
+	finished time.Time
+	// changed is closed and replaced whenever an event is added
+	changed chan struct{}
+}
+
+// parseJobs holds the jobs of this process in memory. Events are lost on
+// restart, which only costs clients a retry of the upload.
+type parseJobs struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*parseJob
+}
+
+func newParseJobs() *parseJobs {
+	return &parseJobs{jobs: map[uuid.UUID]*parseJob{}}
+}
+
+func (p *parseJobs) create(userID uuid.UUID) uuid.UUID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, job := range p.jobs {
+		if !job.finished.IsZero() && time.Since(job.finished) > ParseJobRetention {
+			delete(p.jobs, id)
+		}
+	}
+	id := uuid.New()
+	p.jobs[id] = &parseJob{userID: userID, changed: make(chan struct{})}
+	return id
+}
+
+func (p *parseJobs) publish(id uuid.UUID, event string, data any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job := p.jobs[id]
+	if job == nil || !job.finished.IsZero() {
+		return
+	}
+	job.events = append(job.events, models.ParseEvent{ID: len(job.events) + 1, Event: event, Data: data})
+	if event != models.ParseEventProgress {
+		job.finished = time.Now()
+	}
+	close(job.changed)
+	job.changed = make(chan struct{})
+}
+
+// since returns the events after lastID, whether the job has finished and a
+// channel closed on the next event.
+func (p *parseJobs) since(id uuid.UUID, userID uuid.UUID, lastID int) ([]models.ParseEvent, bool, <-chan struct{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job := p.jobs[id]
+	if job == nil || job.userID != userID {
+		return nil, false, nil, ErrParseJobNotFound
+	}
+	lastID = min(max(lastID, 0), len(job.events))
+	events := append([]models.ParseEvent(nil), job.events[lastID:]...)
+	return events, !job.finished.IsZero(), job.changed, nil
+}
+
+type ParseJobServices struct {
+	repo    repositories.Storage
+	logger  *zap.SugaredLogger
+	parser  parser.ResumeParser
+	jobs    *parseJobs
+	resumes ResumeServices
+}
+
+// StartParse parses and stores the upload in the background and returns the
+// job id. release gives back the parse quota and is called if the job fails.
+func (p ParseJobServices) StartParse(ctx context.Context, upload models.ResumeUpload, release func()) *models.ParseJob {
+	id := p.jobs.create(upload.UserID)
+	p.jobs.publish(id, models.ParseEventProgress, parser.Progress{Stage: parser.StageQueued, Message: "Waiting for the parser"})
+
+	// The job outlives the request that started it
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ParseJobTimeout)
+	go func() {
+		defer cancel()
+		progress := func(progress parser.Progress) {
+			p.jobs.publish(id, models.ParseEventProgress, progress)
+		}
+		parsed, err := parser.ParseWithProgress(ctx, p.parser, upload.FileName, upload.Content, progress)
+		if err != nil {
+			release()
+			p.logger.Warnw("Could not parse the resume", "job", id, "error : ", err.Error())
+			p.jobs.publish(id, models.ParseEventError, map[string]string{"message": parseErrorMessage(err)})
+			return
+		}
+		resume, err := p.resumes.StoreResume(ctx, upload, parsed)
+		if err != nil {
+			release()
+			message := "Could not store the resume"
+			if errors.Is(err, repositories.ErrResumeDocumentNotFound) {
+				message = "Resume document does not exist"
+			}
+			if errors.Is(err, repositories.ErrResumeConflict) {
+				message = repositories.ErrResumeConflict.Error()
+			}
+			p.jobs.publish(id, models.ParseEventError, map[string]string{"message": message})
+			return
+		}
+		p.jobs.publish(id, models.ParseEventDone, resume)
+	}()
+	return &models.ParseJob{ID: id, CreatedAt: time.Now()}
+}
+
+// Events sends the events after lastID on the returned channel as they
+// happen and closes it once the job has finished or ctx is done.
+func (p ParseJobServices) Events(ctx context.Context, id uuid.UUID, userID uuid.UUID, lastID int) (<-chan models.ParseEvent, error) {
+	events, finished, changed, err := p.jobs.since(id, userID, lastID)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan models.ParseEvent)
+	go func() {
+		defer close(out)
+		for {
+			for _, event := range events {
+				select {
+				case out <- event:
+					lastID = event.ID
+				case <-ctx.Done():
+					return
+				}
+			}
+			if finished {
+				return
+			}
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return
+			}
+			events, finished, changed, err = p.jobs.since(id, userID, lastID)
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// parseErrorMessage is the message clients get for a failed parse, matching
+// what the synchronous upload returns.
+func parseErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, parser.ErrUnsupportedFormat), errors.Is(err, parser.ErrEmptyResume), errors.Is(err, pdf.ErrNoText),
+		errors.Is(err, pdf.ErrTooLarge):
+		return err.Error()
+	case errors.Is(err, limiter.ErrQueueFull), errors.Is(err, limiter.ErrQueueTimeout):
+		return "The resume parser is busy, try again shortly"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "Parsing the resume took too long"
+	}
+	return status.Convert(err).Message()
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type QuotaServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+}
+
+func (q QuotaServices) ParseQuota(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, error) {
+	return q.repo.Quota.Usage(ctx, userID)
+}
+
+// ReserveParse counts a parse against the user's quota before it starts. The
+// returned release func gives it back and is meant for parses that fail.
+func (q QuotaServices) ReserveParse(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, func(), error) {
+	quota, id, err := q.repo.Quota.Reserve(ctx, userID)
+	if err != nil {
+		return quota, nil, err
+	}
+	release := func() {
+		// The request context may already be cancelled when the parse failed
+		if err := q.repo.Quota.Release(context.WithoutCancel(ctx), id); err != nil {
+			q.logger.Errorw("Could not release parse quota", "error : ", err.Error())
+		}
+	}
+	return quota, release, nil
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"Inquiro/ingest"
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// RecommendationCandidates is how many jobs are scored for a resume.
+	RecommendationCandidates = 200
+	// RecommendationExperienceSlack is how many years a job may ask for
+	// beyond or below the resume's experience and still be recommended.
+	RecommendationExperienceSlack = 2
+	// RecommendationActiveDays is how long after their last request the
+	// recommendations of a user are kept fresh in the background.
+	RecommendationActiveDays = 30
+	// RecommendationRefreshBatch is how many users are refreshed per tick.
+	RecommendationRefreshBatch = 20
+)
+
+// Weights of the parts of a recommendation score. The job service's
+// relevancy weighs most, then the share of the job's skills on the resume,
+// then how close the job title is to a title on the resume.
+const (
+	relevancyWeight = 0.5
+	coverageWeight  = 0.3
+	titleWeight     = 0.2
+)
+
+type RecommendationServices struct {
+	repo      repositories.Storage
+	logger    *zap.SugaredLogger
+	relevancy RelevancyServices
+}
+
+// Recommend returns a page of the jobs recommended from the user's primary
+// resume. The recommendations are recomputed first when there are none yet
+// or the resume changed since; changed jobs are picked up in the background.
+func (s RecommendationServices) Recommend(ctx context.Context, userID uuid.UUID, filter *models.JobFilter) (*models.RecommendationPage, error) {
+	resume, err := s.repo.Resume.GetPrimary(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.repo.Recommendation.State(ctx, userID)
+	switch {
+	case errors.Is(err, repositories.ErrRecommendationsNotComputed):
+		if state, err = s.refresh(ctx, resume, nil); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case state.ResumeID != resume.ID || !state.ResumeUpdatedAt.Equal(resume.UpdatedAt):
+		if state, err = s.refresh(ctx, resume, state); err != nil {
+			return nil, err
+		}
+	default:
+		if err := s.repo.Recommendation.Touch(ctx, userID); err != nil {
+			s.logger.Warnw("Could not record the recommendation request", "error : ", err.Error())
+		}
+	}
+
+	if err := resolveRequiredSkills(ctx, s.repo, filter); err != nil {
+		return nil, err
+	}
+	recommendations, err := s.repo.Recommendation.List(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.RecommendationPage{
+		ResumeID:        state.ResumeID,
+		RefreshedAt:     state.RefreshedAt,
+		Recommendations: recommendations,
+	}, nil
+}
+
+// refresh recomputes the recommendations of the resume's owner. Relevancy
+// scores computed from the same resume version for the same job version are
+// reused, so only new and changed jobs go to the job service.
+func (s RecommendationServices) refresh(ctx context.Context, resume *models.Resume, previous *models.RecommendationState) (*models.RecommendationState, error) {
+	// RefreshedAt is truncated as stored, so jobs changed within the second
+	// are refreshed again rather than missed
+	state := &models.RecommendationState{
+		UserID:          resume.UserID,
+		ResumeID:        resume.ID,
+		ResumeUpdatedAt: resume.UpdatedAt,
+		RefreshedAt:     time.Now().Truncate(time.Second),
+	}
+	experience := int(resume.Profile.Experience)
+	jobs, err := s.repo.Recommendation.Candidates(ctx, resume.Profile.Skills, experience,
+		RecommendationExperienceSlack, RecommendationCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := map[uuid.UUID]models.JobRecommendation{}
+	if previous != nil && previous.ResumeID == resume.ID && previous.ResumeUpdatedAt.Equal(resume.UpdatedAt) {
+		if cached, err = s.repo.Recommendation.Scores(ctx, resume.UserID); err != nil {
+			return nil, err
+		}
+	}
+	relevancy := map[uuid.UUID]*float64{}
+	pending := []models.RelevancyJob{}
+	for _, job := range jobs {
+		if old, ok := cached[job.ID]; ok && old.Relevancy != nil && old.JobUpdatedAt.Equal(job.UpdatedAt) {
+			relevancy[job.ID] = old.Relevancy
+			continue
+		}
+		pending = append(pending, models.RelevancyJob{ID: job.ID.String(), Description: job.Description})
+	}
+	if len(pending) > 0 {
+		results := s.relevancy.BatchCalculateRelevancy(ctx, resume.Profile.Skills, strconv.Itoa(experience), pending)
+		for _, result := range results {
+			id, err := uuid.Parse(result.JobID)
+			if err != nil || result.Error != "" {
+				continue
+			}
+			score := result.Score
+			relevancy[id] = &score
+		}
+	}
+
+	recommendations := make([]models.JobRecommendation, 0, len(jobs))
+	for _, job := range jobs {
+		recommendations = append(recommendations, recommend(resume, job, relevancy[job.ID]))
+	}
+	if err := s.repo.Recommendation.Replace(ctx, state, recommendations); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// recommend scores the job for the resume. Without a relevancy score the
+// other parts are weighed alone.
+func recommend(resume *models.Resume, job models.Job, relevancy *float64) models.JobRecommendation {
+	onResume := map[string]bool{}
+	for _, skill := range resume.Profile.Skills {
+		onResume[models.SkillKey(skill)] = true
+	}
+	recommendation := models.JobRecommendation{
+		Job:           job,
+		Relevancy:     relevancy,
+		MatchedSkills: []string{},
+		MissingSkills: []string{},
+		JobUpdatedAt:  job.UpdatedAt,
+	}
+	for _, skill := range job.Skills {
+		if onResume[models.SkillKey(skill)] {
+			recommendation.MatchedSkills = append(recommendation.MatchedSkills, skill)
+		} else {
+			recommendation.MissingSkills = append(recommendation.MissingSkills, skill)
+		}
+	}
+
+	coverage := 0.0
+	if len(job.Skills) > 0 {
+		coverage = float64(len(recommendation.MatchedSkills)) / float64(len(job.Skills))
+	}
+	title := 0.0
+	for _, resumeTitle := range resume.Profile.JobTitles {
+		title = max(title, ingest.TitleSimilarity(resumeTitle, job.Title))
+	}
+	score := coverageWeight*coverage + titleWeight*title
+	if relevancy != nil {
+		recommendation.Score = relevancyWeight**relevancy + score
+	} else {
+		recommendation.Score = score / (coverageWeight + titleWeight)
+	}
+	return recommendation
+}
+
+// Schedule refreshes the recommendations outdated by job or resume changes
+// every tick until ctx is done.
+func (s RecommendationServices) Schedule(ctx context.Context, tick time.Duration) {
+	schedule(ctx, tick, s.runDue)
+}
+
+func (s RecommendationServices) runDue(ctx context.Context) {
+	users, err := s.repo.Recommendation.Outdated(ctx, RecommendationActiveDays, RecommendationRefreshBatch)
+	if err != nil {
+		s.logger.Errorw("Could not read the outdated recommendations", "error : ", err.Error())
+		return
+	}
+	for _, userID := range users {
+		if err := s.refreshUser(ctx, userID); err != nil {
+			s.logger.Errorw("Could not refresh the recommendations", "user : ", userID, "error : ", err.Error())
+		}
+	}
+}
+
+func (s RecommendationServices) refreshUser(ctx context.Context, userID uuid.UUID) error {
+	resume, err := s.repo.Resume.GetPrimary(ctx, userID)
+	if err != nil {
+		return err
+	}
+	state, err := s.repo.Recommendation.State(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.refresh(ctx, resume, state)
+	return err
+}
+
+// FeedbackBands is how many score bands feedback statistics are split into.
+var FeedbackBands = 5
+
+// GiveFeedback records what the user thinks of the job. It takes effect on
+// the next page of recommendations.
+func (s RecommendationServices) GiveFeedback(ctx context.Context, userID uuid.UUID, jobID uuid.UUID, payload models.RecommendationFeedbackPayload) (*models.RecommendationFeedback, error) {
+	job, err := s.repo.Job.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	feedback := &models.RecommendationFeedback{
+		UserID:        userID,
+		JobID:         job.ID,
+		Kind:          payload.Kind,
+		Reason:        payload.Reason,
+		CompanyKey:    ingest.CompanyKey(job.Company),
+		Title:         job.Title,
+		Country:       job.Country,
+		Remote:        job.Remote,
+		MinExperience: job.MinExperience,
+	}
+	scores, err := s.repo.Recommendation.Scores(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if recommendation, ok := scores[job.ID]; ok {
+		feedback.Score = &recommendation.Score
+		feedback.MissingSkills = recommendation.MissingSkills
+	}
+	if err := s.repo.Recommendation.SaveFeedback(ctx, feedback); err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
+
+func (s RecommendationServices) RemoveFeedback(ctx context.Context, userID uuid.UUID, jobID uuid.UUID) error {
+	return s.repo.Recommendation.DeleteFeedback(ctx, userID, jobID)
+}
+
+// FeedbackStats sums up the feedback of the last days days.
+func (s RecommendationServices) FeedbackStats(ctx context.Context, days int) (*models.RecommendationFeedbackStats, error) {
+	since := time.Now().AddDate(0, 0, -days).Truncate(time.Second)
+	return s.repo.Recommendation.FeedbackStats(ctx, since, FeedbackBands)
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"Inquiro/models"
+	jobpb "Inquiro/protos"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	RelevancyWorkers      = 8
+	RelevancyCallTimeout  = 5 * time.Second
+	RelevancyBatchTimeout = 60 * time.Second
+	ErrRelevancyNotScored = errors.New("job was not scored")
+)
+
+type RelevancyServices struct {
+	grpc   jobpb.JobServiceClient
+	logger *zap.SugaredLogger
+}
+
+// BatchCalculateRelevancy scores every job against the resume and returns the
+// results sorted by score. Jobs that could not be scored are reported at the
+// end of the list with their error instead of failing the whole batch.
+func (r RelevancyServices) BatchCalculateRelevancy(ctx context.Context, skills []string, experience string, jobs []models.RelevancyJob) []models.RelevancyResult {
+	ctx, cancel := context.WithTimeout(ctx, RelevancyBatchTimeout)
+	defer cancel()
+
+	results := make(map[string]models.RelevancyResult, len(jobs))
+	if err := r.streamRelevancy(ctx, skills, experience, jobs, results); err != nil {
+		r.logger.Warnw("batch relevancy stream failed, falling back to single calls", "error : ", err.Error(), "received", len(results))
+	}
+	pending := make([]models.RelevancyJob, 0, len(jobs))
+	for _, job := range jobs {
+		if _, ok := results[job.ID]; !ok {
+			pending = append(pending, job)
+		}
+	}
+	if len(pending) > 0 {
+		r.fanOutRelevancy(ctx, skills, experience, pending, results)
+	}
+	return rankRelevancy(jobs, results)
+}
+
+// streamRelevancy scores the whole batch in one round trip. Results received
+// before an error are kept so only the remaining jobs need to be retried.
+// Results for jobs that were not asked for are dropped.
+func (r RelevancyServices) streamRelevancy(ctx context.Context, skills []string, experience string, jobs []models.RelevancyJob, results map[string]models.RelevancyResult) error {
+	req := &jobpb.BatchCalculateRelevancyRequest{
+		ResumeSkills:     skills,
+		ResumeExperience: experience,
+		Jobs:             make([]*jobpb.RelevancyJob, 0, len(jobs)),
+	}
+	requested := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		req.Jobs = append(req.Jobs, &jobpb.RelevancyJob{JobId: job.ID, JobDescription: job.Description})
+		requested[job.ID] = true
+	}
+	stream, err := r.grpc.BatchCalculateRelevancy(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !requested[res.JobId] {
+			r.logger.Warnw("batch relevancy returned a job that was not requested", "job", res.JobId)
+			continue
+		}
+		results[res.JobId] = models.RelevancyResult{
+			JobID: res.JobId,
+			Score: res.RelevancyScore,
+			Error: res.Error,
+		}
+	}
+}
+
+// fanOutRelevancy scores jobs with single CalculateRelevancy calls using a
+// bounded pool of workers, each call having its own timeout.
+func (r RelevancyServices) fanOutRelevancy(ctx context.Context, skills []string, experience string, jobs []models.RelevancyJob, results map[string]models.RelevancyResult) {
+	queue := make(chan models.RelevancyJob)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < min(RelevancyWorkers, len(jobs)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				result := r.calculateRelevancy(ctx, skills, experience, job)
+				mu.Lock()
+				results[job.ID] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+send:
+	for _, job := range jobs {
+		select {
+		case queue <- job:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
+
+func (r RelevancyServices) calculateRelevancy(ctx context.Context, skills []string, experience string, job models.RelevancyJob) models.RelevancyResult {
+	ctx, cancel := context.WithTimeout(ctx, RelevancyCallTimeout)
+	defer cancel()
+
+	res, err := r.grpc.CalculateRelevancy(ctx, &jobpb.CalculateRelevancyRequest{
+		ResumeSkills:     skills,
+		ResumeExperience: experience,
+		JobDescription:   job.Description,
+	})
+	if err != nil {
+		r.logger.Warnw("relevancy calculation failed", "job", job.ID, "error : ", err.Error())
+		if status.Code(err) == codes.DeadlineExceeded {
+			return models.RelevancyResult{JobID: job.ID, Error: "timed out"}
+		}
+		return models.RelevancyResult{JobID: job.ID, Error: status.Convert(err).Message()}
+	}
+	return models.RelevancyResult{JobID: job.ID, Score: res.RelevancyScore}
+}
+
+func rankRelevancy(jobs []models.RelevancyJob, results map[string]models.RelevancyResult) []models.RelevancyResult {
+	scored := make([]models.RelevancyResult, 0, len(jobs))
+	failed := make([]models.RelevancyResult, 0)
+	for _, job := range jobs {
+		result, ok := results[job.ID]
+		if !ok {
+			failed = append(failed, models.RelevancyResult{JobID: job.ID, Error: ErrRelevancyNotScored.Error()})
+			continue
+		}
+		if result.Error != "" {
+			failed = append(failed, result)
+			continue
+		}
+		scored = append(scored, result)
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+	for i := range scored {
+		scored[i].Rank = i + 1
+	}
+	return append(scored, failed...)
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/parser"
+	"Inquiro/repositories"
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ResumeServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+}
+
+// StoreResume saves the uploaded file with the parser output. The profile
+// starts as a copy of the parsed data with the skills mapped onto the
+// catalog, and is what the user edits later.
+func (r ResumeServices) StoreResume(ctx context.Context, upload models.ResumeUpload, parsed *parser.ParsedResume) (*models.Resume, error) {
+	text := upload.Text
+	if text == "" {
+		var err error
+		if text, err = parser.ExtractText(upload.Content); err != nil {
+			// Formats only the job service understands are stored without text
+			r.logger.Infow("Could not extract resume text", "error : ", err.Error())
+		}
+	}
+	machine := models.ResumeProfile{
+		JobTitles:  nonNil(parsed.JobTitles),
+		Skills:     nonNil(parsed.Skills),
+		Experience: parsed.Experience,
+	}
+	skills, skillIDs, err := ingestSkills(ctx, r.repo, r.logger, machine.Skills)
+	if err != nil {
+		return nil, err
+	}
+	resume := &models.Resume{
+		UserID:        upload.UserID,
+		DocumentID:    upload.DocumentID,
+		FileName:      upload.FileName,
+		ContentType:   upload.ContentType,
+		FileContent:   upload.Content,
+		ExtractedText: text,
+		Degraded:      parsed.Degraded,
+		Parsed:        machine,
+		Profile: models.ResumeProfile{
+			JobTitles:  machine.JobTitles,
+			Skills:     skills,
+			Experience: machine.Experience,
+		},
+		SkillIDs: skillIDs,
+	}
+	title := upload.Title
+	if title == "" {
+		title = upload.FileName
+	}
+	if err := r.repo.Resume.Create(ctx, resume, title); err != nil {
+		return nil, err
+	}
+	recordUsage(ctx, r.repo, r.logger, skillIDs, machine.JobTitles)
+	return resume, nil
+}
+
+func (r ResumeServices) GetResume(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Resume, error) {
+	return r.repo.Resume.GetByID(ctx, id, userID)
+}
+
+func (r ResumeServices) ListResumes(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Resume, error) {
+	return r.repo.Resume.ListByUser(ctx, userID, pagination)
+}
+
+// CheckDocument tells whether a new version can be uploaded to the
+// document, before the upload is parsed.
+func (r ResumeServices) CheckDocument(ctx context.Context, documentID uuid.UUID, userID uuid.UUID) error {
+	return r.repo.Resume.DocumentExists(ctx, documentID, userID)
+}
+
+func (r ResumeServices) ListDocuments(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeDocument, error) {
+	return r.repo.Resume.ListDocuments(ctx, userID, pagination)
+}
+
+func (r ResumeServices) DiffVersions(ctx context.Context, documentID uuid.UUID, from int, to int, userID uuid.UUID) (*models.ResumeDiff, error) {
+	older, err := r.repo.Resume.GetVersion(ctx, documentID, from, userID)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := r.repo.Resume.GetVersion(ctx, documentID, to, userID)
+	if err != nil {
+		return nil, err
+	}
+	diff := models.DiffResumes(older, newer)
+	return &diff, nil
+}
+
+func (r ResumeServices) SetPrimary(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return r.repo.Resume.SetPrimary(ctx, id, userID)
+}
+
+func (r ResumeServices) UpdateProfile(ctx context.Context, id uuid.UUID, userID uuid.UUID, patch models.ResumeProfilePatch) (*models.Resume, error) {
+	resume, err := r.repo.Resume.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	patch.Apply(&resume.Profile)
+	resume.Profile.Skills, resume.SkillIDs, err = canonicalSkills(ctx, r.repo, resume.Profile.Skills)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.repo.Resume.UpdateProfile(ctx, resume); err != nil {
+		r.logger.Warnw("Could not update resume profile", "error : ", err.Error())
+		return nil, err
+	}
+	return resume, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
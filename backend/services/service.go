@@ -1,11 +1,20 @@
 package services
 
 import (
+	"Inquiro/ats"
+	"Inquiro/export"
 	"Inquiro/models"
+	"Inquiro/parser"
+	jobpb "Inquiro/protos"
 	"Inquiro/repositories"
+	"Inquiro/utils/limiter"
 	"Inquiro/utils/mailer"
 	"context"
+	"io"
+	"net/http"
+	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -25,10 +34,142 @@ type Service struct {
 		AuthenticateMentorPassword(ctx context.Context, mentor *models.Mentor, pass *models.PasswordType) error
 		ActivateMentor(ctx context.Context, token string) error
 		RegisterMentor(ctx context.Context, mentor *models.Mentor, token string) error
+		SetSkills(ctx context.Context, mentorID uuid.UUID, names []string) ([]string, error)
+		Skills(ctx context.Context, mentorID uuid.UUID) ([]string, error)
+	}
+	ResumeServices interface {
+		StoreResume(ctx context.Context, upload models.ResumeUpload, parsed *parser.ParsedResume) (*models.Resume, error)
+		GetResume(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Resume, error)
+		ListResumes(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Resume, error)
+		ListDocuments(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeDocument, error)
+		CheckDocument(ctx context.Context, documentID uuid.UUID, userID uuid.UUID) error
+		DiffVersions(ctx context.Context, documentID uuid.UUID, from int, to int, userID uuid.UUID) (*models.ResumeDiff, error)
+		SetPrimary(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+		UpdateProfile(ctx context.Context, id uuid.UUID, userID uuid.UUID, patch models.ResumeProfilePatch) (*models.Resume, error)
+	}
+	SkillServices interface {
+		CreateSkill(ctx context.Context, payload models.SkillPayload) (*models.Skill, error)
+		UpdateSkill(ctx context.Context, id int64, payload models.SkillPayload) (*models.Skill, error)
+		DeleteSkill(ctx context.Context, id int64) error
+		ListSkills(ctx context.Context, category string, pagination *models.PaginatedQuery) ([]models.Skill, error)
+		ListUnknown(ctx context.Context, pagination *models.PaginatedQuery) ([]models.UnknownSkill, error)
+		ImportCSV(ctx context.Context, source io.Reader) (*models.SkillImportResult, error)
+		Normalize(ctx context.Context, names []string) ([]models.NormalizedSkill, error)
+		Suggest(ctx context.Context, query models.SuggestQuery) ([]models.SkillSuggestion, error)
+	}
+	ATSServices interface {
+		Report(ctx context.Context, id uuid.UUID, userID uuid.UUID, target models.ATSTarget) (*ats.Report, error)
+	}
+	ExportServices interface {
+		Export(ctx context.Context, user *models.User, resumeID uuid.UUID, format string, theme string) (*models.ExportFile, error)
+		Import(ctx context.Context, user *models.User, documentID uuid.UUID, title string, data []byte) (*models.Resume, error)
+		Themes() []export.Theme
+	}
+	ShareServices interface {
+		ShareResume(ctx context.Context, user *models.User, resumeID uuid.UUID, payload models.ResumeSharePayload) (*models.ResumeShare, error)
+		ListShares(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeShare, error)
+		RevokeShare(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error
+		ListSharedWithMentor(ctx context.Context, mentorID uuid.UUID, pagination *models.PaginatedQuery) ([]models.SharedResume, error)
+		GetSharedResume(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (*models.SharedResume, error)
+		GetSharedFile(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (string, []byte, error)
+	}
+	LinkServices interface {
+		CreateLink(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, payload models.ResumeLinkPayload) (*models.ResumeLink, error)
+		ListLinks(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeLink, error)
+		RevokeLink(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error
+		LinkStats(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) (*models.ResumeLinkStats, error)
+		ViewLink(ctx context.Context, token string, password string, referrer string) (*models.PublicResume, error)
+		LinkPDF(ctx context.Context, token string, password string, referrer string) (*models.ExportFile, error)
+	}
+	JobServices interface {
+		CreateJob(ctx context.Context, user *models.User, payload models.JobPayload) (*models.Job, error)
+		UpdateJob(ctx context.Context, id uuid.UUID, user *models.User, payload models.JobPayload) (*models.Job, error)
+		DeleteJob(ctx context.Context, id uuid.UUID, user *models.User) error
+		GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error)
+		ListJobs(ctx context.Context, filter *models.JobFilter) ([]models.Job, error)
+		SearchJobs(ctx context.Context, query *models.JobSearchQuery) (*models.JobSearchResult, error)
+		SkillGap(ctx context.Context, jobID uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) (*models.SkillGap, error)
+	}
+	IngestServices interface {
+		CreateSource(ctx context.Context, userID uuid.UUID, payload models.JobSourcePayload) (*models.JobSource, error)
+		UpdateSource(ctx context.Context, id int64, payload models.JobSourcePayload) (*models.JobSource, error)
+		DeleteSource(ctx context.Context, id int64) error
+		ListSources(ctx context.Context, pagination *models.PaginatedQuery) ([]models.JobSource, error)
+		ListRuns(ctx context.Context, sourceID int64, pagination *models.PaginatedQuery) ([]models.JobImportRun, error)
+		RunSource(ctx context.Context, id int64) (*models.JobImportRun, error)
+		Schedule(ctx context.Context, tick time.Duration)
+	}
+	AlertServices interface {
+		CreateAlert(ctx context.Context, userID uuid.UUID, payload models.JobAlertPayload) (*models.JobAlert, error)
+		UpdateAlert(ctx context.Context, id uuid.UUID, userID uuid.UUID, payload models.JobAlertPayload) (*models.JobAlert, error)
+		DeleteAlert(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+		ListAlerts(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobAlert, error)
+		Unsubscribe(ctx context.Context, token string) (*models.JobAlert, error)
+		Schedule(ctx context.Context, tick time.Duration)
+	}
+	ApplicationServices interface {
+		Apply(ctx context.Context, userID uuid.UUID, payload models.ApplicationPayload) (*models.Application, error)
+		GetApplication(ctx context.Context, id uuid.UUID, user *models.User) (*models.Application, error)
+		ListApplications(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Application, error)
+		ListJobApplications(ctx context.Context, jobID uuid.UUID, user *models.User, status string, pagination *models.PaginatedQuery) ([]models.Application, error)
+		Withdraw(ctx context.Context, id uuid.UUID, userID uuid.UUID, note string) (*models.Application, error)
+		SetStatus(ctx context.Context, id uuid.UUID, user *models.User, payload models.ApplicationStatusPayload) (*models.Application, error)
+	}
+	BookmarkServices interface {
+		CreateCollection(ctx context.Context, userID uuid.UUID, payload models.JobCollectionPayload) (*models.JobCollection, error)
+		UpdateCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID, payload models.JobCollectionPayload) (*models.JobCollection, error)
+		DeleteCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+		GetCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.JobCollection, error)
+		ListCollections(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.JobCollection, error)
+		ListBookmarks(ctx context.Context, collectionID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.Bookmark, error)
+		AddBookmark(ctx context.Context, collectionID uuid.UUID, userID uuid.UUID, payload models.BookmarkPayload) (*models.Bookmark, error)
+		UpdateBookmark(ctx context.Context, id uuid.UUID, collectionID uuid.UUID, userID uuid.UUID, payload models.BookmarkUpdatePayload) (*models.Bookmark, error)
+		RemoveBookmark(ctx context.Context, id uuid.UUID, collectionID uuid.UUID, userID uuid.UUID) error
+		Schedule(ctx context.Context, tick time.Duration)
+	}
+	CompanyServices interface {
+		CreateCompany(ctx context.Context, userID uuid.UUID, payload models.CompanyPayload) (*models.Company, error)
+		GetCompany(ctx context.Context, slug string) (*models.Company, error)
+		ListCompanyJobs(ctx context.Context, slug string, filter *models.JobFilter) ([]models.Job, error)
+		MyCompany(ctx context.Context, userID uuid.UUID) (*models.Company, error)
+		UpdateCompany(ctx context.Context, userID uuid.UUID, payload models.CompanyPayload) (*models.Company, error)
+		ListMembers(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyMember, error)
+		InviteMember(ctx context.Context, userID uuid.UUID, payload models.CompanyInvitePayload) (*models.CompanyInvite, error)
+		ListInvites(ctx context.Context, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.CompanyInvite, error)
+		RevokeInvite(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+		AcceptInvite(ctx context.Context, user *models.User, token string) (*models.CompanyMember, error)
+		RemoveMember(ctx context.Context, userID uuid.UUID, memberID uuid.UUID) error
+		ListCompanies(ctx context.Context, verified *bool, pagination *models.PaginatedQuery) ([]models.Company, error)
+		VerifyCompany(ctx context.Context, id uuid.UUID, adminID uuid.UUID, verified bool) (*models.Company, error)
+	}
+	RecommendationServices interface {
+		Recommend(ctx context.Context, userID uuid.UUID, filter *models.JobFilter) (*models.RecommendationPage, error)
+		GiveFeedback(ctx context.Context, userID uuid.UUID, jobID uuid.UUID, payload models.RecommendationFeedbackPayload) (*models.RecommendationFeedback, error)
+		RemoveFeedback(ctx context.Context, userID uuid.UUID, jobID uuid.UUID) error
+		FeedbackStats(ctx context.Context, days int) (*models.RecommendationFeedbackStats, error)
+		Schedule(ctx context.Context, tick time.Duration)
+	}
+	ParseJobServices interface {
+		StartParse(ctx context.Context, upload models.ResumeUpload, release func()) *models.ParseJob
+		Events(ctx context.Context, id uuid.UUID, userID uuid.UUID, lastID int) (<-chan models.ParseEvent, error)
+	}
+	QuotaServices interface {
+		ParseQuota(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, error)
+		ReserveParse(ctx context.Context, userID uuid.UUID) (*models.ParseQuota, func(), error)
+	}
+	TitleServices interface {
+		Suggest(ctx context.Context, query models.SuggestQuery) ([]models.TitleSuggestion, error)
+	}
+	RelevancyServices interface {
+		BatchCalculateRelevancy(ctx context.Context, skills []string, experience string, jobs []models.RelevancyJob) []models.RelevancyResult
 	}
 }
 
-func NewService(repo repositories.Storage, logger *zap.SugaredLogger, mailer mailer.Client) Service {
+func NewService(repo repositories.Storage, logger *zap.SugaredLogger, mailer mailer.Client, grpc jobpb.JobServiceClient, resumeParser parser.ResumeParser, frontendURL string, apiURL string) Service {
+	resumes := ResumeServices{
+		repo:   repo,
+		logger: logger,
+	}
 	return Service{
 		UserServices: UserServices{
 			repo:   repo,
@@ -38,5 +179,104 @@ func NewService(repo repositories.Storage, logger *zap.SugaredLogger, mailer mai
 			repo:   repo,
 			logger: logger,
 		},
+		ResumeServices: resumes,
+		SkillServices: SkillServices{
+			repo:   repo,
+			logger: logger,
+		},
+		ATSServices: ATSServices{
+			repo:     repo,
+			logger:   logger,
+			keywords: parser.NewLocalParser(),
+		},
+		ExportServices: ExportServices{
+			repo:    repo,
+			logger:  logger,
+			resumes: resumes,
+		},
+		ShareServices: ShareServices{
+			repo:   repo,
+			logger: logger,
+		},
+		LinkServices: LinkServices{
+			repo:     repo,
+			logger:   logger,
+			attempts: limiter.NewBackoff(LinkPasswordFreeAttempts, LinkPasswordBackoff, LinkPasswordMaxBackoff),
+		},
+		JobServices: JobServices{
+			repo:   repo,
+			logger: logger,
+		},
+		IngestServices: IngestServices{
+			repo:   repo,
+			logger: logger,
+			client: &http.Client{Timeout: IngestFetchTimeout},
+		},
+		AlertServices: AlertServices{
+			repo:        repo,
+			logger:      logger,
+			mailer:      mailer,
+			frontendURL: frontendURL,
+			apiURL:      apiURL,
+		},
+		ApplicationServices: ApplicationServices{
+			repo:        repo,
+			logger:      logger,
+			mailer:      mailer,
+			frontendURL: frontendURL,
+		},
+		BookmarkServices: BookmarkServices{
+			repo:        repo,
+			logger:      logger,
+			mailer:      mailer,
+			frontendURL: frontendURL,
+		},
+		CompanyServices: CompanyServices{
+			repo:        repo,
+			logger:      logger,
+			mailer:      mailer,
+			frontendURL: frontendURL,
+		},
+		RecommendationServices: RecommendationServices{
+			repo:   repo,
+			logger: logger,
+			relevancy: RelevancyServices{
+				grpc:   grpc,
+				logger: logger,
+			},
+		},
+		ParseJobServices: ParseJobServices{
+			repo:    repo,
+			logger:  logger,
+			parser:  resumeParser,
+			jobs:    newParseJobs(),
+			resumes: resumes,
+		},
+		QuotaServices: QuotaServices{
+			repo:   repo,
+			logger: logger,
+		},
+		TitleServices: TitleServices{
+			repo:   repo,
+			logger: logger,
+		},
+		RelevancyServices: RelevancyServices{
+			grpc:   grpc,
+			logger: logger,
+		},
+	}
+}
+
+// schedule calls run every tick, starting right away, until ctx is done.
+func schedule(ctx context.Context, tick time.Duration, run func(context.Context)) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		run(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
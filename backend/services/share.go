@@ -0,0 +1,110 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/parser"
+	"Inquiro/repositories"
+	"Inquiro/utils/pdf"
+	"Inquiro/utils/redact"
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrNoRedactedFile = errors.New("no redacted file for this share")
+
+type ShareServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+}
+
+// ShareResume gives the mentor a redacted copy of the resume. Personal data
+// is hidden unless its kind is listed in payload.Reveal. When text can be
+// extracted from the original file a redacted PDF rendition is stored too.
+func (s ShareServices) ShareResume(ctx context.Context, user *models.User, resumeID uuid.UUID, payload models.ResumeSharePayload) (*models.ResumeShare, error) {
+	resume, err := s.repo.Resume.GetByID(ctx, resumeID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	mentor, err := s.repo.Mentor.FindActiveByUsername(ctx, payload.Mentor)
+	if err != nil {
+		return nil, err
+	}
+
+	text := resume.ExtractedText
+	if text == "" {
+		if text, err = parser.ExtractText(resume.FileContent); err != nil {
+			s.logger.Infow("Sharing resume without text", "resume", resume.ID, "error : ", err.Error())
+		}
+	}
+	redacted, report := redact.Default(candidateTerms(user)).Redact(text, redact.ParseKinds(payload.Reveal))
+
+	share := &models.ResumeShare{
+		ResumeID:       resume.ID,
+		UserID:         user.ID,
+		MentorID:       mentor.ID,
+		MentorUsername: mentor.Username,
+		Reveal:         nonNil(payload.Reveal),
+		Redactions:     map[string]int{},
+		RedactedText:   redacted,
+	}
+	for kind, count := range report {
+		share.Redactions[string(kind)] = count
+	}
+	if strings.TrimSpace(redacted) != "" {
+		share.RedactedFile = pdf.Render(redactedFileName(resume.FileName), redacted)
+	}
+	if err := s.repo.ResumeShare.Create(ctx, share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// candidateTerms are spellings that identify the candidate but that the
+// generic detectors cannot know, such as a bare username.
+func candidateTerms(user *models.User) map[string]redact.Kind {
+	terms := map[string]redact.Kind{}
+	if user.Email != "" {
+		terms[user.Email] = redact.KindEmail
+	}
+	if user.Username != "" {
+		terms[user.Username] = redact.KindLink
+	}
+	return terms
+}
+
+func redactedFileName(fileName string) string {
+	return strings.TrimSuffix(fileName, path.Ext(fileName)) + "-redacted.pdf"
+}
+
+func (s ShareServices) ListShares(ctx context.Context, resumeID uuid.UUID, userID uuid.UUID, pagination *models.PaginatedQuery) ([]models.ResumeShare, error) {
+	return s.repo.ResumeShare.ListByResume(ctx, resumeID, userID, pagination)
+}
+
+func (s ShareServices) RevokeShare(ctx context.Context, id uuid.UUID, resumeID uuid.UUID, userID uuid.UUID) error {
+	return s.repo.ResumeShare.Revoke(ctx, id, resumeID, userID)
+}
+
+func (s ShareServices) ListSharedWithMentor(ctx context.Context, mentorID uuid.UUID, pagination *models.PaginatedQuery) ([]models.SharedResume, error) {
+	return s.repo.ResumeShare.ListByMentor(ctx, mentorID, pagination)
+}
+
+func (s ShareServices) GetSharedResume(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (*models.SharedResume, error) {
+	return s.repo.ResumeShare.GetForMentor(ctx, id, mentorID)
+}
+
+// GetSharedFile returns the name and content of the redacted rendition.
+func (s ShareServices) GetSharedFile(ctx context.Context, id uuid.UUID, mentorID uuid.UUID) (string, []byte, error) {
+	resume, err := s.repo.ResumeShare.GetFileForMentor(ctx, id, mentorID)
+	if err != nil {
+		return "", nil, err
+	}
+	if resume.Share.RedactedFile == nil {
+		return "", nil, ErrNoRedactedFile
+	}
+	return redactedFileName(resume.FileName), resume.Share.RedactedFile, nil
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"Inquiro/utils/json"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const defaultSkillCategory = "other"
+
+var ErrInvalidSkillCSV = errors.New("invalid skills csv")
+
+type SkillServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+}
+
+func skillFromPayload(payload models.SkillPayload) *models.Skill {
+	category := strings.ToLower(strings.TrimSpace(payload.Category))
+	if category == "" {
+		category = defaultSkillCategory
+	}
+	return &models.Skill{
+		Name:     strings.TrimSpace(payload.Name),
+		Category: category,
+		Aliases:  payload.Aliases,
+	}
+}
+
+func (s SkillServices) CreateSkill(ctx context.Context, payload models.SkillPayload) (*models.Skill, error) {
+	skill := skillFromPayload(payload)
+	if err := s.repo.Skill.Create(ctx, skill); err != nil {
+		return nil, err
+	}
+	return skill, nil
+}
+
+func (s SkillServices) UpdateSkill(ctx context.Context, id int64, payload models.SkillPayload) (*models.Skill, error) {
+	skill := skillFromPayload(payload)
+	skill.ID = id
+	if err := s.repo.Skill.Update(ctx, skill); err != nil {
+		return nil, err
+	}
+	return skill, nil
+}
+
+func (s SkillServices) DeleteSkill(ctx context.Context, id int64) error {
+	return s.repo.Skill.Delete(ctx, id)
+}
+
+func (s SkillServices) ListSkills(ctx context.Context, category string, pagination *models.PaginatedQuery) ([]models.Skill, error) {
+	return s.repo.Skill.List(ctx, strings.ToLower(strings.TrimSpace(category)), pagination)
+}
+
+func (s SkillServices) ListUnknown(ctx context.Context, pagination *models.PaginatedQuery) ([]models.UnknownSkill, error) {
+	return s.repo.Skill.ListUnknown(ctx, pagination)
+}
+
+func (s SkillServices) Suggest(ctx context.Context, query models.SuggestQuery) ([]models.SkillSuggestion, error) {
+	return s.repo.Skill.Suggest(ctx, query.Query, query.Limit)
+}
+
+// Normalize maps the names onto the catalog without recording anything, so
+// lookups do not count as demand for unknown skills.
+func (s SkillServices) Normalize(ctx context.Context, names []string) ([]models.NormalizedSkill, error) {
+	return normalizeSkills(ctx, s.repo, names)
+}
+
+// ImportCSV reads rows of name, category and aliases, where aliases are
+// separated by "|" or ";". A header row is optional. Rows that fail are
+// reported in the result and do not stop the import.
+func (s SkillServices) ImportCSV(ctx context.Context, source io.Reader) (*models.SkillImportResult, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	// The whole file is read before the first skill is imported, so a file
+	// that cannot be read leaves the catalog untouched
+	type row struct {
+		line   int
+		record []string
+	}
+	result := &models.SkillImportResult{Errors: []string{}}
+	rows := []row{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: %v", parseErr.Line, parseErr.Err))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSkillCSV, err)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, row{line: line, record: record})
+	}
+
+	for i, row := range rows {
+		line, record := row.line, row.record
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "name") {
+			continue
+		}
+		name := strings.TrimSpace(record[0])
+		if name == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: missing skill name", line))
+			continue
+		}
+		payload := models.SkillPayload{Name: name}
+		if len(record) > 1 {
+			payload.Category = record[1]
+		}
+		if len(record) > 2 {
+			payload.Aliases = strings.FieldsFunc(record[2], func(r rune) bool {
+				return r == '|' || r == ';'
+			})
+		}
+		if err := json.Validate.Struct(payload); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: %s", line, err.Error()))
+			continue
+		}
+		created, skipped, err := s.repo.Skill.Upsert(ctx, skillFromPayload(payload))
+		if err != nil {
+			s.logger.Warnw("Could not import skill", "line : ", line, "error : ", err.Error())
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: could not import %q", line, name))
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+		for _, alias := range skipped {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: alias %q belongs to another skill", line, alias))
+		}
+	}
+	return result, nil
+}
+
+// normalizeSkills maps free text skills onto the catalog. Spellings that are
+// not in the catalog are kept as they are.
+func normalizeSkills(ctx context.Context, repo repositories.Storage, names []string) ([]models.NormalizedSkill, error) {
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		if key := models.SkillKey(name); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	resolved, err := repo.Skill.Resolve(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]models.NormalizedSkill, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := models.SkillKey(name)
+		if key == "" {
+			continue
+		}
+		if skill, ok := resolved[key]; ok {
+			normalized = append(normalized, models.NormalizedSkill{Input: name, SkillID: skill.ID, Name: skill.Name, Known: true})
+			continue
+		}
+		normalized = append(normalized, models.NormalizedSkill{Input: name, Name: name})
+	}
+	return normalized, nil
+}
+
+// canonicalSkills rewrites skills to their catalog names and returns the IDs
+// of the known ones.
+func canonicalSkills(ctx context.Context, repo repositories.Storage, skills []string) ([]string, []int64, error) {
+	normalized, err := normalizeSkills(ctx, repo, skills)
+	if err != nil {
+		return nil, nil, err
+	}
+	names, ids := canonicalNames(normalized)
+	return names, ids, nil
+}
+
+// ingestSkills is canonicalSkills for skills coming into the catalog's
+// reach from resumes and job postings. Their unknown spellings are recorded
+// for curation.
+func ingestSkills(ctx context.Context, repo repositories.Storage, logger *zap.SugaredLogger, skills []string) ([]string, []int64, error) {
+	normalized, err := normalizeSkills(ctx, repo, skills)
+	if err != nil {
+		return nil, nil, err
+	}
+	unknown := map[string]string{}
+	for _, skill := range normalized {
+		if !skill.Known {
+			unknown[models.SkillKey(skill.Input)] = skill.Input
+		}
+	}
+	if err := repo.Skill.RecordUnknown(ctx, unknown); err != nil {
+		// Curation data is best effort and must not fail the caller
+		logger.Warnw("Could not record unknown skills", "error : ", err.Error())
+	}
+	names, ids := canonicalNames(normalized)
+	return names, ids, nil
+}
+
+// recordUsage bumps the popularity of the skills and job titles that
+// suggestions are weighted by. Failures are logged and ignored.
+func recordUsage(ctx context.Context, repo repositories.Storage, logger *zap.SugaredLogger, skillIDs []int64, titles []string) {
+	if err := repo.Skill.RecordUsage(ctx, skillIDs); err != nil {
+		logger.Warnw("Could not record skill usage", "error : ", err.Error())
+	}
+	if err := repo.Title.Record(ctx, titles); err != nil {
+		logger.Warnw("Could not record job titles", "error : ", err.Error())
+	}
+}
+
+// canonicalNames returns the names of the normalized skills, each once, and
+// the IDs of the known ones.
+func canonicalNames(normalized []models.NormalizedSkill) ([]string, []int64) {
+	names := make([]string, 0, len(normalized))
+	ids := []int64{}
+	seen := map[string]bool{}
+	for _, skill := range normalized {
+		// "golang" and "Go" both become "Go" and are only kept once
+		key := models.SkillKey(skill.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if skill.Known {
+			ids = append(ids, skill.SkillID)
+		}
+		names = append(names, skill.Name)
+	}
+	return names, ids
+}
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"Inquiro/models"
+	"Inquiro/repositories"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type TitleServices struct {
+	repo   repositories.Storage
+	logger *zap.SugaredLogger
+}
+
+func (t TitleServices) Suggest(ctx context.Context, query models.SuggestQuery) ([]models.TitleSuggestion, error) {
+	return t.repo.Title.Suggest(ctx, query.Query, query.Limit)
+}
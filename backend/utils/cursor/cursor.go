@@ -0,0 +1,103 @@
+package cursor
+
+import (
+	"Inquiro/config/env"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalid = errors.New("invalid cursor")
+
+// Signer encodes the position of a page in a list as an opaque token. The
+// token is signed so clients cannot make up positions, and names the list it
+// was made for so it cannot be used on another.
+type Signer struct {
+	secret []byte
+}
+
+var Default *Signer
+
+// Ephemeral tells that CURSOR_SECRET is not set and Default signs with a
+// random secret instead, so its cursors break when the backend restarts and
+// are not accepted by other instances.
+var Ephemeral bool
+
+func init() {
+	secret := env.GetString("CURSOR_SECRET", "")
+	if secret == "" {
+		random := make([]byte, 32)
+		rand.Read(random)
+		secret = string(random)
+		Ephemeral = true
+	}
+	Default = NewSigner(secret)
+}
+
+func NewSigner(secret string) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+	}
+}
+
+type position struct {
+	Scope string            `json:"s"`
+	Keys  []json.RawMessage `json:"k"`
+}
+
+// Encode returns the token of the position given by the sort keys of the
+// last row of a page in the list named scope.
+func (s *Signer) Encode(scope string, keys ...any) (string, error) {
+	pos := position{Scope: scope, Keys: make([]json.RawMessage, 0, len(keys))}
+	for _, key := range keys {
+		raw, err := json.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+		pos.Keys = append(pos.Keys, raw)
+	}
+	payload, err := json.Marshal(pos)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// Decode checks the token was made for the list named scope and reads its
+// sort keys into keys, which must be pointers to values of the types given
+// to Encode.
+func (s *Signer) Decode(token string, scope string, keys ...any) error {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return ErrInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalid
+	}
+	var pos position
+	if err := json.Unmarshal(payload, &pos); err != nil || pos.Scope != scope || len(pos.Keys) != len(keys) {
+		return ErrInvalid
+	}
+	for i, key := range keys {
+		if err := json.Unmarshal(pos.Keys[i], key); err != nil {
+			return ErrInvalid
+		}
+	}
+	return nil
+}
+
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
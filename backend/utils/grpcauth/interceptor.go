@@ -0,0 +1,60 @@
+package grpcauth
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey is the metadata key carrying the request id.
+const RequestIDKey = "x-request-id"
+
+// requestID returns the id chi's RequestID middleware gave the HTTP request,
+// or a new one for calls made outside a request.
+func requestID(ctx context.Context) string {
+	if id := middleware.GetReqID(ctx); id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// outgoing adds the request id and, with a signer, the service token to the
+// outgoing metadata of ctx.
+func outgoing(ctx context.Context, signer *Signer, method string) (context.Context, error) {
+	id := requestID(ctx)
+	pairs := []string{RequestIDKey, id}
+	if signer != nil {
+		token, err := signer.Sign(method, id)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, "authorization", "Bearer "+token)
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...), nil
+}
+
+// UnaryClientInterceptor attaches the metadata to unary calls. signer may be
+// nil, in which case only the request id is sent.
+func UnaryClientInterceptor(signer *Signer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := outgoing(ctx, signer, method)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is UnaryClientInterceptor for streams.
+func StreamClientInterceptor(signer *Signer) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := outgoing(ctx, signer, method)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,47 @@
+package grpcauth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// Issuer and Audience are the iss and aud claims of service tokens.
+	Issuer   = "inquiro-backend"
+	Audience = "job-service"
+	// TokenLifetime is short since a token is minted for every call.
+	TokenLifetime = time.Minute
+)
+
+type claims struct {
+	jwt.RegisteredClaims
+	RequestID string `json:"rid"`
+}
+
+// Signer mints HS256 JWTs with a secret shared with the job service. The
+// token names the gRPC method and request id it was minted for, so it cannot
+// be replayed against another method.
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret string) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+	}
+}
+
+func (s *Signer) Sign(method string, requestID string) (string, error) {
+	now := time.Now()
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    Issuer,
+			Audience:  jwt.ClaimStrings{Audience},
+			Subject:   method,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenLifetime)),
+		},
+		RequestID: requestID,
+	}).SignedString(s.secret)
+}
@@ -0,0 +1,85 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// Backoff slows down repeated attempts on a key, such as password guesses on
+// one resource. The first free attempts go through right away, after that
+// every attempt locks the key for twice as long as the previous one, up to
+// maxWait. A successful attempt resets the key. State is held in memory, so
+// it is per process and lost on restart.
+type Backoff struct {
+	mu        sync.Mutex
+	free      int
+	base      time.Duration
+	maxWait   time.Duration
+	keys      map[string]*backoffKey
+	lastSweep time.Time
+}
+
+type backoffKey struct {
+	attempts    int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+func NewBackoff(free int, base time.Duration, maxWait time.Duration) *Backoff {
+	return &Backoff{
+		free:    max(free, 0),
+		base:    base,
+		maxWait: maxWait,
+		keys:    map[string]*backoffKey{},
+	}
+}
+
+// Attempt counts an attempt on key before it is made, so that concurrent
+// guesses cannot slip in before the failures are recorded. It returns false
+// while the key is locked.
+func (b *Backoff) Attempt(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.sweep(now)
+	k, ok := b.keys[key]
+	if !ok || now.Sub(k.last) > b.maxWait {
+		// A key left alone for the longest wait starts over
+		k = &backoffKey{}
+		b.keys[key] = k
+	}
+	if now.Before(k.lockedUntil) {
+		return false
+	}
+	k.attempts++
+	k.last = now
+	if over := k.attempts - b.free; over > 0 {
+		wait := b.maxWait
+		if over <= 32 {
+			wait = min(b.base<<(over-1), b.maxWait)
+		}
+		k.lockedUntil = now.Add(wait)
+	}
+	return true
+}
+
+// Reset forgets the attempts on key, after one succeeded.
+func (b *Backoff) Reset(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.keys, key)
+}
+
+// sweep drops the keys that would start over anyway, at most once per
+// maxWait, so that the map does not grow with every key ever tried.
+func (b *Backoff) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < b.maxWait {
+		return
+	}
+	b.lastSweep = now
+	for key, k := range b.keys {
+		if now.Sub(k.last) > b.maxWait && !now.Before(k.lockedUntil) {
+			delete(b.keys, key)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Package limiter caps the number of concurrent calls to a backend. Callers
+// over the cap wait in a bounded queue instead of piling onto the backend.
+// It also slows down repeated failed attempts, see Backoff.
+package limiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+var (
+	// ErrQueueFull is returned when every slot is busy and the queue is full.
+	ErrQueueFull = errors.New("too many requests waiting for the job service")
+	// ErrQueueTimeout is returned when no slot frees up within the wait time.
+	ErrQueueTimeout = errors.New("timed out waiting for the job service")
+)
+
+// Semaphore allows up to a fixed number of holders at once and queues up to
+// maxQueue more for at most wait each.
+type Semaphore struct {
+	slots    chan struct{}
+	maxQueue int64
+	wait     time.Duration
+	queued   atomic.Int64
+}
+
+func NewSemaphore(capacity int, maxQueue int, wait time.Duration) *Semaphore {
+	return &Semaphore{
+		slots:    make(chan struct{}, max(capacity, 1)),
+		maxQueue: int64(max(maxQueue, 0)),
+		wait:     wait,
+	}
+}
+
+// Acquire takes a slot, waiting in the queue if none is free. The caller
+// must call Release once done.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if s.queued.Add(1) > s.maxQueue {
+		s.queued.Add(-1)
+		return ErrQueueFull
+	}
+	defer s.queued.Add(-1)
+
+	timer := time.NewTimer(s.wait)
+	defer timer.Stop()
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrQueueTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Semaphore) Release() {
+	<-s.slots
+}
+
+// UnaryClientInterceptor holds a slot of s for the duration of every unary
+// call made through the connection.
+func UnaryClientInterceptor(s *Semaphore) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := s.Acquire(ctx); err != nil {
+			return err
+		}
+		defer s.Release()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor holds a slot of s from the start of a stream until
+// it ends, either by an error from RecvMsg or by its context finishing.
+func StreamClientInterceptor(s *Semaphore) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := s.Acquire(ctx); err != nil {
+			return nil, err
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			s.Release()
+			return nil, err
+		}
+		limited := &limitedStream{ClientStream: stream}
+		limited.release = func() { limited.once.Do(s.Release) }
+		go func() {
+			<-stream.Context().Done()
+			limited.release()
+		}()
+		return limited, nil
+	}
+}
+
+type limitedStream struct {
+	grpc.ClientStream
+	once    sync.Once
+	release func()
+}
+
+func (l *limitedStream) RecvMsg(m any) error {
+	err := l.ClientStream.RecvMsg(m)
+	if err != nil {
+		l.release()
+	}
+	return err
+}
@@ -5,9 +5,13 @@ import (
 )
 
 const (
-	FromName               = "BloggerSpot"
-	MaxRetries             = 3
-	UserActivationTemplate = "user_invitation.tmpl"
+	FromName                  = "BloggerSpot"
+	MaxRetries                = 3
+	UserActivationTemplate    = "user_invitation.tmpl"
+	JobAlertTemplate          = "job_alert.tmpl"
+	ApplicationStatusTemplate = "application_status.tmpl"
+	BookmarkReminderTemplate  = "bookmark_reminder.tmpl"
+	CompanyInviteTemplate     = "company_invite.tmpl"
 )
 
 //go:embed "templates"
@@ -15,4 +19,6 @@ var FS embed.FS
 
 type Client interface {
 	Send(templateFile, username string, email []string, data any) error
+	// SendWithHeaders sends like Send, adding headers to the message.
+	SendWithHeaders(templateFile, username string, email []string, data any, headers map[string]string) error
 }
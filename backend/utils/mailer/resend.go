@@ -25,6 +25,10 @@ func NewResendClient(apiKey string, fromEmail string, logger *zap.SugaredLogger)
 }
 
 func (r *ResendClient) Send(templateFile, username string, email []string, data any) error {
+	return r.SendWithHeaders(templateFile, username, email, data, nil)
+}
+
+func (r *ResendClient) SendWithHeaders(templateFile, username string, email []string, data any, headers map[string]string) error {
 	templ, err := template.ParseFS(FS, "templates/"+templateFile)
 	if err != nil {
 		r.logger.Errorw("error with template parsing",
@@ -42,6 +46,7 @@ func (r *ResendClient) Send(templateFile, username string, email []string, data
 		To:      email,
 		Subject: templateFile,
 		Html:    body.String(),
+		Headers: headers,
 	}
 	_, err = r.client.Emails.Send(params)
 	if err != nil {
@@ -0,0 +1,362 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+var (
+	ErrNotPDF   = errors.New("file is not a pdf")
+	ErrNoText   = errors.New("no text found in pdf")
+	ErrTooLarge = errors.New("pdf content is too large")
+	streamExpr  = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n`)
+)
+
+// Limits on what one document may expand to, so a small upload of highly
+// compressed streams or huge CMap ranges cannot exhaust memory.
+var (
+	// MaxDecodedBytes caps the bytes inflated from all the streams of a
+	// document.
+	MaxDecodedBytes int64 = 32 << 20
+	// MaxCMapEntries caps the codes read from all the CMaps of a document.
+	MaxCMapEntries = 1 << 18
+)
+
+// IsPDF reports whether content starts with the PDF header.
+func IsPDF(content []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(content, " \t\r\n"), []byte("%PDF-"))
+}
+
+// ExtractText returns the text drawn by the content streams of the document.
+// It understands uncompressed and FlateDecode streams, literal and hex
+// strings and ToUnicode CMaps, which covers the PDFs produced by common
+// word processors. Encrypted documents and scanned images yield ErrNoText.
+func ExtractText(content []byte) (string, error) {
+	if !IsPDF(content) {
+		return "", ErrNotPDF
+	}
+	streams, err := readStreams(content)
+	if err != nil {
+		return "", err
+	}
+	cmap := map[string]string{}
+	entries := 0
+	for _, s := range streams {
+		if bytes.Contains(s, []byte("begincmap")) {
+			if err := parseCMap(s, cmap, &entries); err != nil {
+				return "", err
+			}
+		}
+	}
+	var out strings.Builder
+	for _, s := range streams {
+		if bytes.Contains(s, []byte("begincmap")) || !bytes.Contains(s, []byte("BT")) {
+			continue
+		}
+		extractContent(s, cmap, &out)
+	}
+	text := strings.TrimSpace(out.String())
+	if text == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
+
+// readStreams returns the streams that may hold text, inflated. It fails
+// with ErrTooLarge once more than MaxDecodedBytes were inflated.
+func readStreams(content []byte) ([][]byte, error) {
+	var streams [][]byte
+	budget := MaxDecodedBytes
+	for _, loc := range streamExpr.FindAllSubmatchIndex(content, -1) {
+		dict := content[loc[2]:loc[3]]
+		start := loc[1]
+		end := bytes.Index(content[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		data := content[start : start+end]
+		switch {
+		case bytes.Contains(dict, []byte("/FlateDecode")):
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				continue
+			}
+			// Truncated streams still give us the text decoded so far
+			decoded, _ := io.ReadAll(io.LimitReader(r, budget+1))
+			r.Close()
+			budget -= int64(len(decoded))
+			if budget < 0 {
+				return nil, ErrTooLarge
+			}
+			streams = append(streams, decoded)
+		case bytes.Contains(dict, []byte("/Filter")):
+			// Images and other encodings never hold text
+			continue
+		default:
+			streams = append(streams, data)
+		}
+	}
+	return streams, nil
+}
+
+var (
+	bfcharExpr  = regexp.MustCompile(`(?s)beginbfchar(.*?)endbfchar`)
+	bfrangeExpr = regexp.MustCompile(`(?s)beginbfrange(.*?)endbfrange`)
+	hexExpr     = regexp.MustCompile(`<([0-9A-Fa-f]+)>`)
+)
+
+// parseCMap adds the codes mapped by a ToUnicode CMap to cmap, counting them
+// in entries. It fails with ErrTooLarge once the document's CMaps have read
+// more than MaxCMapEntries codes.
+func parseCMap(data []byte, cmap map[string]string, entries *int) error {
+	for _, block := range bfcharExpr.FindAllSubmatch(data, -1) {
+		codes := hexExpr.FindAllSubmatch(block[1], -1)
+		if *entries += len(codes) / 2; *entries > MaxCMapEntries {
+			return ErrTooLarge
+		}
+		for i := 0; i+1 < len(codes); i += 2 {
+			cmap[strings.ToUpper(string(codes[i][1]))] = decodeUTF16Hex(string(codes[i+1][1]))
+		}
+	}
+	for _, block := range bfrangeExpr.FindAllSubmatch(data, -1) {
+		for _, line := range strings.Split(string(block[1]), "\n") {
+			codes := hexExpr.FindAllStringSubmatch(line, -1)
+			if len(codes) < 3 || strings.Contains(line, "[") {
+				continue
+			}
+			lo, err1 := strconv.ParseUint(codes[0][1], 16, 32)
+			hi, err2 := strconv.ParseUint(codes[1][1], 16, 32)
+			dst, err3 := strconv.ParseUint(codes[2][1], 16, 32)
+			if err1 != nil || err2 != nil || err3 != nil || hi < lo || hi-lo > 0xffff {
+				continue
+			}
+			if *entries += int(hi-lo) + 1; *entries > MaxCMapEntries {
+				return ErrTooLarge
+			}
+			width := len(codes[0][1])
+			for c := lo; c <= hi; c++ {
+				cmap[strings.ToUpper(padHex(c, width))] = string(rune(dst + c - lo))
+			}
+		}
+	}
+	return nil
+}
+
+func padHex(v uint64, width int) string {
+	s := strconv.FormatUint(v, 16)
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
+
+func decodeUTF16Hex(h string) string {
+	var units []uint16
+	for i := 0; i+4 <= len(h); i += 4 {
+		v, err := strconv.ParseUint(h[i:i+4], 16, 16)
+		if err != nil {
+			return ""
+		}
+		units = append(units, uint16(v))
+	}
+	if len(units) == 0 && len(h) == 2 {
+		v, _ := strconv.ParseUint(h, 16, 8)
+		return string(rune(v))
+	}
+	return string(utf16.Decode(units))
+}
+
+// extractContent walks a content stream and writes the shown strings,
+// starting a new line for every positioning operator that moves down.
+func extractContent(data []byte, cmap map[string]string, out *strings.Builder) {
+	var operands []string
+	inText := false
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '(':
+			s, n := readLiteral(data[i:])
+			operands = append(operands, "L"+s)
+			i += n
+		case c == '<' && i+1 < len(data) && data[i+1] != '<':
+			end := bytes.IndexByte(data[i:], '>')
+			if end < 0 {
+				return
+			}
+			operands = append(operands, "H"+string(data[i+1:i+end]))
+			i += end + 1
+		case c == '/':
+			start := i
+			i++
+			for i < len(data) && !isSpace(data[i]) && !isDelimiter(data[i]) {
+				i++
+			}
+			operands = append(operands, "N"+string(data[start:i]))
+		case c == '[' || c == ']':
+			i++
+		case c == '%':
+			for i < len(data) && data[i] != '\n' && data[i] != '\r' {
+				i++
+			}
+		case isSpace(c):
+			i++
+		default:
+			start := i
+			for i < len(data) && !isSpace(data[i]) && !isDelimiter(data[i]) {
+				i++
+			}
+			if i == start {
+				i++
+				continue
+			}
+			token := string(data[start:i])
+			if isOperand(token) {
+				operands = append(operands, "N"+token)
+				continue
+			}
+			switch token {
+			case "BT":
+				inText = true
+			case "ET":
+				inText = false
+				out.WriteString("\n")
+			case "Tj", "TJ", "'", "\"":
+				if !inText {
+					break
+				}
+				if token == "'" || token == "\"" {
+					out.WriteString("\n")
+				}
+				for _, op := range operands {
+					switch op[0] {
+					case 'L':
+						out.WriteString(op[1:])
+					case 'H':
+						out.WriteString(decodeHexString(op[1:], cmap))
+					case 'N':
+						// Large negative kerning in TJ arrays is a word gap
+						if v, err := strconv.ParseFloat(op[1:], 64); err == nil && v < -200 {
+							out.WriteString(" ")
+						}
+					}
+				}
+			case "Td", "TD":
+				if len(operands) >= 2 {
+					if v, err := strconv.ParseFloat(operands[len(operands)-1][1:], 64); err == nil && v != 0 {
+						out.WriteString("\n")
+					} else {
+						out.WriteString(" ")
+					}
+				}
+			case "T*", "Tm":
+				out.WriteString("\n")
+			}
+			operands = operands[:0]
+		}
+	}
+}
+
+func readLiteral(data []byte) (string, int) {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				b.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return b.String(), i + 1
+			}
+			b.WriteByte(c)
+		case '\\':
+			i++
+			if i >= len(data) {
+				return b.String(), i
+			}
+			switch e := data[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r', '\r', '\n':
+			case 't':
+				b.WriteByte('\t')
+			case 'b', 'f':
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				j := i
+				for j < len(data) && j < i+3 && data[j] >= '0' && data[j] <= '7' {
+					j++
+				}
+				v, _ := strconv.ParseUint(string(data[i:j]), 8, 8)
+				b.WriteRune(rune(v))
+				i = j - 1
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			// Bytes above ASCII are read as Latin-1, the common simple font encoding
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String(), len(data)
+}
+
+func decodeHexString(h string, cmap map[string]string) string {
+	h = strings.ToUpper(strings.Join(strings.Fields(h), ""))
+	if len(h)%2 == 1 {
+		h += "0"
+	}
+	var b strings.Builder
+	if len(cmap) > 0 {
+		for _, width := range []int{4, 2} {
+			if len(h)%width != 0 {
+				continue
+			}
+			ok := true
+			var part strings.Builder
+			for i := 0; i < len(h); i += width {
+				s, found := cmap[h[i:i+width]]
+				if !found {
+					ok = false
+					break
+				}
+				part.WriteString(s)
+			}
+			if ok {
+				return part.String()
+			}
+		}
+	}
+	for i := 0; i+2 <= len(h); i += 2 {
+		v, err := strconv.ParseUint(h[i:i+2], 16, 8)
+		if err != nil {
+			continue
+		}
+		if v >= 32 {
+			b.WriteRune(rune(v))
+		}
+	}
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isOperand(token string) bool {
+	_, err := strconv.ParseFloat(token, 64)
+	return err == nil || token == "true" || token == "false" || token == "null"
+}
@@ -0,0 +1,254 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	pageWidth  = 595 // A4 in points
+	pageHeight = 842
+	pageMargin = 56
+	fontSize   = 10
+)
+
+// winAnsi maps the characters outside Latin-1 that WinAnsiEncoding can draw.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// Render lays text out on A4 pages in Helvetica and returns the PDF. Long
+// lines are wrapped at word boundaries and characters the standard fonts
+// cannot draw are replaced by "?". It is meant for plain renditions of
+// resumes, not for reproducing their layout.
+func Render(title string, text string) []byte {
+	doc := NewDocument(title)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		doc.Text(line, fontSize, false, 0)
+	}
+	return doc.Bytes()
+}
+
+// Document is a single column of text flowing over A4 pages, enough to lay
+// out a resume with headings and bullet points.
+type Document struct {
+	title string
+	lines []line
+}
+
+type line struct {
+	text   string
+	size   int
+	bold   bool
+	indent int
+	space  int // extra points above the line
+}
+
+func NewDocument(title string) *Document {
+	return &Document{title: title}
+}
+
+// Text adds a paragraph wrapped to the page width.
+func (d *Document) Text(text string, size int, bold bool, indent int) {
+	d.add(text, size, bold, indent, 0)
+}
+
+func (d *Document) Heading(text string, size int) {
+	d.add(text, size, true, 0, size/2)
+}
+
+// Bullet adds a paragraph whose wrapped lines align after the bullet.
+func (d *Document) Bullet(text string, size int) {
+	for i, l := range wrap(text, charsPerLine(size, false, 12)) {
+		if i == 0 {
+			d.lines = append(d.lines, line{text: "• " + l, size: size, indent: 0})
+		} else {
+			d.lines = append(d.lines, line{text: l, size: size, indent: 12})
+		}
+	}
+}
+
+// Space adds vertical space before the next line.
+func (d *Document) Space(points int) {
+	d.lines = append(d.lines, line{space: points})
+}
+
+func (d *Document) add(text string, size int, bold bool, indent int, space int) {
+	for i, l := range wrap(text, charsPerLine(size, bold, indent)) {
+		ln := line{text: l, size: size, bold: bold, indent: indent}
+		if i == 0 {
+			ln.space = space
+		}
+		d.lines = append(d.lines, ln)
+	}
+}
+
+// charsPerLine estimates how many characters fit a line from the average
+// glyph width of Helvetica, which is about half the font size.
+func charsPerLine(size int, bold bool, indent int) int {
+	width := float64(pageWidth - 2*pageMargin - indent)
+	glyph := 0.5 * float64(size)
+	if bold {
+		glyph = 0.55 * float64(size)
+	}
+	return max(int(width/glyph), 10)
+}
+
+func (d *Document) Bytes() []byte {
+	// Lines are placed on pages from the top, a page breaks when the next
+	// line would cross the bottom margin
+	var pages [][]placed
+	var page []placed
+	y := float64(pageHeight - pageMargin)
+	for _, l := range d.lines {
+		leading := float64(l.size) * 1.3
+		if l.text == "" && l.size == 0 {
+			y -= float64(l.space)
+			continue
+		}
+		if y-float64(l.space)-leading < pageMargin && len(page) > 0 {
+			pages = append(pages, page)
+			page = nil
+			y = float64(pageHeight - pageMargin)
+		} else {
+			y -= float64(l.space)
+		}
+		y -= leading
+		page = append(page, placed{line: l, y: y})
+	}
+	pages = append(pages, page)
+
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1 to 5 are fixed, then every page takes a page and a content object
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 6+2*i))
+	}
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	w.object(fmt.Sprintf("<< /Title %s /Producer (Inquirio) >>", literal(d.title)))
+	for i, page := range pages {
+		w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 7+2*i))
+		w.stream(content(page))
+	}
+	return w.finish()
+}
+
+type placed struct {
+	line
+	y float64
+}
+
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+func (w *writer) stream(data []byte) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(data)
+	zw.Close()
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", len(w.offsets), compressed.Len())
+	w.buf.Write(compressed.Bytes())
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *writer) finish() []byte {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xref)
+	return w.buf.Bytes()
+}
+
+func content(lines []placed) []byte {
+	var b bytes.Buffer
+	b.WriteString("BT\n")
+	for _, l := range lines {
+		font := "F1"
+		if l.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(&b, "/%s %d Tf\n1 0 0 1 %d %.1f Tm\n%s Tj\n", font, l.size, pageMargin+l.indent, l.y, literal(l.text))
+	}
+	b.WriteString("ET\n")
+	return b.Bytes()
+}
+
+// literal encodes s as a WinAnsi PDF string.
+func literal(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteString("    ")
+		case r < 0x20 || r == utf8.RuneError:
+			b.WriteByte(' ')
+		case r < 0x7f || (r >= 0xa0 && r <= 0xff):
+			b.WriteByte(byte(r))
+		default:
+			if c, ok := winAnsi[r]; ok {
+				b.WriteByte(c)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		line := ""
+		for _, word := range words {
+			for utf8.RuneCountInString(word) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				cut := []rune(word)
+				lines = append(lines, string(cut[:width]))
+				word = string(cut[width:])
+			}
+			switch {
+			case line == "":
+				line = word
+			case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
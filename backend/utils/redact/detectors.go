@@ -0,0 +1,133 @@
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// RegexDetector reports every match of Expr. When Valid is set, matches it
+// rejects are dropped.
+type RegexDetector struct {
+	Kind  Kind
+	Expr  *regexp.Regexp
+	Valid func(match string) bool
+}
+
+func (d RegexDetector) Detect(text string) []Match {
+	var matches []Match
+	for _, loc := range d.Expr.FindAllStringIndex(text, -1) {
+		if d.Valid != nil && !d.Valid(text[loc[0]:loc[1]]) {
+			continue
+		}
+		matches = append(matches, Match{Kind: d.Kind, Start: loc[0], End: loc[1]})
+	}
+	return matches
+}
+
+// profileSites are hosts whose links identify a person even without a
+// scheme, such as "linkedin.com/in/jane".
+var profileSites = []string{
+	"linkedin.com", "github.com", "gitlab.com", "bitbucket.org", "twitter.com", "x.com",
+	"medium.com", "dev.to", "behance.net", "dribbble.com", "stackoverflow.com",
+	"kaggle.com", "leetcode.com", "hackerrank.com", "codeforces.com", "instagram.com", "facebook.com",
+}
+
+// streetWords mark the end of a street address in English and Indian
+// resumes. The words before them must be capitalised, which keeps phrases
+// such as "led 5 engineers the right way" out.
+var streetWords = []string{
+	"street", "st", "avenue", "ave", "road", "rd", "boulevard", "blvd", "lane", "ln", "drive", "dr",
+	"court", "ct", "way", "place", "pl", "terrace", "highway", "hwy", "square", "sq",
+	"apartment", "apt", "suite", "floor", "block", "sector", "nagar", "colony", "marg", "layout", "chowk",
+}
+
+var (
+	emailDetector = RegexDetector{
+		Kind: KindEmail,
+		Expr: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	}
+	linkDetector = RegexDetector{
+		Kind: KindLink,
+		Expr: regexp.MustCompile(`(?i)(?:\bhttps?://|\bwww\.|\b(?:` + quoteAll(profileSites) + `)/)[^\s<>()"']+`),
+	}
+	phoneDetector = RegexDetector{
+		Kind:  KindPhone,
+		Expr:  regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{1,5}\)[\s.-]?)?\d[\d\s.-]{5,}\d`),
+		Valid: validPhone,
+	}
+	addressDetector = RegexDetector{
+		Kind: KindAddress,
+		Expr: regexp.MustCompile(`\b\d{1,6}[A-Za-z]?(?:[ ,/-]+\p{Lu}[\pL.'-]*){0,5}[ ,]+(?i:` + quoteAll(streetWords) + `)\b\.?` +
+			`(?:,?[ ]+[\pL\pN][\pL\pN.'-]*){0,4}`),
+	}
+)
+
+// validPhone keeps numbers with 8 to 15 digits and drops year ranges such as
+// "2019 - 2021" and dates that look like numbers.
+func validPhone(match string) bool {
+	digits := 0
+	for _, r := range match {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	if digits < 8 || digits > 15 {
+		return false
+	}
+	groups := strings.FieldsFunc(match, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '.'
+	})
+	if len(groups) == 2 && len(groups[0]) == 4 && len(groups[1]) == 4 && isYear(groups[0]) && isYear(groups[1]) {
+		return false
+	}
+	return !strings.Contains(match, "\n")
+}
+
+func isYear(s string) bool {
+	return strings.HasPrefix(s, "19") || strings.HasPrefix(s, "20")
+}
+
+// DictionaryDetector finds whole word occurrences of known terms, ignoring
+// case.
+type DictionaryDetector struct {
+	exprs []RegexDetector
+}
+
+func NewDictionaryDetector(terms map[string]Kind) DictionaryDetector {
+	byKind := map[Kind][]string{}
+	for term, kind := range terms {
+		if term = strings.TrimSpace(term); len(term) >= 3 {
+			byKind[kind] = append(byKind[kind], term)
+		}
+	}
+	detector := DictionaryDetector{}
+	for kind, words := range byKind {
+		// Longer terms first so "jane.doe" wins over "jane"
+		sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+		detector.exprs = append(detector.exprs, RegexDetector{
+			Kind: kind,
+			Expr: regexp.MustCompile(`(?i)(?:^|[^\pL\pN_])(` + quoteAll(words) + `)(?:$|[^\pL\pN_])`),
+		})
+	}
+	return detector
+}
+
+func (d DictionaryDetector) Detect(text string) []Match {
+	var matches []Match
+	for _, e := range d.exprs {
+		for _, loc := range e.Expr.FindAllStringSubmatchIndex(text, -1) {
+			matches = append(matches, Match{Kind: e.Kind, Start: loc[2], End: loc[3]})
+		}
+	}
+	return matches
+}
+
+func quoteAll(words []string) string {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		quoted = append(quoted, regexp.QuoteMeta(w))
+	}
+	return strings.Join(quoted, "|")
+}
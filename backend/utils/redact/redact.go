@@ -0,0 +1,121 @@
+// Package redact hides personal data in resume text before it is shown to
+// somebody other than the candidate.
+package redact
+
+import (
+	"sort"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindEmail   Kind = "email"
+	KindPhone   Kind = "phone"
+	KindAddress Kind = "address"
+	KindLink    Kind = "link"
+)
+
+// Kinds lists every kind a candidate can choose to reveal.
+var Kinds = []Kind{KindEmail, KindPhone, KindAddress, KindLink}
+
+// Match is a span of text a detector considers personal data.
+type Match struct {
+	Kind  Kind
+	Start int
+	End   int
+}
+
+type Detector interface {
+	Detect(text string) []Match
+}
+
+// Report counts the redactions made per kind. It never holds the redacted
+// values themselves.
+type Report map[Kind]int
+
+type Redactor struct {
+	detectors []Detector
+}
+
+func New(detectors ...Detector) *Redactor {
+	return &Redactor{detectors: detectors}
+}
+
+// Default returns a redactor with the built in detectors. terms are
+// candidate specific spellings, such as a username, hidden as the given kind
+// wherever they appear.
+func Default(terms map[string]Kind) *Redactor {
+	detectors := []Detector{emailDetector, linkDetector, phoneDetector, addressDetector}
+	if len(terms) > 0 {
+		detectors = append(detectors, NewDictionaryDetector(terms))
+	}
+	return New(detectors...)
+}
+
+// Redact replaces the personal data found in text by a placeholder naming
+// its kind. Kinds set in reveal are left as they are.
+func (r *Redactor) Redact(text string, reveal map[Kind]bool) (string, Report) {
+	var matches []Match
+	for _, detector := range r.detectors {
+		for _, m := range detector.Detect(text) {
+			if !reveal[m.Kind] && m.End > m.Start {
+				matches = append(matches, m)
+			}
+		}
+	}
+	report := Report{}
+	if len(matches) == 0 {
+		return text, report
+	}
+
+	// Overlapping spans are merged, the longer span decides the kind
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		return matches[i].End > matches[j].End
+	})
+	merged := []Match{matches[0]}
+	for _, m := range matches[1:] {
+		last := &merged[len(merged)-1]
+		if m.Start < last.End {
+			if m.End-m.Start > last.End-last.Start {
+				last.Kind = m.Kind
+			}
+			if m.End > last.End {
+				last.End = m.End
+			}
+			continue
+		}
+		merged = append(merged, m)
+	}
+
+	var b strings.Builder
+	at := 0
+	for _, m := range merged {
+		b.WriteString(text[at:m.Start])
+		b.WriteString(Placeholder(m.Kind))
+		report[m.Kind]++
+		at = m.End
+	}
+	b.WriteString(text[at:])
+	return b.String(), report
+}
+
+func Placeholder(kind Kind) string {
+	return "[" + strings.ToUpper(string(kind)) + " HIDDEN]"
+}
+
+// ParseKinds converts names to kinds, ignoring names that are not kinds.
+func ParseKinds(names []string) map[Kind]bool {
+	kinds := map[Kind]bool{}
+	for _, name := range names {
+		for _, kind := range Kinds {
+			if strings.EqualFold(name, string(kind)) {
+				kinds[kind] = true
+			}
+		}
+	}
+	return kinds
+}
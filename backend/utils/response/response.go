@@ -2,7 +2,10 @@ package response
 
 import (
 	"encoding/json"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 type APIResponse struct {
@@ -12,6 +15,13 @@ type APIResponse struct {
 	Error   APIError    `json:"error"`
 }
 
+// PageResponse is the response of a page of a list. NextCursor is null on
+// the last page.
+type PageResponse struct {
+	APIResponse
+	NextCursor *string `json:"next_cursor"`
+}
+
 type APIError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -27,6 +37,41 @@ func Success(w http.ResponseWriter, r *http.Request, message string, data interf
 	})
 }
 
+// Page sends a page of a list like Success. next is the cursor of the
+// following page, empty on the last one; it is sent as next_cursor and, with
+// the first page, in a Link header (RFC 8288).
+func Page(w http.ResponseWriter, r *http.Request, message string, data interface{}, next string, statusCode int) {
+	links := pageLink(r, "", "first")
+	page := PageResponse{
+		APIResponse: APIResponse{
+			Status:  "success",
+			Message: message,
+			Data:    data,
+		},
+	}
+	if next != "" {
+		links += ", " + pageLink(r, next, "next")
+		page.NextCursor = &next
+	}
+	w.Header().Set("Link", links)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(page)
+}
+
+// pageLink is a link to the request's list starting at cursor, at the first
+// page without one.
+func pageLink(r *http.Request, cursor string, rel string) string {
+	query := r.URL.Query()
+	query.Del("offset")
+	query.Del("cursor")
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	target := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return "<" + target.String() + `>; rel="` + rel + `"`
+}
+
 func Error(w http.ResponseWriter, r *http.Request, message string, e_message string, code int, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -39,3 +84,12 @@ func Error(w http.ResponseWriter, r *http.Request, message string, e_message str
 		},
 	})
 }
+
+// File sends data as a download named fileName.
+func File(w http.ResponseWriter, r *http.Request, fileName string, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\tjob.proto\x12\x03job\"D\n\x12ParseResumeRequest\x12\x1b\n\x13resume_file_content\x18\x01 \x01(\x0c\x12\x11\n\tfile_name\x18\x02 \x01(\t\"M\n\x13ParseResumeResponse\x12\x12\n\njob_titles\x18\x01 \x03(\t\x12\x0e\n\x06skills\x18\x02 \x03(\t\x12\x12\n\nexperience\x18\x03 \x01(\x05\"f\n\x19\x43\x61lculateRelevancyRequest\x12\x15\n\rresume_skills\x18\x01 \x03(\t\x12\x19\n\x11resume_experience\x18\x02 \x01(\t\x12\x17\n\x0fjob_description\x18\x03 \x01(\t\"5\n\x1a\x43\x61lculateRelevancyResponse\x12\x17\n\x0frelevancy_score\x18\x01 \x01(\x01\x32\xa9\x01\n\nJobService\x12\x42\n\x0bParseResume\x12\x17.job.ParseResumeRequest\x1a\x18.job.ParseResumeResponse\"\x00\x12W\n\x12\x43\x61lculateRelevancy\x12\x1e.job.CalculateRelevancyRequest\x1a\x1f.job.CalculateRelevancyResponse\"\x00\x62\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\tjob.proto\x12\x03job\"D\n\x12ParseResumeRequest\x12\x1b\n\x13resume_file_content\x18\x01 \x01(\x0c\x12\x11\n\tfile_name\x18\x02 \x01(\t\"M\n\x13ParseResumeResponse\x12\x12\n\njob_titles\x18\x01 \x03(\t\x12\x0e\n\x06skills\x18\x02 \x03(\t\x12\x12\n\nexperience\x18\x03 \x01(\x05\"\xe4\x01\n\x13ParseResumeProgress\x12-\n\x05stage\x18\x01 \x01(\x0e\x32\x1e.job.ParseResumeProgress.Stage\x12\x0f\n\x07percent\x18\x02 \x01(\x05\x12\x0f\n\x07message\x18\x03 \x01(\t\x12(\n\x06result\x18\x04 \x01(\x0b\x32\x18.job.ParseResumeResponse\"R\n\x05Stage\x12\x15\n\x11STAGE_UNSPECIFIED\x10\x00\x12\x0e\n\nEXTRACTING\x10\x01\x12\x0b\n\x07PARSING\x10\x02\x12\x0b\n\x07SCORING\x10\x03\x12\x08\n\x04\x44ONE\x10\x04\"f\n\x19\x43\x61lculateRelevancyRequest\x12\x15\n\rresume_skills\x18\x01 \x03(\t\x12\x19\n\x11resume_experience\x18\x02 \x01(\t\x12\x17\n\x0fjob_description\x18\x03 \x01(\t\"5\n\x1a\x43\x61lculateRelevancyResponse\x12\x17\n\x0frelevancy_score\x18\x01 \x01(\x01\"7\n\x0cRelevancyJob\x12\x0e\n\x06job_id\x18\x01 \x01(\t\x12\x17\n\x0fjob_description\x18\x02 \x01(\t\"s\n\x1e\x42\x61tchCalculateRelevancyRequest\x12\x15\n\rresume_skills\x18\x01 \x03(\t\x12\x19\n\x11resume_experience\x18\x02 \x01(\t\x12\x1f\n\x04jobs\x18\x03 \x03(\x0b\x32\x11.job.RelevancyJob\"Y\n\x1f\x42\x61tchCalculateRelevancyResponse\x12\x0e\n\x06job_id\x18\x01 \x01(\t\x12\x17\n\x0frelevancy_score\x18\x02 \x01(\x01\x12\r\n\x05\x65rror\x18\x03 \x01(\t2\xe5\x02\n\nJobService\x12\x42\n\x0bParseResume\x12\x17.job.ParseResumeRequest\x1a\x18.job.ParseResumeResponse\"\x00\x12W\n\x12\x43\x61lculateRelevancy\x12\x1e.job.CalculateRelevancyRequest\x1a\x1f.job.CalculateRelevancyResponse\"\x00\x12h\n\x17\x42\x61tchCalculateRelevancy\x12#.job.BatchCalculateRelevancyRequest\x1a$.job.BatchCalculateRelevancyResponse\"\x00\x30\x01\x12P\n\x17ParseResumeWithProgress\x12\x17.job.ParseResumeRequest\x1a\x18.job.ParseResumeProgress\"\x00\x30\x01\x62\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -35,10 +35,20 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_PARSERESUMEREQUEST']._serialized_end=86
   _globals['_PARSERESUMERESPONSE']._serialized_start=88
   _globals['_PARSERESUMERESPONSE']._serialized_end=165
-  _globals['_CALCULATERELEVANCYREQUEST']._serialized_start=167
-  _globals['_CALCULATERELEVANCYREQUEST']._serialized_end=269
-  _globals['_CALCULATERELEVANCYRESPONSE']._serialized_start=271
-  _globals['_CALCULATERELEVANCYRESPONSE']._serialized_end=324
-  _globals['_JOBSERVICE']._serialized_start=327
-  _globals['_JOBSERVICE']._serialized_end=496
+  _globals['_PARSERESUMEPROGRESS']._serialized_start=168
+  _globals['_PARSERESUMEPROGRESS']._serialized_end=396
+  _globals['_PARSERESUMEPROGRESS_STAGE']._serialized_start=314
+  _globals['_PARSERESUMEPROGRESS_STAGE']._serialized_end=396
+  _globals['_CALCULATERELEVANCYREQUEST']._serialized_start=398
+  _globals['_CALCULATERELEVANCYREQUEST']._serialized_end=500
+  _globals['_CALCULATERELEVANCYRESPONSE']._serialized_start=502
+  _globals['_CALCULATERELEVANCYRESPONSE']._serialized_end=555
+  _globals['_RELEVANCYJOB']._serialized_start=557
+  _globals['_RELEVANCYJOB']._serialized_end=612
+  _globals['_BATCHCALCULATERELEVANCYREQUEST']._serialized_start=614
+  _globals['_BATCHCALCULATERELEVANCYREQUEST']._serialized_end=729
+  _globals['_BATCHCALCULATERELEVANCYRESPONSE']._serialized_start=731
+  _globals['_BATCHCALCULATERELEVANCYRESPONSE']._serialized_end=820
+  _globals['_JOBSERVICE']._serialized_start=823
+  _globals['_JOBSERVICE']._serialized_end=1180
 # @@protoc_insertion_point(module_scope)
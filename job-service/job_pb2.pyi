@@ -1,8 +1,9 @@
 from google.protobuf.internal import containers as _containers
+from google.protobuf.internal import enum_type_wrapper as _enum_type_wrapper
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
-from collections.abc import Iterable as _Iterable
-from typing import ClassVar as _ClassVar, Optional as _Optional
+from collections.abc import Iterable as _Iterable, Mapping as _Mapping
+from typing import ClassVar as _ClassVar, Optional as _Optional, Union as _Union
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
@@ -24,6 +25,30 @@ class ParseResumeResponse(_message.Message):
     experience: int
     def __init__(self, job_titles: _Optional[_Iterable[str]] = ..., skills: _Optional[_Iterable[str]] = ..., experience: _Optional[int] = ...) -> None: ...
 
+class ParseResumeProgress(_message.Message):
+    __slots__ = ("stage", "percent", "message", "result")
+    class Stage(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+        __slots__ = ()
+        STAGE_UNSPECIFIED: _ClassVar[ParseResumeProgress.Stage]
+        EXTRACTING: _ClassVar[ParseResumeProgress.Stage]
+        PARSING: _ClassVar[ParseResumeProgress.Stage]
+        SCORING: _ClassVar[ParseResumeProgress.Stage]
+        DONE: _ClassVar[ParseResumeProgress.Stage]
+    STAGE_UNSPECIFIED: ParseResumeProgress.Stage
+    EXTRACTING: ParseResumeProgress.Stage
+    PARSING: ParseResumeProgress.Stage
+    SCORING: ParseResumeProgress.Stage
+    DONE: ParseResumeProgress.Stage
+    STAGE_FIELD_NUMBER: _ClassVar[int]
+    PERCENT_FIELD_NUMBER: _ClassVar[int]
+    MESSAGE_FIELD_NUMBER: _ClassVar[int]
+    RESULT_FIELD_NUMBER: _ClassVar[int]
+    stage: ParseResumeProgress.Stage
+    percent: int
+    message: str
+    result: ParseResumeResponse
+    def __init__(self, stage: _Optional[_Union[ParseResumeProgress.Stage, str]] = ..., percent: _Optional[int] = ..., message: _Optional[str] = ..., result: _Optional[_Union[ParseResumeResponse, _Mapping]] = ...) -> None: ...
+
 class CalculateRelevancyRequest(_message.Message):
     __slots__ = ("resume_skills", "resume_experience", "job_description")
     RESUME_SKILLS_FIELD_NUMBER: _ClassVar[int]
@@ -39,3 +64,31 @@ class CalculateRelevancyResponse(_message.Message):
     RELEVANCY_SCORE_FIELD_NUMBER: _ClassVar[int]
     relevancy_score: float
     def __init__(self, relevancy_score: _Optional[float] = ...) -> None: ...
+
+class RelevancyJob(_message.Message):
+    __slots__ = ("job_id", "job_description")
+    JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    JOB_DESCRIPTION_FIELD_NUMBER: _ClassVar[int]
+    job_id: str
+    job_description: str
+    def __init__(self, job_id: _Optional[str] = ..., job_description: _Optional[str] = ...) -> None: ...
+
+class BatchCalculateRelevancyRequest(_message.Message):
+    __slots__ = ("resume_skills", "resume_experience", "jobs")
+    RESUME_SKILLS_FIELD_NUMBER: _ClassVar[int]
+    RESUME_EXPERIENCE_FIELD_NUMBER: _ClassVar[int]
+    JOBS_FIELD_NUMBER: _ClassVar[int]
+    resume_skills: _containers.RepeatedScalarFieldContainer[str]
+    resume_experience: str
+    jobs: _containers.RepeatedCompositeFieldContainer[RelevancyJob]
+    def __init__(self, resume_skills: _Optional[_Iterable[str]] = ..., resume_experience: _Optional[str] = ..., jobs: _Optional[_Iterable[_Union[RelevancyJob, _Mapping]]] = ...) -> None: ...
+
+class BatchCalculateRelevancyResponse(_message.Message):
+    __slots__ = ("job_id", "relevancy_score", "error")
+    JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    RELEVANCY_SCORE_FIELD_NUMBER: _ClassVar[int]
+    ERROR_FIELD_NUMBER: _ClassVar[int]
+    job_id: str
+    relevancy_score: float
+    error: str
+    def __init__(self, job_id: _Optional[str] = ..., relevancy_score: _Optional[float] = ..., error: _Optional[str] = ...) -> None: ...
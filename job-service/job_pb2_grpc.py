@@ -44,6 +44,16 @@ class JobServiceStub(object):
                 request_serializer=job__pb2.CalculateRelevancyRequest.SerializeToString,
                 response_deserializer=job__pb2.CalculateRelevancyResponse.FromString,
                 _registered_method=True)
+        self.BatchCalculateRelevancy = channel.unary_stream(
+                '/job.JobService/BatchCalculateRelevancy',
+                request_serializer=job__pb2.BatchCalculateRelevancyRequest.SerializeToString,
+                response_deserializer=job__pb2.BatchCalculateRelevancyResponse.FromString,
+                _registered_method=True)
+        self.ParseResumeWithProgress = channel.unary_stream(
+                '/job.JobService/ParseResumeWithProgress',
+                request_serializer=job__pb2.ParseResumeRequest.SerializeToString,
+                response_deserializer=job__pb2.ParseResumeProgress.FromString,
+                _registered_method=True)
 
 
 class JobServiceServicer(object):
@@ -61,6 +71,18 @@ class JobServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def BatchCalculateRelevancy(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def ParseResumeWithProgress(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_JobServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -74,6 +96,16 @@ def add_JobServiceServicer_to_server(servicer, server):
                     request_deserializer=job__pb2.CalculateRelevancyRequest.FromString,
                     response_serializer=job__pb2.CalculateRelevancyResponse.SerializeToString,
             ),
+            'BatchCalculateRelevancy': grpc.unary_stream_rpc_method_handler(
+                    servicer.BatchCalculateRelevancy,
+                    request_deserializer=job__pb2.BatchCalculateRelevancyRequest.FromString,
+                    response_serializer=job__pb2.BatchCalculateRelevancyResponse.SerializeToString,
+            ),
+            'ParseResumeWithProgress': grpc.unary_stream_rpc_method_handler(
+                    servicer.ParseResumeWithProgress,
+                    request_deserializer=job__pb2.ParseResumeRequest.FromString,
+                    response_serializer=job__pb2.ParseResumeProgress.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'job.JobService', rpc_method_handlers)
@@ -138,3 +170,57 @@ class JobService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def BatchCalculateRelevancy(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(
+            request,
+            target,
+            '/job.JobService/BatchCalculateRelevancy',
+            job__pb2.BatchCalculateRelevancyRequest.SerializeToString,
+            job__pb2.BatchCalculateRelevancyResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def ParseResumeWithProgress(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(
+            request,
+            target,
+            '/job.JobService/ParseResumeWithProgress',
+            job__pb2.ParseResumeRequest.SerializeToString,
+            job__pb2.ParseResumeProgress.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
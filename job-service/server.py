@@ -3,8 +3,11 @@ import grpc
 import job_pb2
 import job_pb2_grpc
 import logging
+import os
 from services.resume_parser import ResumeParser
 from services.resume_proccessing import ResumeProcessor
+from services.relevancy_scorer import RelevancyScorer
+from utils.service_auth import ServiceAuthInterceptor
 
 MAX_TEXT_PROCESSING_BYTES = 5 * 1024 * 1024
 
@@ -15,6 +18,7 @@ class JobServiceServicer(job_pb2_grpc.JobServiceServicer):
         logging.info("Initializing JobServiceServicer...")
         self.parser = ResumeParser()
         self.processor = ResumeProcessor(maxi_allowed_bytes=MAX_TEXT_PROCESSING_BYTES)
+        self.scorer = RelevancyScorer()
 
 
     def ParseResume(self, request, context):
@@ -33,12 +37,83 @@ class JobServiceServicer(job_pb2_grpc.JobServiceServicer):
         response.experience = parsed_data.experience
         return response
 
+    def ParseResumeWithProgress(self, request, context):
+        # Same work as ParseResume, reporting each stage before starting it
+        Progress = job_pb2.ParseResumeProgress
+        yield Progress(stage=Progress.EXTRACTING, percent=10, message="Extracting text from the resume")
+        resume_text = self.processor.process_raw_resume(request.resume_file_content,context)
+        if resume_text is None or not context.is_active():
+            return
+        yield Progress(stage=Progress.PARSING, percent=30, message="Reading titles, skills and experience")
+        parsed_data = self.parser.parse(resume_text,context)
+        if parsed_data is None:
+            context.set_code(grpc.StatusCode.UNAVAILABLE)
+            context.set_details("No data extracted from the resume")
+            return
+        if not context.is_active():
+            return
+        yield Progress(stage=Progress.SCORING, percent=90, message="Calculating experience")
+        result = job_pb2.ParseResumeResponse(
+            job_titles=parsed_data.job_titles or [],
+            skills=parsed_data.skills or [],
+            experience=max(int(parsed_data.experience or 0), 0),
+        )
+        yield Progress(stage=Progress.DONE, percent=100, message="Done", result=result)
+
+    def CalculateRelevancy(self, request, context):
+        response = job_pb2.CalculateRelevancyResponse()
+        try:
+            response.relevancy_score = self.scorer.score(
+                list(request.resume_skills), request.resume_experience, request.job_description)
+        except ValueError as e:
+            context.set_code(grpc.StatusCode.INVALID_ARGUMENT)
+            context.set_details(str(e))
+        return response
+
+    def BatchCalculateRelevancy(self, request, context):
+        # Every job gets its own response, a failing job does not end the stream
+        skills = list(request.resume_skills)
+        for job in request.jobs:
+            if not context.is_active():
+                logging.info("Batch relevancy cancelled by the client")
+                return
+            response = job_pb2.BatchCalculateRelevancyResponse(job_id=job.job_id)
+            try:
+                response.relevancy_score = self.scorer.score(
+                    skills, request.resume_experience, job.job_description)
+            except Exception as e:
+                logging.info(f"Failed to score job {job.job_id}: {e}")
+                response.error = str(e)
+            yield response
+
+def _read(path):
+    with open(path, "rb") as f:
+        return f.read()
+
 def serve():
-    server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
+    address = os.environ.get("JOB_SERVICE_ADDR", "[::]:50051")
+    interceptors = []
+    secret = os.environ.get("JOB_SERVICE_TOKEN_SECRET")
+    if secret:
+        interceptors.append(ServiceAuthInterceptor(secret))
+    else:
+        logging.warning("JOB_SERVICE_TOKEN_SECRET not set, accepting unauthenticated calls")
+    server = grpc.server(futures.ThreadPoolExecutor(max_workers=10), interceptors=interceptors)
     job_pb2_grpc.add_JobServiceServicer_to_server(JobServiceServicer(), server)
-    server.add_insecure_port('[::]:50051')
+    cert_file = os.environ.get("JOB_SERVICE_CERT_FILE")
+    if cert_file:
+        # Clients must present a certificate signed by the CA
+        credentials = grpc.ssl_server_credentials(
+            [(_read(os.environ["JOB_SERVICE_KEY_FILE"]), _read(cert_file))],
+            root_certificates=_read(os.environ["JOB_SERVICE_CA_FILE"]),
+            require_client_auth=True,
+        )
+        server.add_secure_port(address, credentials)
+    else:
+        logging.warning("JOB_SERVICE_CERT_FILE not set, listening without TLS")
+        server.add_insecure_port(address)
     server.start()
-    print("Server started, listening on port 50051.")
+    print(f"Server started, listening on {address}.")
     server.wait_for_termination()
 
 if __name__ == '__main__':
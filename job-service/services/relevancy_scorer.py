@@ -0,0 +1,49 @@
+import logging
+import re
+from typing import List
+
+# Configure logging
+logging.basicConfig(level=logging.INFO)
+logger = logging.getLogger(__name__)
+
+
+class RelevancyScorer:
+    def __init__(self, skill_weight: float = 0.8, experience_weight: float = 0.2):
+        """
+        Scores a job description against the skills and experience of a resume.
+        The score is between 0 and 1.
+        """
+        self.skill_weight = skill_weight
+        self.experience_weight = experience_weight
+
+    def _skill_overlap(self, resume_skills: List[str], job_description: str) -> float:
+        skills = {s.strip().lower() for s in resume_skills if s.strip()}
+        if not skills:
+            return 0.0
+        description = job_description.lower()
+        matched = 0
+        for skill in skills:
+            # Word boundaries are not used for skills such as "c++" or ".net"
+            if re.search(r'(?<![\w])' + re.escape(skill) + r'(?![\w])', description):
+                matched += 1
+        return matched / len(skills)
+
+    def _experience_match(self, resume_experience: str, job_description: str) -> float:
+        try:
+            years = float(resume_experience)
+        except (TypeError, ValueError):
+            return 0.0
+        required = re.findall(r'(\d+)\+?\s*(?:years|yrs)', job_description.lower())
+        if not required:
+            return 1.0
+        needed = min(int(r) for r in required)
+        if needed == 0:
+            return 1.0
+        return min(years / needed, 1.0)
+
+    def score(self, resume_skills: List[str], resume_experience: str, job_description: str) -> float:
+        if not job_description:
+            raise ValueError("job description is empty")
+        skill_score = self._skill_overlap(resume_skills, job_description)
+        experience_score = self._experience_match(resume_experience, job_description)
+        return round(self.skill_weight * skill_score + self.experience_weight * experience_score, 4)
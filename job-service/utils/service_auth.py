@@ -0,0 +1,98 @@
+import base64
+import hashlib
+import hmac
+import json
+import logging
+import time
+
+import grpc
+
+# Must match Issuer and Audience in backend/utils/grpcauth
+ISSUER = "inquiro-backend"
+AUDIENCE = "job-service"
+# Tolerated clock difference between the backend and this service
+CLOCK_SKEW_SECONDS = 30
+
+logger = logging.getLogger(__name__)
+
+
+class InvalidToken(Exception):
+    pass
+
+
+def _b64decode(part: str) -> bytes:
+    return base64.urlsafe_b64decode(part + "=" * (-len(part) % 4))
+
+
+def verify_token(token: str, secret: bytes, method: str) -> dict:
+    """Checks an HS256 service token minted by the backend for this method."""
+    try:
+        header, payload, signature = token.split(".")
+    except ValueError:
+        raise InvalidToken("malformed token")
+    expected = hmac.new(secret, f"{header}.{payload}".encode(), hashlib.sha256).digest()
+    try:
+        if not hmac.compare_digest(expected, _b64decode(signature)):
+            raise InvalidToken("bad signature")
+        if json.loads(_b64decode(header)).get("alg") != "HS256":
+            raise InvalidToken("unexpected algorithm")
+        claims = json.loads(_b64decode(payload))
+    except (ValueError, TypeError):
+        raise InvalidToken("malformed token")
+    now = time.time()
+    # aud may be a single string or a list of them
+    audience = claims.get("aud")
+    if isinstance(audience, str):
+        audience = [audience]
+    if claims.get("iss") != ISSUER or not isinstance(audience, list) or AUDIENCE not in audience:
+        raise InvalidToken("wrong issuer or audience")
+    if claims.get("sub") != method:
+        raise InvalidToken("token minted for another method")
+    if claims.get("exp", 0) < now - CLOCK_SKEW_SECONDS or claims.get("iat", 0) > now + CLOCK_SKEW_SECONDS:
+        raise InvalidToken("token expired")
+    return claims
+
+
+def _abort_handler(handler, details):
+    def abort(request, context):
+        context.abort(grpc.StatusCode.UNAUTHENTICATED, details)
+
+    kwargs = dict(request_deserializer=handler.request_deserializer,
+                  response_serializer=handler.response_serializer)
+    if handler.unary_unary:
+        return grpc.unary_unary_rpc_method_handler(abort, **kwargs)
+    if handler.unary_stream:
+        return grpc.unary_stream_rpc_method_handler(abort, **kwargs)
+    if handler.stream_unary:
+        return grpc.stream_unary_rpc_method_handler(abort, **kwargs)
+    return grpc.stream_stream_rpc_method_handler(abort, **kwargs)
+
+
+class ServiceAuthInterceptor(grpc.ServerInterceptor):
+    """Rejects calls without a valid service token and logs the request id
+    the backend sends, so a call can be traced across both services."""
+
+    def __init__(self, secret: str):
+        self.secret = secret.encode()
+
+    def intercept_service(self, continuation, handler_call_details):
+        metadata = dict(handler_call_details.invocation_metadata or ())
+        method = handler_call_details.method
+        request_id = metadata.get("x-request-id", "-")
+        handler = continuation(handler_call_details)
+        if handler is None:
+            return None
+        auth = metadata.get("authorization", "")
+        if not auth.startswith("Bearer "):
+            logger.warning(f"[{request_id}] {method} rejected: missing service token")
+            return _abort_handler(handler, "missing service token")
+        try:
+            claims = verify_token(auth[len("Bearer "):], self.secret, method)
+        except InvalidToken as e:
+            logger.warning(f"[{request_id}] {method} rejected: {e}")
+            return _abort_handler(handler, "invalid service token")
+        if claims.get("rid") != request_id:
+            logger.warning(f"[{request_id}] {method} rejected: request id does not match the token")
+            return _abort_handler(handler, "invalid service token")
+        logger.info(f"[{request_id}] {method}")
+        return handler